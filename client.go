@@ -0,0 +1,544 @@
+// Package squads is the high-level SDK for the Squads v4 multisig program.
+//
+// A Client bundles the RPC connection, the program deployment and the default
+// commitment so that every operation runs against the same cluster and program.
+package squads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+	"github.com/hogyzen12/squads-go/pkg/spendinglimit"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// Client exposes every multisig, proposal and transaction operation against a
+// single cluster and program deployment.
+type Client struct {
+	RPC        *rpc.Client
+	WS         *ws.Client // optional; notifies confirmations sooner than polling
+	ProgramID  solana.PublicKey
+	Commitment rpc.CommitmentType
+	FeePayer   signer.Signer // optional; pays fees and rent instead of the acting member
+	Nonce      *sender.Nonce // optional; durable nonce used by every transaction
+
+	ComputeBudget *sender.ComputeBudget // optional; priority fee and compute unit limit
+	Confirmation  *sender.Confirmation  // optional; commitment target and retry behaviour
+
+	ownsWS bool
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithWebsocket sets the websocket client used to learn about confirmations
+// without waiting for the next status poll.
+func WithWebsocket(wsClient *ws.Client) Option {
+	return func(c *Client) {
+		c.WS = wsClient
+	}
+}
+
+// WithProgramID targets a custom Squads deployment.
+func WithProgramID(programID solana.PublicKey) Option {
+	return func(c *Client) {
+		c.ProgramID = programID
+	}
+}
+
+// WithCommitment sets the commitment used for reads, preflight and confirmation.
+func WithCommitment(commitment rpc.CommitmentType) Option {
+	return func(c *Client) {
+		c.Commitment = commitment
+	}
+}
+
+// WithFeePayer makes payer cover transaction fees and rent for every operation.
+func WithFeePayer(payer signer.Signer) Option {
+	return func(c *Client) {
+		c.FeePayer = payer
+	}
+}
+
+// WithNonce makes every transaction use a durable nonce instead of a recent
+// blockhash. A nonce set on an individual input takes precedence.
+func WithNonce(nonce *sender.Nonce) Option {
+	return func(c *Client) {
+		c.Nonce = nonce
+	}
+}
+
+// WithComputeBudget adds priority fee and compute unit limit instructions to
+// every transaction. A budget set on an individual input takes precedence.
+func WithComputeBudget(budget *sender.ComputeBudget) Option {
+	return func(c *Client) {
+		c.ComputeBudget = budget
+	}
+}
+
+// WithConfirmation tunes how operations wait for their transactions to land.
+func WithConfirmation(confirmation *sender.Confirmation) Option {
+	return func(c *Client) {
+		c.Confirmation = confirmation
+	}
+}
+
+// NewClient creates a Client around an existing RPC client.
+func NewClient(rpcClient *rpc.Client, opts ...Option) *Client {
+	c := &Client{
+		RPC:        rpcClient,
+		ProgramID:  multisig.DefaultProgramID,
+		Commitment: sender.DefaultCommitment,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Dial connects to a cluster's RPC and websocket endpoints.
+// The websocket connection is closed by Close.
+func Dial(ctx context.Context, cluster Cluster, opts ...Option) (*Client, error) {
+	if cluster.RPC == "" {
+		return nil, errors.New("cluster RPC endpoint is required")
+	}
+
+	c := NewClient(rpc.New(cluster.RPC), opts...)
+	if c.WS == nil && cluster.WS != "" {
+		wsClient, err := ws.Connect(ctx, cluster.WS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to WebSocket: %w", err)
+		}
+		c.WS = wsClient
+		c.ownsWS = true
+	}
+	return c, nil
+}
+
+// Close releases the websocket connection opened by Dial.
+func (c *Client) Close() {
+	if c.ownsWS && c.WS != nil {
+		c.WS.Close()
+		c.WS = nil
+	}
+}
+
+// Options returns the connection settings passed to the lower-level packages.
+func (c *Client) Options() sender.Options {
+	return sender.Options{
+		Client:     c.RPC,
+		WsClient:   c.WS,
+		Commitment: c.Commitment,
+		FeePayer:   c.FeePayer,
+		Nonce:      c.Nonce,
+
+		ComputeBudget: c.ComputeBudget,
+		Confirmation:  c.Confirmation,
+	}
+}
+
+// mergeOptions fills in the connection settings of an SDK input from the
+// client while keeping the per-call fee payer, nonce, compute budget,
+// confirmation settings and address tables, if any.
+func (c *Client) mergeOptions(opts sender.Options) sender.Options {
+	merged := c.Options()
+	if opts.FeePayer != nil {
+		merged.FeePayer = opts.FeePayer
+	}
+	if opts.Nonce != nil {
+		merged.Nonce = opts.Nonce
+	}
+	if opts.ComputeBudget != nil {
+		merged.ComputeBudget = opts.ComputeBudget
+	}
+	if opts.Confirmation != nil {
+		merged.Confirmation = opts.Confirmation
+	}
+	merged.AddressTables = opts.AddressTables
+	return merged
+}
+
+// ProgramConfigPDA derives the program config address.
+func (c *Client) ProgramConfigPDA() (solana.PublicKey, uint8, error) {
+	return pda.ProgramConfig(c.ProgramID)
+}
+
+// MultisigPDA derives the multisig address for a create key.
+func (c *Client) MultisigPDA(createKey solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return pda.Multisig(createKey, c.ProgramID)
+}
+
+// VaultPDA derives a vault address of a multisig.
+func (c *Client) VaultPDA(multisigPDA solana.PublicKey, vaultIndex uint8) (solana.PublicKey, uint8, error) {
+	return pda.Vault(multisigPDA, vaultIndex, c.ProgramID)
+}
+
+// TransactionPDA derives the transaction address at an index.
+func (c *Client) TransactionPDA(multisigPDA solana.PublicKey, transactionIndex uint64) (solana.PublicKey, uint8, error) {
+	return pda.Transaction(multisigPDA, transactionIndex, c.ProgramID)
+}
+
+// ProposalPDA derives the proposal address at an index.
+func (c *Client) ProposalPDA(multisigPDA solana.PublicKey, transactionIndex uint64) (solana.PublicKey, uint8, error) {
+	return pda.Proposal(multisigPDA, transactionIndex, c.ProgramID)
+}
+
+// BatchTransactionPDA derives a transaction inside the batch at batchIndex.
+func (c *Client) BatchTransactionPDA(multisigPDA solana.PublicKey, batchIndex uint64, innerIndex uint32) (solana.PublicKey, uint8, error) {
+	return pda.BatchTransaction(multisigPDA, batchIndex, innerIndex, c.ProgramID)
+}
+
+// EphemeralSignerPDA derives an ephemeral signer of a vault or batch transaction.
+func (c *Client) EphemeralSignerPDA(transactionPDA solana.PublicKey, signerIndex uint8) (solana.PublicKey, uint8, error) {
+	return pda.EphemeralSigner(transactionPDA, signerIndex, c.ProgramID)
+}
+
+// SpendingLimitPDA derives the spending limit seeded by createKey.
+func (c *Client) SpendingLimitPDA(multisigPDA, createKey solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return pda.SpendingLimit(multisigPDA, createKey, c.ProgramID)
+}
+
+// TransactionBufferPDA derives a transaction buffer of creator.
+func (c *Client) TransactionBufferPDA(multisigPDA, creator solana.PublicKey, bufferIndex uint8) (solana.PublicKey, uint8, error) {
+	return pda.TransactionBuffer(multisigPDA, creator, bufferIndex, c.ProgramID)
+}
+
+// FetchProgramConfig fetches the program config of the client's deployment.
+func (c *Client) FetchProgramConfig(ctx context.Context) (*squads_multisig_program.ProgramConfig, error) {
+	address, _, err := c.ProgramConfigPDA()
+	if err != nil {
+		return nil, err
+	}
+	return accounts.FetchProgramConfig(ctx, c.Options(), address)
+}
+
+// FetchMultisig fetches a multisig account.
+func (c *Client) FetchMultisig(ctx context.Context, multisigPDA solana.PublicKey) (*squads_multisig_program.Multisig, error) {
+	return accounts.FetchMultisig(ctx, c.Options(), multisigPDA)
+}
+
+// FetchProposal fetches the proposal at a transaction index.
+func (c *Client) FetchProposal(ctx context.Context, multisigPDA solana.PublicKey, transactionIndex uint64) (*squads_multisig_program.Proposal, error) {
+	address, _, err := c.ProposalPDA(multisigPDA, transactionIndex)
+	if err != nil {
+		return nil, err
+	}
+	return accounts.FetchProposal(ctx, c.Options(), address)
+}
+
+// FetchVaultTransaction fetches the vault transaction at a transaction index.
+func (c *Client) FetchVaultTransaction(ctx context.Context, multisigPDA solana.PublicKey, transactionIndex uint64) (*squads_multisig_program.VaultTransaction, error) {
+	address, _, err := c.TransactionPDA(multisigPDA, transactionIndex)
+	if err != nil {
+		return nil, err
+	}
+	return accounts.FetchVaultTransaction(ctx, c.Options(), address)
+}
+
+// FetchConfigTransaction fetches the config transaction at a transaction index.
+func (c *Client) FetchConfigTransaction(ctx context.Context, multisigPDA solana.PublicKey, transactionIndex uint64) (*squads_multisig_program.ConfigTransaction, error) {
+	address, _, err := c.TransactionPDA(multisigPDA, transactionIndex)
+	if err != nil {
+		return nil, err
+	}
+	return accounts.FetchConfigTransaction(ctx, c.Options(), address)
+}
+
+// FetchBatch fetches the batch at a transaction index.
+func (c *Client) FetchBatch(ctx context.Context, multisigPDA solana.PublicKey, transactionIndex uint64) (*squads_multisig_program.Batch, error) {
+	address, _, err := c.TransactionPDA(multisigPDA, transactionIndex)
+	if err != nil {
+		return nil, err
+	}
+	return accounts.FetchBatch(ctx, c.Options(), address)
+}
+
+// ListMultisigs finds the multisigs of the client's program that match
+// filter, such as every multisig a wallet is a member of.
+func (c *Client) ListMultisigs(ctx context.Context, filter accounts.MultisigFilter) ([]accounts.KeyedMultisig, error) {
+	return accounts.ListMultisigs(ctx, c.Options(), c.ProgramID, filter)
+}
+
+// ListSpendingLimits finds the spending limits of a multisig. Use
+// spendinglimit.Available and spendinglimit.NextReset to see what each
+// allows right now.
+func (c *Client) ListSpendingLimits(ctx context.Context, multisigPDA solana.PublicKey) ([]accounts.KeyedSpendingLimit, error) {
+	return accounts.ListSpendingLimits(ctx, c.Options(), c.ProgramID, multisigPDA)
+}
+
+// FetchAccount fetches any account owned by the client's program and decodes
+// it according to its discriminator; see accounts.DecodeAny.
+func (c *Client) FetchAccount(ctx context.Context, address solana.PublicKey) (interface{}, error) {
+	accountInfo, err := c.Options().GetAccountInfo(ctx, address)
+	if errors.Is(err, rpc.ErrNotFound) {
+		return nil, fmt.Errorf("account %s: %w", address, accounts.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account %s: %w", address, err)
+	}
+	if !accountInfo.Value.Owner.Equals(c.ProgramID) {
+		return nil, fmt.Errorf("account %s is owned by %s, not the Squads program %s", address, accountInfo.Value.Owner, c.ProgramID)
+	}
+	return accounts.DecodeAny(accountInfo.Value.Data.GetBinary())
+}
+
+// CreateMultisig creates a new multisig seeded by createKey and waits for confirmation.
+func (c *Client) CreateMultisig(
+	ctx context.Context,
+	payer signer.Signer,
+	createKey signer.Signer,
+	members []squads_multisig_program.Member,
+	threshold uint16,
+	timeLock uint32,
+) (solana.Signature, solana.PublicKey, error) {
+	sigStr, multisigPDA, err := multisig.CreateMultisig(
+		ctx,
+		c.Options(),
+		payer,
+		createKey,
+		members,
+		threshold,
+		timeLock,
+		c.ProgramID,
+	)
+	if err != nil {
+		return solana.Signature{}, solana.PublicKey{}, DecodeError(err, c.ProgramID)
+	}
+	sig, err := solana.SignatureFromBase58(sigStr)
+	return sig, multisigPDA, err
+}
+
+// CreateNonceAccount creates a durable nonce account at the address of
+// nonceAccount, controlled by authority. Pass a vault PDA as the authority to
+// have the multisig own the nonce.
+func (c *Client) CreateNonceAccount(ctx context.Context, funder, nonceAccount signer.Signer, authority solana.PublicKey) (solana.Signature, error) {
+	return c.Options().CreateNonceAccount(ctx, funder, nonceAccount, authority)
+}
+
+// FetchNonce fetches a durable nonce account.
+func (c *Client) FetchNonce(ctx context.Context, account solana.PublicKey) (*system.NonceAccount, error) {
+	return c.Options().FetchNonce(ctx, account)
+}
+
+// Administer changes a controlled multisig directly, signed by its config
+// authority.
+func (c *Client) Administer(ctx context.Context, input multisig.AdminInput) (*multisig.AdminOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := multisig.Administer(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// PrepareAdminister builds the admin transaction without submitting it,
+// leaving offline signers' signatures empty.
+func (c *Client) PrepareAdminister(ctx context.Context, input multisig.AdminInput) (*solana.Transaction, *multisig.AdminOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	tx, out, err := multisig.PrepareAdminister(ctx, input)
+	return tx, out, DecodeError(err, c.ProgramID)
+}
+
+// CreateVaultTransaction proposes a vault transaction. Connection settings in
+// the input are replaced by the client's; per-call fee payer, nonce, compute
+// budget and confirmation settings are kept.
+//
+// Failures raised by the Squads program match the Err* values with errors.Is,
+// here and in every other operation of the Client.
+func (c *Client) CreateVaultTransaction(ctx context.Context, input transaction.VaultTransactionCreateInput) (*transaction.VaultTransactionCreateOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := transaction.CreateVaultTransaction(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// VoteOnProposal approves, rejects or cancels a proposal.
+func (c *Client) VoteOnProposal(ctx context.Context, input transaction.ProposalVoteInput) (*transaction.ProposalVoteOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := transaction.VoteOnProposal(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// ActivateProposal opens a draft proposal for voting.
+func (c *Client) ActivateProposal(ctx context.Context, input transaction.ProposalActivateInput) (*transaction.ProposalActivateOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := transaction.ActivateProposal(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// ExecuteProposal executes an approved vault transaction.
+func (c *Client) ExecuteProposal(ctx context.Context, input transaction.ProposalExecuteInput) (*transaction.ProposalExecuteOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := transaction.ExecuteProposal(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// CreateConfigTransaction proposes a change to the settings of an autonomous
+// multisig.
+func (c *Client) CreateConfigTransaction(ctx context.Context, input transaction.ConfigTransactionCreateInput) (*transaction.ConfigTransactionCreateOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := transaction.CreateConfigTransaction(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// ExecuteConfigTransaction applies an approved config transaction.
+func (c *Client) ExecuteConfigTransaction(ctx context.Context, input transaction.ConfigTransactionExecuteInput) (*transaction.ConfigTransactionExecuteOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := transaction.ExecuteConfigTransaction(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// UseSpendingLimit transfers from a vault through a spending limit, without
+// a proposal.
+func (c *Client) UseSpendingLimit(ctx context.Context, input spendinglimit.UseInput) (*spendinglimit.UseOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := spendinglimit.Use(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// CloseTransactionBuffer closes a transaction buffer left by an abandoned
+// upload, refunding its rent to the creator.
+func (c *Client) CloseTransactionBuffer(ctx context.Context, input transaction.TransactionBufferCloseInput) (*transaction.TransactionBufferCloseOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := transaction.CloseTransactionBuffer(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// CreateBatch proposes several transactions as one batch covered by a single
+// approval.
+func (c *Client) CreateBatch(ctx context.Context, input transaction.BatchCreateInput) (*transaction.BatchCreateOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := transaction.CreateBatch(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// ExecuteBatch executes the remaining transactions of an approved batch,
+// resuming after the last one executed.
+func (c *Client) ExecuteBatch(ctx context.Context, input transaction.BatchExecuteInput) (*transaction.BatchExecuteOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := transaction.ExecuteBatch(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// Cleanup closes the accounts of executed, rejected, cancelled and stale
+// transactions, sending their rent to the multisig's rent collector.
+func (c *Client) Cleanup(ctx context.Context, input transaction.CleanupInput) (*transaction.CleanupOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	out, err := transaction.Cleanup(ctx, input)
+	return out, DecodeError(err, c.ProgramID)
+}
+
+// ListProposals returns one page of the proposals of a multisig, newest
+// first; pass the page's NextCursor as input.Cursor for the next one.
+func (c *Client) ListProposals(ctx context.Context, input transaction.ListProposalsInput) (*transaction.ProposalPage, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	return transaction.ListProposals(ctx, input)
+}
+
+// PrepareVaultTransaction builds the create transaction without submitting it,
+// leaving offline signers' signatures empty.
+func (c *Client) PrepareVaultTransaction(ctx context.Context, input transaction.VaultTransactionCreateInput) (*solana.Transaction, *transaction.VaultTransactionCreateOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	tx, out, err := transaction.PrepareVaultTransaction(ctx, input)
+	return tx, out, DecodeError(err, c.ProgramID)
+}
+
+// PrepareCloseTransactionBuffer builds the buffer close transaction without
+// submitting it, leaving offline signers' signatures empty.
+func (c *Client) PrepareCloseTransactionBuffer(ctx context.Context, input transaction.TransactionBufferCloseInput) (*solana.Transaction, *transaction.TransactionBufferCloseOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	tx, out, err := transaction.PrepareCloseTransactionBuffer(ctx, input)
+	return tx, out, DecodeError(err, c.ProgramID)
+}
+
+// PrepareVote builds the voting transaction without submitting it, leaving
+// offline signers' signatures empty.
+func (c *Client) PrepareVote(ctx context.Context, input transaction.ProposalVoteInput) (*solana.Transaction, *transaction.ProposalVoteOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	tx, out, err := transaction.PrepareVote(ctx, input)
+	return tx, out, DecodeError(err, c.ProgramID)
+}
+
+// PrepareActivateProposal builds the activation transaction without
+// submitting it, leaving offline signers' signatures empty.
+func (c *Client) PrepareActivateProposal(ctx context.Context, input transaction.ProposalActivateInput) (*solana.Transaction, *transaction.ProposalActivateOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	tx, out, err := transaction.PrepareActivateProposal(ctx, input)
+	return tx, out, DecodeError(err, c.ProgramID)
+}
+
+// PrepareExecute builds the execute transaction without submitting it,
+// leaving offline signers' signatures empty.
+func (c *Client) PrepareExecute(ctx context.Context, input transaction.ProposalExecuteInput) (*solana.Transaction, *transaction.ProposalExecuteOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	tx, out, err := transaction.PrepareExecute(ctx, input)
+	return tx, out, DecodeError(err, c.ProgramID)
+}
+
+// PrepareConfigTransaction builds the config transaction proposal without
+// submitting it, leaving offline signers' signatures empty.
+func (c *Client) PrepareConfigTransaction(ctx context.Context, input transaction.ConfigTransactionCreateInput) (*solana.Transaction, *transaction.ConfigTransactionCreateOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	tx, out, err := transaction.PrepareConfigTransaction(ctx, input)
+	return tx, out, DecodeError(err, c.ProgramID)
+}
+
+// PrepareConfigExecute builds the config execute transaction without
+// submitting it, leaving offline signers' signatures empty.
+func (c *Client) PrepareConfigExecute(ctx context.Context, input transaction.ConfigTransactionExecuteInput) (*solana.Transaction, *transaction.ConfigTransactionExecuteOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	tx, out, err := transaction.PrepareConfigExecute(ctx, input)
+	return tx, out, DecodeError(err, c.ProgramID)
+}
+
+// PrepareUseSpendingLimit builds the spending limit transaction without
+// submitting it, leaving offline signers' signatures empty.
+func (c *Client) PrepareUseSpendingLimit(ctx context.Context, input spendinglimit.UseInput) (*solana.Transaction, *spendinglimit.UseOutput, error) {
+	input.ProgramID = c.ProgramID
+	input.Options = c.mergeOptions(input.Options)
+	tx, out, err := spendinglimit.PrepareUse(ctx, input)
+	return tx, out, DecodeError(err, c.ProgramID)
+}
+
+// Submit broadcasts a fully signed transaction, such as one signed offline,
+// and waits for it to land. It cannot re-sign, so an expired blockhash is
+// reported as sender.StatusExpired.
+func (c *Client) Submit(ctx context.Context, tx *solana.Transaction) (*sender.Result, error) {
+	if missing := signer.Missing(tx); len(missing) > 0 {
+		return nil, fmt.Errorf("transaction is missing %d signature(s), first from %s", len(missing), missing[0])
+	}
+	if err := tx.VerifySignatures(); err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	result, err := c.Options().SubmitAndConfirm(ctx, tx)
+	return result, DecodeError(err, c.ProgramID)
+}
@@ -0,0 +1,34 @@
+package squads
+
+import "fmt"
+
+// Cluster names a pair of RPC and websocket endpoints.
+type Cluster struct {
+	Name string
+	RPC  string
+	WS   string
+}
+
+// Well-known public clusters.
+var (
+	MainnetBeta = Cluster{Name: "mainnet-beta", RPC: "https://api.mainnet-beta.solana.com", WS: "wss://api.mainnet-beta.solana.com"}
+	Devnet      = Cluster{Name: "devnet", RPC: "https://api.devnet.solana.com", WS: "wss://api.devnet.solana.com"}
+	Testnet     = Cluster{Name: "testnet", RPC: "https://api.testnet.solana.com", WS: "wss://api.testnet.solana.com"}
+	Localnet    = Cluster{Name: "localnet", RPC: "http://127.0.0.1:8899", WS: "ws://127.0.0.1:8900"}
+)
+
+// ClusterByName looks up one of the well-known clusters.
+func ClusterByName(name string) (Cluster, error) {
+	switch name {
+	case "mainnet-beta", "mainnet":
+		return MainnetBeta, nil
+	case "devnet":
+		return Devnet, nil
+	case "testnet":
+		return Testnet, nil
+	case "localnet", "localhost":
+		return Localnet, nil
+	default:
+		return Cluster{}, fmt.Errorf("unknown cluster %q (expected mainnet-beta, devnet, testnet or localnet)", name)
+	}
+}
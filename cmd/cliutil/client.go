@@ -0,0 +1,134 @@
+package cliutil
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/spf13/cobra"
+
+	squads "github.com/hogyzen12/squads-go"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+// ResolveCluster works out the RPC and websocket endpoints from the global
+// --cluster, --rpc and --ws flags. Explicit --rpc/--ws values win over --cluster.
+func ResolveCluster(cmd *cobra.Command) (squads.Cluster, error) {
+	flags := cmd.Flags()
+	rpcEndpoint, _ := flags.GetString("rpc")
+	wsEndpoint, _ := flags.GetString("ws")
+	clusterName, _ := flags.GetString("cluster")
+
+	cluster := squads.Cluster{Name: "custom", RPC: rpcEndpoint, WS: wsEndpoint}
+	if clusterName != "" {
+		named, err := squads.ClusterByName(clusterName)
+		if err != nil {
+			return squads.Cluster{}, err
+		}
+		cluster.Name = named.Name
+		if !flags.Changed("rpc") {
+			cluster.RPC = named.RPC
+		}
+		if !flags.Changed("ws") {
+			cluster.WS = named.WS
+		}
+	}
+	return cluster, nil
+}
+
+// NewClient builds a squads.Client from the global flags. When websocket is
+// true a websocket connection is opened too; callers must Close the client.
+func NewClient(ctx context.Context, cmd *cobra.Command, websocket bool) (*squads.Client, error) {
+	cluster, err := ResolveCluster(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := cmd.Flags()
+	programIDStr, _ := flags.GetString("program-id")
+	commitment, _ := flags.GetString("commitment")
+	nonceAccountStr, _ := flags.GetString("nonce-account")
+	nonceAuthority, _ := flags.GetString("nonce-authority")
+	priorityFee, _ := flags.GetString("priority-fee")
+	unitLimit, _ := flags.GetString("compute-unit-limit")
+
+	var opts []squads.Option
+	if programIDStr != "" {
+		programID, err := solana.PublicKeyFromBase58(programIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid program ID: %w", err)
+		}
+		opts = append(opts, squads.WithProgramID(programID))
+	}
+	switch rpc.CommitmentType(commitment) {
+	case rpc.CommitmentProcessed, rpc.CommitmentConfirmed, rpc.CommitmentFinalized:
+		opts = append(opts, squads.WithCommitment(rpc.CommitmentType(commitment)))
+	default:
+		return nil, fmt.Errorf("invalid commitment %q (expected processed, confirmed or finalized)", commitment)
+	}
+
+	if nonceAccountStr != "" {
+		nonceAccount, err := solana.PublicKeyFromBase58(nonceAccountStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce account: %w", err)
+		}
+		nonce := &sender.Nonce{Account: nonceAccount}
+		if nonceAuthority != "" {
+			nonce.Authority, err = LoadSigner(ctx, nonceAuthority)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load nonce authority: %w", err)
+			}
+		}
+		opts = append(opts, squads.WithNonce(nonce))
+	}
+
+	budget, err := parseComputeBudget(priorityFee, unitLimit)
+	if err != nil {
+		return nil, err
+	}
+	if budget != nil {
+		opts = append(opts, squads.WithComputeBudget(budget))
+	}
+
+	if !websocket {
+		cluster.WS = ""
+	}
+	return squads.Dial(ctx, cluster, opts...)
+}
+
+// parseComputeBudget turns the --priority-fee and --compute-unit-limit values
+// into a compute budget. Either may be a number or "auto"; nil means neither
+// flag was set.
+func parseComputeBudget(priorityFee, unitLimit string) (*sender.ComputeBudget, error) {
+	if priorityFee == "" && unitLimit == "" {
+		return nil, nil
+	}
+
+	budget := &sender.ComputeBudget{}
+	switch priorityFee {
+	case "":
+	case "auto":
+		budget.AutoUnitPrice = true
+	default:
+		price, err := strconv.ParseUint(priorityFee, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority fee %q: expected micro-lamports or \"auto\"", priorityFee)
+		}
+		budget.UnitPrice = price
+	}
+
+	switch unitLimit {
+	case "":
+	case "auto":
+		budget.AutoUnitLimit = true
+	default:
+		limit, err := strconv.ParseUint(unitLimit, 10, 32)
+		if err != nil || limit > sender.MaxComputeUnitLimit {
+			return nil, fmt.Errorf("invalid compute unit limit %q: expected up to %d or \"auto\"", unitLimit, sender.MaxComputeUnitLimit)
+		}
+		budget.UnitLimit = uint32(limit)
+	}
+	return budget, nil
+}
@@ -0,0 +1,246 @@
+package cliutil
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+)
+
+// ParsePeriod parses a spending limit reset period: one-time, day, week or month.
+func ParsePeriod(value string) (squads_multisig_program.Period, error) {
+	switch strings.ToLower(value) {
+	case "one-time", "onetime":
+		return squads_multisig_program.PeriodOneTime, nil
+	case "day":
+		return squads_multisig_program.PeriodDay, nil
+	case "week":
+		return squads_multisig_program.PeriodWeek, nil
+	case "month":
+		return squads_multisig_program.PeriodMonth, nil
+	}
+	return 0, fmt.Errorf("unknown period %q (one-time, day, week or month)", value)
+}
+
+// ParseKeys parses a list of base58 public keys.
+func ParseKeys(values []string) ([]solana.PublicKey, error) {
+	keys := make([]solana.PublicKey, 0, len(values))
+	for _, value := range values {
+		key, err := solana.PublicKeyFromBase58(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %q: %w", value, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// DescribeConfigAction renders a config action as one line.
+func DescribeConfigAction(action squads_multisig_program.ConfigAction) string {
+	switch action := action.(type) {
+	case *squads_multisig_program.ConfigActionAddMember:
+		return fmt.Sprintf("Add member %s (permissions %d)", action.NewMember.Key, action.NewMember.Permissions.Mask)
+	case *squads_multisig_program.ConfigActionRemoveMember:
+		return fmt.Sprintf("Remove member %s", action.OldMember)
+	case *squads_multisig_program.ConfigActionChangeThreshold:
+		return fmt.Sprintf("Change threshold to %d", action.NewThreshold)
+	case *squads_multisig_program.ConfigActionSetTimeLock:
+		return fmt.Sprintf("Set time lock to %d seconds", action.NewTimeLock)
+	case *squads_multisig_program.ConfigActionAddSpendingLimit:
+		mint := "SOL"
+		if !action.Mint.IsZero() {
+			mint = action.Mint.String()
+		}
+		return fmt.Sprintf("Add spending limit of %d %s per %s from vault %d (create key %s, %d members)",
+			action.Amount, mint, action.Period, action.VaultIndex, action.CreateKey, len(action.Members))
+	case *squads_multisig_program.ConfigActionRemoveSpendingLimit:
+		return fmt.Sprintf("Remove spending limit %s", action.SpendingLimit)
+	case *squads_multisig_program.ConfigActionSetRentCollector:
+		if action.NewRentCollector == nil {
+			return "Clear rent collector"
+		}
+		return fmt.Sprintf("Set rent collector to %s", *action.NewRentCollector)
+	default:
+		return fmt.Sprintf("Unknown action %T", action)
+	}
+}
+
+// ConfigActionCommand describes one config action as a subcommand, shared by
+// "config propose" and "admin".
+type ConfigActionCommand struct {
+	Name    string // subcommand name, e.g. "add-member"
+	Summary string // lower-case verb phrase, e.g. "add a member"
+	Example string // action flags for the usage example
+	// AddFlags registers the action's own flags.
+	AddFlags func(cmd *cobra.Command)
+	// Action builds the config action from the parsed flags.
+	Action func(cmd *cobra.Command) squads_multisig_program.ConfigAction
+}
+
+// ConfigActionCommands lists every config action in the order the program
+// defines them.
+var ConfigActionCommands = []ConfigActionCommand{
+	{
+		Name:    "add-member",
+		Summary: "add a member",
+		Example: "--member NEW_MEMBER --permissions 7",
+		AddFlags: func(cmd *cobra.Command) {
+			cmd.Flags().String("member", "", "Public key of the new member (REQUIRED)")
+			cmd.Flags().Uint8("permissions", 7, "Permissions of the new member (1=Propose, 2=Vote, 4=Execute, 7=Full)")
+			cmd.MarkFlagRequired("member")
+		},
+		Action: func(cmd *cobra.Command) squads_multisig_program.ConfigAction {
+			permissions, _ := cmd.Flags().GetUint8("permissions")
+			if permissions == 0 || permissions > 7 {
+				log.Fatalf("--permissions must be between 1 and 7")
+			}
+			return &squads_multisig_program.ConfigActionAddMember{
+				NewMember: squads_multisig_program.Member{
+					Key:         KeyFlag(cmd, "member"),
+					Permissions: squads_multisig_program.Permissions{Mask: permissions},
+				},
+			}
+		},
+	},
+	{
+		Name:    "remove-member",
+		Summary: "remove a member",
+		Example: "--member OLD_MEMBER",
+		AddFlags: func(cmd *cobra.Command) {
+			cmd.Flags().String("member", "", "Public key of the member to remove (REQUIRED)")
+			cmd.MarkFlagRequired("member")
+		},
+		Action: func(cmd *cobra.Command) squads_multisig_program.ConfigAction {
+			return &squads_multisig_program.ConfigActionRemoveMember{OldMember: KeyFlag(cmd, "member")}
+		},
+	},
+	{
+		Name:    "change-threshold",
+		Summary: "change the approval threshold",
+		Example: "--threshold 2",
+		AddFlags: func(cmd *cobra.Command) {
+			cmd.Flags().Uint16("threshold", 0, "New number of approvals required (REQUIRED)")
+			cmd.MarkFlagRequired("threshold")
+		},
+		Action: func(cmd *cobra.Command) squads_multisig_program.ConfigAction {
+			threshold, _ := cmd.Flags().GetUint16("threshold")
+			if threshold == 0 {
+				log.Fatalf("--threshold must be at least 1")
+			}
+			return &squads_multisig_program.ConfigActionChangeThreshold{NewThreshold: threshold}
+		},
+	},
+	{
+		Name:    "set-timelock",
+		Summary: "set the time lock",
+		Example: "--seconds 86400",
+		AddFlags: func(cmd *cobra.Command) {
+			cmd.Flags().Uint32("seconds", 0, "Seconds between approval and execution; 0 disables the time lock (REQUIRED)")
+			cmd.MarkFlagRequired("seconds")
+		},
+		Action: func(cmd *cobra.Command) squads_multisig_program.ConfigAction {
+			seconds, _ := cmd.Flags().GetUint32("seconds")
+			return &squads_multisig_program.ConfigActionSetTimeLock{NewTimeLock: seconds}
+		},
+	},
+	{
+		Name:    "add-spending-limit",
+		Summary: "add a spending limit that members can use without a vote",
+		Example: "--amount 1000000000 --period day --members MEMBER1,MEMBER2",
+		AddFlags: func(cmd *cobra.Command) {
+			cmd.Flags().String("mint", "", "Token mint the limit applies to (default: SOL)")
+			cmd.Flags().Uint64("amount", 0, "Amount per period in base units, lamports for SOL (REQUIRED)")
+			cmd.Flags().String("period", "", "Reset period: one-time, day, week or month (REQUIRED)")
+			cmd.Flags().StringSlice("members", nil, "Members allowed to use the limit (REQUIRED)")
+			cmd.Flags().StringSlice("destinations", nil, "Allowed destinations (default: any)")
+			cmd.Flags().Uint8("vault-index", 0, "Vault the limit spends from (default 0)")
+			cmd.Flags().String("create-key", "", "Key seeding the spending limit address (default: a new random key)")
+			cmd.MarkFlagRequired("amount")
+			cmd.MarkFlagRequired("period")
+			cmd.MarkFlagRequired("members")
+		},
+		Action: func(cmd *cobra.Command) squads_multisig_program.ConfigAction {
+			amount, _ := cmd.Flags().GetUint64("amount")
+			periodStr, _ := cmd.Flags().GetString("period")
+			memberStrs, _ := cmd.Flags().GetStringSlice("members")
+			destinationStrs, _ := cmd.Flags().GetStringSlice("destinations")
+			vaultIndex, _ := cmd.Flags().GetUint8("vault-index")
+
+			if amount == 0 {
+				log.Fatalf("--amount must be greater than zero")
+			}
+			period, err := ParsePeriod(periodStr)
+			if err != nil {
+				log.Fatalf("Invalid --period: %v", err)
+			}
+			members, err := ParseKeys(memberStrs)
+			if err != nil {
+				log.Fatalf("Invalid --members: %v", err)
+			}
+			destinations, err := ParseKeys(destinationStrs)
+			if err != nil {
+				log.Fatalf("Invalid --destinations: %v", err)
+			}
+			var mint solana.PublicKey
+			if cmd.Flags().Changed("mint") {
+				mint = KeyFlag(cmd, "mint")
+			}
+			createKey := solana.NewWallet().PublicKey()
+			if cmd.Flags().Changed("create-key") {
+				createKey = KeyFlag(cmd, "create-key")
+			}
+			return &squads_multisig_program.ConfigActionAddSpendingLimit{
+				CreateKey:    createKey,
+				VaultIndex:   vaultIndex,
+				Mint:         mint,
+				Amount:       amount,
+				Period:       period,
+				Members:      members,
+				Destinations: destinations,
+			}
+		},
+	},
+	{
+		Name:    "remove-spending-limit",
+		Summary: "remove a spending limit",
+		Example: "--spending-limit SPENDING_LIMIT_ADDRESS",
+		AddFlags: func(cmd *cobra.Command) {
+			cmd.Flags().String("spending-limit", "", "Spending limit address (REQUIRED)")
+			cmd.MarkFlagRequired("spending-limit")
+		},
+		Action: func(cmd *cobra.Command) squads_multisig_program.ConfigAction {
+			return &squads_multisig_program.ConfigActionRemoveSpendingLimit{SpendingLimit: KeyFlag(cmd, "spending-limit")}
+		},
+	},
+	{
+		Name:    "set-rent-collector",
+		Summary: "set the account that receives rent from closed accounts",
+		Example: "--rent-collector VAULT_ADDRESS",
+		AddFlags: func(cmd *cobra.Command) {
+			cmd.Flags().String("rent-collector", "", "Rent collector address, or \"none\" to disable rent reclaiming (REQUIRED)")
+			cmd.MarkFlagRequired("rent-collector")
+		},
+		Action: func(cmd *cobra.Command) squads_multisig_program.ConfigAction {
+			value, _ := cmd.Flags().GetString("rent-collector")
+			if value == "none" {
+				return &squads_multisig_program.ConfigActionSetRentCollector{}
+			}
+			collector := KeyFlag(cmd, "rent-collector")
+			return &squads_multisig_program.ConfigActionSetRentCollector{NewRentCollector: &collector}
+		},
+	},
+}
+
+// KeyFlag parses a public key flag, exiting on invalid input.
+func KeyFlag(cmd *cobra.Command, name string) solana.PublicKey {
+	value, _ := cmd.Flags().GetString(name)
+	key, err := solana.PublicKeyFromBase58(value)
+	if err != nil {
+		log.Fatalf("Invalid --%s: %v", name, err)
+	}
+	return key
+}
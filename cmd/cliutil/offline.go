@@ -0,0 +1,66 @@
+package cliutil
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/pkg/offline"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+// AddExportFlag registers --export on commands that can hand their transaction
+// to an offline signer instead of sending it.
+func AddExportFlag(cmd *cobra.Command) {
+	cmd.Flags().String("export", "", "Write the unsigned transaction to this file for offline signing instead of sending it")
+}
+
+// ExportTransaction writes tx and its summary to path and tells the user how
+// to finish the offline flow. transactionIndex is the index of the proposal
+// tx acts on, or 0; the offline signer checks it against the transaction.
+func ExportTransaction(path string, tx *solana.Transaction, transactionIndex uint64, summary ...string) error {
+	env := offline.New(tx, summary...)
+	env.TransactionIndex = transactionIndex
+	if err := env.WriteFile(path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("        TRANSACTION EXPORTED")
+	fmt.Println("════════════════════════════════════════")
+	for _, line := range summary {
+		fmt.Println(line)
+	}
+	nonceAccount, durable := sender.UsesNonce(tx)
+	if durable {
+		fmt.Printf("Durable Nonce: %s (account %s)\n", tx.Message.RecentBlockhash, nonceAccount)
+	} else {
+		fmt.Printf("Recent Blockhash: %s\n", tx.Message.RecentBlockhash)
+	}
+	PrintSigners(env)
+	fmt.Printf("\nWritten to %s. Sign it on the offline machine with:\n", path)
+	fmt.Printf("  squads-cli sign --in %s --keypair /path/to/keypair.json\n", path)
+	fmt.Println("Then broadcast it from an online machine with:")
+	fmt.Printf("  squads-cli submit --in %s\n", path)
+	if durable {
+		fmt.Println("\nThe transaction stays valid until the nonce is advanced.")
+	} else {
+		fmt.Println("\nNote: a recent blockhash expires after roughly 60-90 seconds.")
+		fmt.Println("Use --nonce-account for signing that takes longer.")
+	}
+	return nil
+}
+
+// PrintSigners lists the required signers of an exported transaction and
+// whether each has signed.
+func PrintSigners(env *offline.Envelope) {
+	fmt.Println("\nSigners:")
+	for _, s := range env.Signers() {
+		state := "missing"
+		if s.Signed {
+			state = "signed"
+		}
+		fmt.Printf("  - %s (%s)\n", s.PublicKey, state)
+	}
+}
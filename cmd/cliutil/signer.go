@@ -0,0 +1,46 @@
+package cliutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/pkg/signer"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// SignerTokenEnv names the environment variable holding the bearer token sent
+// to remote signers.
+const SignerTokenEnv = "SQUADS_SIGNER_TOKEN"
+
+// LoadSigner resolves a --payer style value. An http(s) URL selects a remote
+// signing service and a bare public key an offline signer, usable only when
+// exporting transactions; anything else is read as a keypair JSON file.
+func LoadSigner(ctx context.Context, value string) (signer.Signer, error) {
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		var opts []signer.RemoteOption
+		if token := os.Getenv(SignerTokenEnv); token != "" {
+			opts = append(opts, signer.WithToken(token))
+		}
+		remote, err := signer.DialRemote(ctx, value, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to remote signer: %w", err)
+		}
+		return remote, nil
+	}
+
+	if _, statErr := os.Stat(value); os.IsNotExist(statErr) {
+		if publicKey, err := solana.PublicKeyFromBase58(value); err == nil {
+			return signer.NewOffline(publicKey), nil
+		}
+	}
+
+	key, err := transaction.LoadKeypair(value)
+	if err != nil {
+		return nil, err
+	}
+	return signer.NewLocal(key), nil
+}
@@ -0,0 +1,123 @@
+package configtransaction
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewExecuteCommand creates the command for executing an approved config transaction
+func NewExecuteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execute",
+		Short: "Execute an approved config transaction",
+		Long: `Execute an approved config transaction and apply its actions to the multisig.
+
+The payer covers the rent when the multisig account grows, for example when a
+member is added, and the rent of new spending limit accounts. Spending limit
+accounts created or removed by the transaction are passed automatically.
+
+Examples:
+squads-cli config execute \
+--multisig MULTISIG_ADDRESS \
+--transaction TRANSACTION_INDEX \
+--payer /path/to/payer.json
+`,
+		Run: runExecute,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().Uint64P("transaction", "t", 0, "Transaction index to execute (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Member keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+	cliutil.AddExportFlag(cmd)
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("transaction")
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+func runExecute(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	transactionIndex, _ := cmd.Flags().GetUint64("transaction")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	executor, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	input := transaction.ConfigTransactionExecuteInput{
+		Multisig:         multisigPDA,
+		TransactionIndex: transactionIndex,
+		Executor:         executor,
+	}
+
+	if exportPath != "" {
+		tx, output, err := client.PrepareConfigExecute(ctx, input)
+		if err != nil {
+			log.Fatalf("Failed to prepare execution: %v", err)
+		}
+		summary := []string{
+			"Action: execute config transaction",
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+			fmt.Sprintf("Transaction Index: %d", transactionIndex),
+			fmt.Sprintf("Transaction PDA: %s", output.TransactionPDA),
+		}
+		for _, action := range output.Actions {
+			summary = append(summary, fmt.Sprintf("Config Action: %s", cliutil.DescribeConfigAction(action)))
+		}
+		summary = append(summary, fmt.Sprintf("Executor: %s", executor.PublicKey()))
+		if err := cliutil.ExportTransaction(exportPath, tx, transactionIndex, summary...); err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.ExecuteConfigTransaction(ctxWithTimeout, input)
+	if err != nil {
+		log.Fatalf("Failed to execute config transaction: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("   CONFIG TRANSACTION EXECUTED SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", output.Signature)
+	fmt.Printf("Transaction PDA: %s\n", output.TransactionPDA)
+	fmt.Printf("Proposal PDA: %s\n", output.ProposalPDA)
+	fmt.Printf("Transaction Index: %d\n", output.TransactionIndex)
+	fmt.Println("Applied Actions:")
+	for _, action := range output.Actions {
+		fmt.Printf("  - %s\n", cliutil.DescribeConfigAction(action))
+	}
+	for _, spendingLimit := range output.SpendingLimits {
+		fmt.Printf("Spending Limit Account: %s\n", spendingLimit)
+	}
+}
@@ -0,0 +1,202 @@
+package configtransaction
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewCommand creates the command group for config transactions
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Propose and execute changes to a multisig's settings",
+		Long: `Propose and execute config transactions.
+
+Autonomous multisigs (those without a config authority) change their members,
+threshold, time lock, rent collector and spending limits through config
+transactions, which are voted on like any other proposal. Approve them with
+"squads-cli transaction approve" and apply them with "squads-cli config execute".
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		NewProposeCommand(),
+		NewExecuteCommand(),
+	)
+
+	return cmd
+}
+
+// NewProposeCommand creates the command group for proposing config actions
+func NewProposeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose",
+		Short: "Propose a config transaction",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	for _, action := range cliutil.ConfigActionCommands {
+		cmd.AddCommand(newActionCommand(action))
+	}
+
+	return cmd
+}
+
+// newActionCommand builds one "config propose" command.
+func newActionCommand(action cliutil.ConfigActionCommand) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   action.Name,
+		Short: "Propose to " + action.Summary,
+		Long: fmt.Sprintf(`Propose to %s.
+
+The config transaction and its proposal are created in one transaction and,
+unless --approve=false or --draft is given, approved by the proposer.
+
+Example:
+squads-cli config propose %s \
+--multisig MULTISIG_ADDRESS \
+%s \
+--payer /path/to/payer.json
+`, action.Summary, action.Name, action.Example),
+		Run: func(cmd *cobra.Command, args []string) {
+			runPropose(cmd, action.Action(cmd))
+		},
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Proposer keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().StringP("memo", "", "", "Transaction memo (optional)")
+	cmd.Flags().BoolP("approve", "", true, "Auto-approve the proposal (default true)")
+	cmd.Flags().Bool("draft", false, "Create the proposal as a draft")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+	cliutil.AddExportFlag(cmd)
+	action.AddFlags(cmd)
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+func runPropose(cmd *cobra.Command, action squads_multisig_program.ConfigAction) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	memo, _ := cmd.Flags().GetString("memo")
+	autoApprove, _ := cmd.Flags().GetBool("approve")
+	draft, _ := cmd.Flags().GetBool("draft")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+	// A draft cannot be voted on, so --approve defaults to off for drafts and
+	// asking for both is an error rather than silently dropping the approval.
+	if draft && cmd.Flags().Changed("approve") && autoApprove {
+		log.Fatalf("--draft and --approve cannot be used together: a draft cannot be approved until it is activated with \"transaction activate\"")
+	}
+	if draft {
+		autoApprove = false
+	}
+
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	description := cliutil.DescribeConfigAction(action)
+	log.Printf("Proposing config action: %s", description)
+
+	input := transaction.ConfigTransactionCreateInput{
+		Multisig:    multisigPDA,
+		Creator:     payer,
+		Actions:     []squads_multisig_program.ConfigAction{action},
+		Memo:        memo,
+		Draft:       draft,
+		AutoApprove: autoApprove,
+	}
+
+	if exportPath != "" {
+		tx, output, err := client.PrepareConfigTransaction(ctx, input)
+		if err != nil {
+			log.Fatalf("Failed to prepare config transaction: %v", err)
+		}
+		err = cliutil.ExportTransaction(exportPath, tx, output.TransactionIndex,
+			"Action: create config transaction",
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+			fmt.Sprintf("Transaction Index: %d", output.TransactionIndex),
+			fmt.Sprintf("Config Action: %s", description),
+			fmt.Sprintf("Creator: %s", payer.PublicKey()),
+			fmt.Sprintf("Auto-approve: %t", autoApprove),
+		)
+		if err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.CreateConfigTransaction(ctxWithTimeout, input)
+	if err != nil {
+		log.Fatalf("Failed to create config transaction: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("    CONFIG TRANSACTION CREATED SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", output.Signature)
+	fmt.Printf("Transaction Index: %d\n", output.TransactionIndex)
+	fmt.Printf("Transaction PDA: %s\n", output.TransactionPDA)
+	fmt.Printf("Proposal PDA: %s\n", output.ProposalPDA)
+	fmt.Printf("Config Action: %s\n", description)
+	if spendingLimit, ok := action.(*squads_multisig_program.ConfigActionAddSpendingLimit); ok {
+		address, _, err := client.SpendingLimitPDA(multisigPDA, spendingLimit.CreateKey)
+		if err != nil {
+			log.Fatalf("Failed to derive spending limit PDA: %v", err)
+		}
+		fmt.Printf("Spending Limit: %s\n", address)
+	}
+
+	switch {
+	case draft:
+		fmt.Println("\nThe proposal is a draft and must be activated before members can vote:")
+		fmt.Printf("  squads-cli transaction activate --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+			multisigPDA, output.TransactionIndex)
+	case autoApprove && output.Threshold <= 1:
+		fmt.Println("\nThe proposal was approved by the creator and has reached its threshold.")
+	case autoApprove:
+		fmt.Printf("\nThe proposal was approved by the creator. Waiting for %d more approvals.\n", output.Threshold-1)
+	default:
+		fmt.Println("\nThe proposal requires explicit approval. Use the following command to approve:")
+		fmt.Printf("  squads-cli transaction approve --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+			multisigPDA, output.TransactionIndex)
+	}
+	fmt.Println("\nOnce approved, apply it with:")
+	fmt.Printf("  squads-cli config execute --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+		multisigPDA, output.TransactionIndex)
+}
@@ -6,9 +6,19 @@ import (
 
 	"github.com/spf13/cobra"
 
+	configtransaction "github.com/hogyzen12/squads-go/cmd/config-transaction"
+	multisigadmin "github.com/hogyzen12/squads-go/cmd/multisig-admin"
+	multisigbatch "github.com/hogyzen12/squads-go/cmd/multisig-batch"
+	multisigcleanup "github.com/hogyzen12/squads-go/cmd/multisig-cleanup"
 	multisigcreate "github.com/hogyzen12/squads-go/cmd/multisig-create"
 	multisiginfo "github.com/hogyzen12/squads-go/cmd/multisig-info"
+	multisiglist "github.com/hogyzen12/squads-go/cmd/multisig-list"
 	multisigtransaction "github.com/hogyzen12/squads-go/cmd/multisig-transaction"
+	nonceaccount "github.com/hogyzen12/squads-go/cmd/nonce-account"
+	offlinesigning "github.com/hogyzen12/squads-go/cmd/offline-signing"
+	pdaderive "github.com/hogyzen12/squads-go/cmd/pda-derive"
+	spendinglimits "github.com/hogyzen12/squads-go/cmd/spending-limits"
+	vaulttoken "github.com/hogyzen12/squads-go/cmd/vault-token"
 )
 
 func main() {
@@ -22,6 +32,13 @@ func main() {
 	// Global persistent flags that can be used across all commands
 	rootCmd.PersistentFlags().String("rpc", "https://api.mainnet-beta.solana.com", "Solana RPC endpoint")
 	rootCmd.PersistentFlags().String("ws", "wss://api.mainnet-beta.solana.com", "Solana WebSocket endpoint")
+	rootCmd.PersistentFlags().String("cluster", "", "Named cluster (mainnet-beta, devnet, testnet, localnet); overridden by --rpc/--ws")
+	rootCmd.PersistentFlags().String("program-id", "", "Squads program ID (default SQDS4ep65T869zMMBKyuUq6aD6EgTu8psMjkvj52pCf)")
+	rootCmd.PersistentFlags().String("commitment", "confirmed", "Commitment level (processed, confirmed, finalized)")
+	rootCmd.PersistentFlags().String("nonce-account", "", "Durable nonce account to use instead of a recent blockhash")
+	rootCmd.PersistentFlags().String("priority-fee", "", "Priority fee in micro-lamports per compute unit, or \"auto\" to use recent fees")
+	rootCmd.PersistentFlags().String("compute-unit-limit", "", "Compute unit limit, or \"auto\" to size it by simulation")
+	rootCmd.PersistentFlags().String("nonce-authority", "", "Nonce authority keypair, remote signer URL or public key (default: the acting member)")
 
 	// Create a multisig command group
 	multisigCmd := &cobra.Command{
@@ -36,6 +53,7 @@ func main() {
 	multisigCmd.AddCommand(
 		multisigcreate.NewCommand(),
 		multisiginfo.NewCommand(),
+		multisiglist.NewCommand(),
 	)
 
 	// Create a transaction subcommand group
@@ -50,14 +68,46 @@ func main() {
 	// Add transaction subcommands
 	transactionCmd.AddCommand(
 		multisigtransaction.NewCreateCommand(),
+		multisigtransaction.NewProposeCommand(),
+		multisigtransaction.NewCloseBufferCommand(),
+		multisigtransaction.NewActivateCommand(),
 		multisigtransaction.NewApproveCommand(),
+		multisigtransaction.NewRejectCommand(),
+		multisigtransaction.NewCancelCommand(),
 		multisigtransaction.NewExecuteCommand(),
+		multisigtransaction.NewListCommand(),
+		multisigtransaction.NewStatusCommand(),
+	)
+
+	// Create a nonce subcommand group
+	nonceCmd := &cobra.Command{
+		Use:   "nonce",
+		Short: "Manage durable nonce accounts",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	// Add nonce subcommands
+	nonceCmd.AddCommand(
+		nonceaccount.NewCreateCommand(),
+		nonceaccount.NewShowCommand(),
 	)
 
 	// Add command groups to root
 	rootCmd.AddCommand(
 		multisigCmd,
 		transactionCmd,
+		multisigbatch.NewCommand(),
+		multisigcleanup.NewCommand(),
+		configtransaction.NewCommand(),
+		multisigadmin.NewCommand(),
+		spendinglimits.NewCommand(),
+		vaulttoken.NewCommand(),
+		nonceCmd,
+		offlinesigning.NewSignCommand(),
+		offlinesigning.NewSubmitCommand(),
+		pdaderive.NewCommand(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
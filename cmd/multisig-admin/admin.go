@@ -0,0 +1,213 @@
+package multisigadmin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+)
+
+// NewCommand creates the command group for administering controlled multisigs
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Change a controlled multisig through its config authority",
+		Long: `Change a controlled multisig directly, without a vote.
+
+A multisig created with a config authority is "controlled": the authority
+changes its members, threshold, time lock, rent collector and spending limits
+on its own. Each command checks that --authority is the configured authority
+and that the resulting member set and threshold are valid before sending.
+Autonomous multisigs use "squads-cli config propose" instead.
+
+Examples:
+squads-cli admin add-member --multisig MULTISIG_ADDRESS --member NEW_MEMBER --authority /path/to/authority.json
+squads-cli admin set-config-authority --multisig MULTISIG_ADDRESS --new-authority NEW_AUTHORITY --authority /path/to/authority.json
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	for _, action := range cliutil.ConfigActionCommands {
+		action := action
+		cmd.AddCommand(newAdminCommand(action.Name, capitalize(action.Summary), action.Example,
+			action.AddFlags,
+			func(cmd *cobra.Command, input *multisig.AdminInput) {
+				input.Actions = []squads_multisig_program.ConfigAction{action.Action(cmd)}
+			}))
+	}
+	cmd.AddCommand(newAdminCommand("set-config-authority", "Hand the config authority over, or renounce it",
+		"--new-authority NEW_AUTHORITY",
+		func(cmd *cobra.Command) {
+			cmd.Flags().String("new-authority", "", "Key that becomes the config authority")
+			cmd.Flags().Bool("renounce", false, "Remove the config authority for good, making the multisig autonomous")
+		},
+		func(cmd *cobra.Command, input *multisig.AdminInput) {
+			renounce, _ := cmd.Flags().GetBool("renounce")
+			switch {
+			case renounce && cmd.Flags().Changed("new-authority"):
+				log.Fatalf("--new-authority and --renounce are mutually exclusive")
+			case renounce:
+				input.NewConfigAuthority = &solana.PublicKey{}
+			case cmd.Flags().Changed("new-authority"):
+				newAuthority := cliutil.KeyFlag(cmd, "new-authority")
+				if newAuthority.IsZero() {
+					log.Fatalf("Use --renounce to remove the config authority")
+				}
+				input.NewConfigAuthority = &newAuthority
+			default:
+				log.Fatalf("One of --new-authority or --renounce is required")
+			}
+		}))
+
+	return cmd
+}
+
+// newAdminCommand builds one admin command. addFlags registers the change's
+// own flags and apply fills the change into the input.
+func newAdminCommand(
+	use, short, example string,
+	addFlags func(*cobra.Command),
+	apply func(*cobra.Command, *multisig.AdminInput),
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: fmt.Sprintf(`%s.
+
+The change applies immediately and must be signed by the multisig's config
+authority.
+
+Example:
+squads-cli admin %s \
+--multisig MULTISIG_ADDRESS \
+%s \
+--authority /path/to/authority.json
+`, short, use, example),
+		Run: func(cmd *cobra.Command, args []string) {
+			var input multisig.AdminInput
+			apply(cmd, &input)
+			runAdmin(cmd, input)
+		},
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().StringP("authority", "a", "", "Config authority keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().StringP("memo", "", "", "Memo recorded with the change (optional)")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+	cliutil.AddExportFlag(cmd)
+	addFlags(cmd)
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("authority")
+
+	return cmd
+}
+
+func runAdmin(cmd *cobra.Command, input multisig.AdminInput) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	authorityPath, _ := cmd.Flags().GetString("authority")
+	memo, _ := cmd.Flags().GetString("memo")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	authority, err := cliutil.LoadSigner(ctx, authorityPath)
+	if err != nil {
+		log.Fatalf("Failed to load authority signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	input.Multisig = multisigPDA
+	input.Authority = authority
+	input.Memo = memo
+	changes := describeChanges(input)
+
+	if exportPath != "" {
+		tx, _, err := client.PrepareAdminister(ctx, input)
+		if err != nil {
+			log.Fatalf("Failed to prepare change: %v", err)
+		}
+		summary := []string{
+			"Action: administer controlled multisig",
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+		}
+		for _, change := range changes {
+			summary = append(summary, fmt.Sprintf("Change: %s", change))
+		}
+		summary = append(summary, fmt.Sprintf("Config Authority: %s", authority.PublicKey()))
+		if err := cliutil.ExportTransaction(exportPath, tx, 0, summary...); err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.Administer(ctxWithTimeout, input)
+	if err != nil {
+		log.Fatalf("Failed to change multisig: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("       MULTISIG CHANGED SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", output.Signature)
+	for _, change := range changes {
+		fmt.Printf("Change: %s\n", change)
+	}
+	for _, spendingLimit := range output.SpendingLimits {
+		fmt.Printf("Spending Limit Account: %s\n", spendingLimit)
+	}
+	fmt.Printf("Threshold: %d of %d members\n", output.Threshold, len(output.Members))
+	fmt.Printf("Time Lock: %d seconds\n", output.TimeLock)
+	if output.ConfigAuthority.IsZero() {
+		fmt.Println("Config Authority: none (the multisig is now autonomous)")
+	} else {
+		fmt.Printf("Config Authority: %s\n", output.ConfigAuthority)
+	}
+}
+
+// describeChanges renders the changes of an admin input, one per line.
+func describeChanges(input multisig.AdminInput) []string {
+	var changes []string
+	for _, action := range input.Actions {
+		changes = append(changes, cliutil.DescribeConfigAction(action))
+	}
+	if input.NewConfigAuthority != nil {
+		if input.NewConfigAuthority.IsZero() {
+			changes = append(changes, "Renounce config authority")
+		} else {
+			changes = append(changes, fmt.Sprintf("Hand config authority to %s", *input.NewConfigAuthority))
+		}
+	}
+	return changes
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
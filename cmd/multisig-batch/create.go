@@ -0,0 +1,191 @@
+package multisigbatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewCommand creates the command group for batches
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Propose and execute batches of vault transactions",
+		Long: `Propose and execute batches of vault transactions.
+
+A batch is a list of vault transactions under one proposal: a single approval
+covers all of them, and they execute one at a time, in order. Members vote on
+a batch like on any proposal, with "squads-cli transaction approve
+--transaction BATCH_INDEX".
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		NewCreateCommand(),
+		NewExecuteCommand(),
+		NewShowCommand(),
+	)
+
+	return cmd
+}
+
+// NewCreateCommand creates the command for proposing a batch
+func NewCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Propose a batch of vault transactions",
+		Long: `Propose a batch of vault transactions.
+
+The batch and its proposal are created as a draft, each transaction is added
+in order, and the proposal is then activated for voting. This takes several
+transactions; if one fails, the batch stays a draft that nobody can vote on
+until it is resumed: run the same command again with --resume BATCH_INDEX to
+add the missing transactions and activate it. Only the batch's creator can
+resume it, with the same transactions file: transactions already in the
+batch are checked against it, and a different or reordered list is refused.
+
+Batch transactions are not uploaded through transaction buffers, so each must
+fit in one Solana transaction; propose larger ones on their own with
+"squads-cli transaction propose". This command does not take ephemeral
+signers; the SDK's BatchCreateInput.EphemeralSigners does.
+
+--transactions takes a JSON array of transactions, each an array of
+instructions in the format of "squads-cli transaction propose --instructions":
+[
+  [{"programId": "...", "accounts": [...], "data": "..."}],
+  [{"programId": "...", "accounts": [...], "data": "..."}]
+]
+
+Example:
+squads-cli batch create \
+--multisig MULTISIG_ADDRESS \
+--transactions batch.json \
+--payer /path/to/payer.json
+`,
+		Run: runCreate,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().StringP("transactions", "i", "", "JSON file with the batch's transactions (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Proposer keypair path or remote signer URL (REQUIRED)")
+	cmd.Flags().Uint8P("vault-index", "v", 0, "Vault index (default 0)")
+	cmd.Flags().StringSlice("lookup-table", nil, "Address lookup tables to compile the transactions against (repeatable)")
+	cmd.Flags().StringP("memo", "", "", "Batch memo (optional)")
+	cmd.Flags().BoolP("approve", "", true, "Approve the batch once it is complete (default true)")
+	cmd.Flags().Uint64P("resume", "", 0, "Resume the draft batch at this index, passing the same --transactions file (optional)")
+	cmd.Flags().Uint32P("timeout", "", 300, "Confirmation timeout in seconds for the whole batch (default 300)")
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("transactions")
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	transactionsPath, _ := cmd.Flags().GetString("transactions")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	vaultIndex, _ := cmd.Flags().GetUint8("vault-index")
+	lookupTableStrs, _ := cmd.Flags().GetStringSlice("lookup-table")
+	memo, _ := cmd.Flags().GetString("memo")
+	autoApprove, _ := cmd.Flags().GetBool("approve")
+	resume, _ := cmd.Flags().GetUint64("resume")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	data, err := os.ReadFile(transactionsPath)
+	if err != nil {
+		log.Fatalf("Failed to read transactions: %v", err)
+	}
+	transactions, err := transaction.ParseBatchJSON(data)
+	if err != nil {
+		log.Fatalf("Invalid transactions file: %v", err)
+	}
+
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, true)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	lookupTableKeys, err := cliutil.ParseKeys(lookupTableStrs)
+	if err != nil {
+		log.Fatalf("Invalid lookup table: %v", err)
+	}
+	lookupTables, err := transaction.FetchAddressLookupTables(ctx, client.Options(), lookupTableKeys)
+	if err != nil {
+		log.Fatalf("Failed to load lookup tables: %v", err)
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.CreateBatch(ctxWithTimeout, transaction.BatchCreateInput{
+		Multisig:            multisigPDA,
+		Creator:             payer,
+		Transactions:        transactions,
+		VaultIndex:          vaultIndex,
+		Memo:                memo,
+		AutoApprove:         autoApprove,
+		AddressLookupTables: lookupTables,
+		BatchIndex:          resume,
+	})
+	var incomplete *transaction.BatchIncompleteError
+	if errors.As(err, &incomplete) {
+		log.Fatalf("Failed to create batch: %v\nTo resume, run the same command with --resume %d", err, incomplete.BatchIndex)
+	}
+	if err != nil {
+		log.Fatalf("Failed to create batch: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("         BATCH CREATED SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	for i, signature := range output.Signatures {
+		fmt.Printf("Signature %d: %s\n", i+1, signature)
+	}
+	fmt.Printf("Batch Index: %d\n", output.BatchIndex)
+	fmt.Printf("Batch PDA: %s\n", output.BatchPDA)
+	fmt.Printf("Proposal PDA: %s\n", output.ProposalPDA)
+	fmt.Printf("Vault: %s\n", output.VaultPDA)
+	fmt.Printf("Transactions: %d\n", len(output.TransactionPDAs))
+	if output.Resumed > 0 {
+		fmt.Printf("Already Added: %d\n", output.Resumed)
+	}
+
+	if autoApprove && output.Threshold <= 1 {
+		fmt.Println("\nThe batch was approved by the creator and has reached its threshold. Execute it with:")
+		fmt.Printf("  squads-cli batch execute --multisig %s --batch %d --payer /path/to/keypair.json\n",
+			multisigPDA, output.BatchIndex)
+	} else if autoApprove {
+		fmt.Printf("\nThe batch was approved by the creator. Waiting for %d more approvals.\n", output.Threshold-1)
+	} else {
+		fmt.Println("\nThe batch requires approval. Use the following command to approve:")
+		fmt.Printf("  squads-cli transaction approve --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+			multisigPDA, output.BatchIndex)
+	}
+}
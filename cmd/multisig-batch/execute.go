@@ -0,0 +1,92 @@
+package multisigbatch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewExecuteCommand creates the command for executing an approved batch
+func NewExecuteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execute",
+		Short: "Execute the remaining transactions of an approved batch",
+		Long: `Execute the remaining transactions of an approved batch, one transaction each.
+
+Progress is kept on chain: if execution stops part way, running the command
+again continues with the first transaction not yet executed.
+
+Example:
+squads-cli batch execute \
+--multisig MULTISIG_ADDRESS \
+--batch BATCH_INDEX \
+--payer /path/to/payer.json
+`,
+		Run: runExecute,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().Uint64P("batch", "b", 0, "Transaction index of the batch (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Member keypair path or remote signer URL (REQUIRED)")
+	cmd.Flags().Uint32P("timeout", "", 300, "Confirmation timeout in seconds for the whole batch (default 300)")
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("batch")
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+func runExecute(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	batchIndex, _ := cmd.Flags().GetUint64("batch")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, true)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.ExecuteBatch(ctxWithTimeout, transaction.BatchExecuteInput{
+		Multisig:   multisigPDA,
+		BatchIndex: batchIndex,
+		Executor:   payer,
+	})
+	if err != nil {
+		log.Fatalf("Failed to execute batch: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("        BATCH EXECUTED SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	first := output.Size - uint32(len(output.Signatures)) + 1
+	for i, signature := range output.Signatures {
+		fmt.Printf("Transaction %d: %s\n", first+uint32(i), signature)
+	}
+	fmt.Printf("Batch PDA: %s\n", output.BatchPDA)
+	fmt.Printf("Executed: %d/%d\n", output.Executed, output.Size)
+}
@@ -0,0 +1,79 @@
+package multisigbatch
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewShowCommand creates the command for inspecting a batch
+func NewShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show a batch and how far its execution has come",
+		Long: `Show a batch, its proposal status and how many of its transactions have
+been executed.
+
+Example:
+squads-cli batch show --multisig MULTISIG_ADDRESS --batch BATCH_INDEX
+`,
+		Run: runShow,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().Uint64P("batch", "b", 0, "Transaction index of the batch (REQUIRED)")
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("batch")
+
+	return cmd
+}
+
+func runShow(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	batchIndex, _ := cmd.Flags().GetUint64("batch")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, false)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	batch, err := client.FetchBatch(ctx, multisigPDA, batchIndex)
+	if err != nil {
+		log.Fatalf("Failed to fetch batch: %v", err)
+	}
+	vaultPDA, _, err := client.VaultPDA(multisigPDA, batch.VaultIndex)
+	if err != nil {
+		log.Fatalf("Failed to derive vault PDA: %v", err)
+	}
+
+	fmt.Printf("Batch #%d\n", batch.Index)
+	fmt.Printf("Creator: %s\n", batch.Creator)
+	fmt.Printf("Vault: %s (index %d)\n", vaultPDA, batch.VaultIndex)
+	fmt.Printf("Executed: %d/%d\n", batch.ExecutedTransactionIndex, batch.Size)
+
+	proposal, err := client.FetchProposal(ctx, multisigPDA, batchIndex)
+	if err != nil {
+		fmt.Printf("Proposal: %v\n", err)
+		return
+	}
+	fmt.Printf("Proposal: %s (%d approvals)\n", transaction.StateOf(proposal.Status), len(proposal.Approved))
+	if batch.ExecutedTransactionIndex < batch.Size && transaction.StateOf(proposal.Status) == transaction.StateApproved {
+		fmt.Printf("\nContinue execution with:\n  squads-cli batch execute --multisig %s --batch %d --payer /path/to/keypair.json\n",
+			multisigPDA, batchIndex)
+	}
+}
@@ -0,0 +1,137 @@
+package multisigcleanup
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+	"github.com/hogyzen12/squads-go/pkg/token"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewCommand creates the command for reclaiming rent from settled
+// transactions
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cleanup",
+		Short: "Close settled transactions and reclaim their rent",
+		Long: `Close the accounts of executed, rejected, cancelled and stale transactions
+and send their rent to the multisig's rent collector.
+
+Every transaction index is scanned. Vault transactions, config transactions and
+batches are closed together with their proposals; transactions that can still
+be executed are left alone. The close instructions are packed into as few
+transactions as fit. Closing needs no permission, so any payer can run it, but
+the multisig must have a rent collector (see "config propose set-rent-collector").
+
+Examples:
+# Show what would be closed and how much rent it holds
+squads-cli cleanup --multisig MULTISIG_ADDRESS --dry-run
+
+# Close everything that can be closed
+squads-cli cleanup --multisig MULTISIG_ADDRESS --payer /path/to/payer.json
+`,
+		Run: runCleanup,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Fee payer keypair path or remote signer URL (REQUIRED unless --dry-run)")
+	cmd.Flags().Bool("dry-run", false, "Report the closable accounts without closing them")
+	cmd.Flags().Uint32P("timeout", "", 300, "Confirmation timeout in seconds for all cleanup transactions (default 300)")
+
+	cmd.MarkFlagRequired("multisig")
+
+	return cmd
+}
+
+func runCleanup(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+	if payerPath == "" && !dryRun {
+		log.Fatalf("--payer is required unless --dry-run is set")
+	}
+
+	var payer signer.Signer
+	if !dryRun {
+		payer, err = cliutil.LoadSigner(ctx, payerPath)
+		if err != nil {
+			log.Fatalf("Failed to load payer signer: %v", err)
+		}
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, !dryRun)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.Cleanup(ctxWithTimeout, transaction.CleanupInput{
+		Multisig: multisigPDA,
+		Signer:   payer,
+		DryRun:   dryRun,
+	})
+	if err != nil {
+		log.Fatalf("Failed to clean up: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	if dryRun {
+		fmt.Println("          CLEANUP (DRY RUN)")
+	} else {
+		fmt.Println("           CLEANUP COMPLETE")
+	}
+	fmt.Println("════════════════════════════════════════")
+	if len(output.Transactions) == 0 {
+		fmt.Println("Nothing to close.")
+		return
+	}
+	for _, closable := range output.Transactions {
+		state := string(closable.State)
+		if state == "" {
+			state = "no proposal"
+		}
+		if closable.Stale {
+			state += ", stale"
+		}
+		fmt.Printf("#%d  %s  [%s]  %d accounts, %s SOL\n", closable.TransactionIndex, closable.Kind, state,
+			len(closable.Accounts), token.FormatAmount(closable.Lamports, token.SOLDecimals))
+	}
+	for _, sig := range output.Signatures {
+		fmt.Printf("Transaction Signature: %s\n", sig)
+	}
+
+	if output.RentCollector.IsZero() {
+		fmt.Println("Rent Collector: none")
+	} else {
+		fmt.Printf("Rent Collector: %s\n", output.RentCollector)
+	}
+	if dryRun {
+		fmt.Printf("Reclaimable: %s SOL from %d transactions\n",
+			token.FormatAmount(output.Lamports, token.SOLDecimals), len(output.Transactions))
+		if output.RentCollector.IsZero() {
+			fmt.Println("\nThe multisig has no rent collector, so nothing can be closed until one is set with:")
+			fmt.Printf("  squads-cli config propose set-rent-collector --multisig %s --rent-collector ADDRESS --payer /path/to/member.json\n", multisigPDA)
+		}
+		return
+	}
+	fmt.Printf("Reclaimed: %s SOL from %d transactions\n",
+		token.FormatAmount(output.Lamports, token.SOLDecimals), len(output.Transactions))
+}
@@ -1,19 +1,16 @@
 package multisigcreate
 
 import (
-	"encoding/json"
 	"fmt"
 	"log"
-	"os"
 	"strings"
 
 	"github.com/gagliardetto/solana-go"
-	"github.com/gagliardetto/solana-go/rpc"
-	"github.com/gagliardetto/solana-go/rpc/ws"
 	"github.com/spf13/cobra"
 
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
 	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
-	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/signer"
 )
 
 // Define permission masks
@@ -61,7 +58,7 @@ Examples:
 		Run: runCreate,
 	}
 
-	cmd.Flags().StringP("payer", "p", "", "Path to payer keypair JSON (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Path to payer keypair JSON or remote signer URL (REQUIRED)")
 	cmd.Flags().Uint16P("threshold", "t", 2, "Multisig signature threshold")
 	cmd.Flags().Uint32P("timelock", "l", 0, "Timelock duration in seconds")
 	cmd.Flags().StringSliceP("members", "m", []string{
@@ -75,15 +72,11 @@ Examples:
 }
 
 func runCreate(cmd *cobra.Command, args []string) {
-	// Get RPC endpoints
-	rpcEndpoint, _ := cmd.Parent().Flags().GetString("rpc")
-	wsEndpoint, _ := cmd.Parent().Flags().GetString("ws")
-
-	// Load payer keypair
+	// Load payer signer
 	payerPath, _ := cmd.Flags().GetString("payer")
-	payer, err := loadKeypair(payerPath)
+	payer, err := cliutil.LoadSigner(cmd.Context(), payerPath)
 	if err != nil {
-		log.Fatalf("Failed to load payer keypair: %v", err)
+		log.Fatalf("Failed to load payer signer: %v", err)
 	}
 
 	// Get threshold and timelock
@@ -150,27 +143,24 @@ func runCreate(cmd *cobra.Command, args []string) {
 		)
 	}
 
-	// Set up RPC and WebSocket clients
-	client := rpc.New(rpcEndpoint)
-	wsClient, err := ws.Connect(cmd.Context(), wsEndpoint)
+	// Set up the Squads client
+	client, err := cliutil.NewClient(cmd.Context(), cmd, true)
 	if err != nil {
-		log.Fatalf("Failed to connect to WebSocket: %v", err)
+		log.Fatalf("Failed to set up client: %v", err)
 	}
-	defer wsClient.Close()
+	defer client.Close()
 
 	// Generate a create key
-	createKey := solana.NewWallet().PrivateKey
+	createKey := signer.NewLocal(solana.NewWallet().PrivateKey)
 
 	// Call multisig creation
-	sig, multisigPDA, err := multisig.CreateMultisig(
-		client,
-		wsClient,
+	sig, multisigPDA, err := client.CreateMultisig(
+		cmd.Context(),
 		payer,
 		createKey,
 		members,
 		threshold,
 		timeLock,
-		solana.MustPublicKeyFromBase58("SQDS4ep65T869zMMBKyuUq6aD6EgTu8psMjkvj52pCf"),
 	)
 	if err != nil {
 		log.Fatalf("Failed to create multisig: %v", err)
@@ -210,18 +200,6 @@ func describePermissions(mask uint8) string {
 	return strings.Join(desc, ", ")
 }
 
-func loadKeypair(path string) (solana.PrivateKey, error) {
-	keyBytes, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-	var keyArray []byte
-	if err := json.Unmarshal(keyBytes, &keyArray); err != nil {
-		return nil, err
-	}
-	return solana.PrivateKey(keyArray), nil
-}
-
 func explainThresholdError(memberKeys []string, memberPermissions []int, threshold uint16) string {
 	votingMembers := make([]string, 0)
 	nonVotingMembers := make([]string, 0)
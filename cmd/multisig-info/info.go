@@ -7,13 +7,13 @@ import (
 	"strings"
 	"time"
 
-	ag_binary "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
-	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/spf13/cobra"
 
+	squads "github.com/hogyzen12/squads-go"
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
 	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
-	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
 )
 
 // Define permission masks
@@ -42,27 +42,32 @@ Example:
 	}
 
 	cmd.Flags().StringP("address", "a", "", "Multisig address (REQUIRED)")
+	cmd.Flags().Int("recent", 5, "Number of recent transactions to show")
 	cmd.MarkFlagRequired("address")
 
 	return cmd
 }
 
 func runInfoCommand(cmd *cobra.Command, args []string) {
-	// Get RPC endpoint
-	rpcEndpoint, _ := cmd.Parent().Flags().GetString("rpc")
+	ctx := context.Background()
 
 	// Get multisig address
 	multisigStr, _ := cmd.Flags().GetString("address")
+	recent, _ := cmd.Flags().GetInt("recent")
 	multisigAddr, err := solana.PublicKeyFromBase58(multisigStr)
 	if err != nil {
 		log.Fatalf("Invalid multisig address: %v", err)
 	}
 
-	// Set up RPC client
-	client := rpc.New(rpcEndpoint)
+	// Set up the Squads client
+	client, err := cliutil.NewClient(ctx, cmd, false)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
 
 	// Fetch multisig account
-	multisigAccount, err := fetchMultisigAccount(client, multisigAddr)
+	multisigAccount, err := client.FetchMultisig(ctx, multisigAddr)
 	if err != nil {
 		log.Fatalf("Failed to fetch multisig account: %v", err)
 	}
@@ -71,102 +76,61 @@ func runInfoCommand(cmd *cobra.Command, args []string) {
 	displayMultisigInfo(multisigAddr, multisigAccount)
 
 	// Get vault PDA (default vault index 0)
-	vaultPDA, vaultBump := multisig.GetVaultPDA(multisigAddr, 0)
+	vaultPDA, vaultBump, err := client.VaultPDA(multisigAddr, 0)
+	if err != nil {
+		log.Fatalf("Failed to derive vault PDA: %v", err)
+	}
 	fmt.Printf("\nMultisig Vaults:\n")
 	fmt.Printf("  Default Vault (Index 0): %s (Bump: %d)\n", vaultPDA, vaultBump)
 
 	// Get balance of the vault
-	balance, err := getAccountBalance(client, vaultPDA)
+	balance, err := getAccountBalance(ctx, client, vaultPDA)
 	if err != nil {
 		fmt.Printf("  Balance: Unable to fetch balance\n")
 	} else {
 		fmt.Printf("  Balance: %f SOL\n", float64(balance)/1e9)
 	}
 
-	// Show a list of the last 5 transactions if any exist
-	if multisigAccount.TransactionIndex > 0 {
-		fmt.Printf("\nRecent Transactions:\n")
-		// Show up to the last 5 transactions
-		startIdx := multisigAccount.TransactionIndex
-		if startIdx > 5 {
-			startIdx = 5
-		}
-
-		for i := multisigAccount.TransactionIndex; i > multisigAccount.TransactionIndex-startIdx; i-- {
-			txPDA, _ := multisig.GetTransactionPDA(multisigAddr, i)
-			proposalPDA, _ := multisig.GetProposalPDA(multisigAddr, i)
-
-			// Try to fetch the proposal to get its status
-			proposal, err := fetchProposalAccount(client, proposalPDA)
-			if err != nil {
-				fmt.Printf("  Transaction #%d: %s (Proposal: %s) - Unable to fetch status\n",
-					i, txPDA.String(), proposalPDA.String())
-				continue
-			}
-
-			status := getProposalStatusString(proposal.Status)
-			fmt.Printf("  Transaction #%d: %s - Status: %s\n", i, txPDA.String(), status)
-
-			// Show approval count if in active or approved state
-			if strings.Contains(status, "Active") || strings.Contains(status, "Approved") {
-				fmt.Printf("    Approvals: %d, Rejections: %d, Cancellations: %d\n",
-					len(proposal.Approved), len(proposal.Rejected), len(proposal.Cancelled))
-			}
-		}
-	} else {
+	// Show the most recent transactions, if any exist
+	if multisigAccount.TransactionIndex == 0 {
 		fmt.Println("\nNo transactions created yet.")
+		return
 	}
-}
-
-func fetchMultisigAccount(
-	client *rpc.Client,
-	multisigPDA solana.PublicKey,
-) (*squads_multisig_program.Multisig, error) {
-	accountInfo, err := client.GetAccountInfo(
-		context.Background(),
-		multisigPDA,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get multisig account: %w", err)
-	}
-
-	var multisigAccount squads_multisig_program.Multisig
-	decoder := ag_binary.NewBorshDecoder(accountInfo.Value.Data.GetBinary())
-	err = multisigAccount.UnmarshalWithDecoder(decoder)
+	page, err := client.ListProposals(ctx, transaction.ListProposalsInput{
+		Multisig: multisigAddr,
+		Limit:    recent,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode multisig account: %w", err)
-	}
+		fmt.Printf("\nRecent Transactions: Unable to fetch (%v)\n", err)
+		return
+	}
+	fmt.Printf("\nRecent Transactions:\n")
+	for _, entry := range page.Proposals {
+		if entry.Proposal == nil {
+			fmt.Printf("  Transaction #%d: %s - No proposal\n", entry.TransactionIndex, entry.TransactionPDA)
+			continue
+		}
 
-	return &multisigAccount, nil
-}
+		fmt.Printf("  Transaction #%d: %s - Status: %s\n",
+			entry.TransactionIndex, entry.TransactionPDA, getProposalStatusString(entry.Proposal.Status))
 
-func fetchProposalAccount(
-	client *rpc.Client,
-	proposalPDA solana.PublicKey,
-) (*squads_multisig_program.Proposal, error) {
-	accountInfo, err := client.GetAccountInfo(
-		context.Background(),
-		proposalPDA,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get proposal account: %w", err)
+		// Show approval count if in active or approved state
+		if state := entry.State(); state == transaction.StateActive || state == transaction.StateApproved {
+			fmt.Printf("    Approvals: %d, Rejections: %d, Cancellations: %d\n",
+				len(entry.Proposal.Approved), len(entry.Proposal.Rejected), len(entry.Proposal.Cancelled))
+		}
 	}
-
-	var proposalAccount squads_multisig_program.Proposal
-	decoder := ag_binary.NewBorshDecoder(accountInfo.Value.Data.GetBinary())
-	err = proposalAccount.UnmarshalWithDecoder(decoder)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode proposal account: %w", err)
+	if page.NextCursor != 0 {
+		fmt.Printf("\nOlder transactions: squads-cli transaction list --multisig %s --cursor %d\n",
+			multisigAddr, page.NextCursor)
 	}
-
-	return &proposalAccount, nil
 }
 
-func getAccountBalance(client *rpc.Client, pubkey solana.PublicKey) (uint64, error) {
-	balance, err := client.GetBalance(
-		context.Background(),
+func getAccountBalance(ctx context.Context, client *squads.Client, pubkey solana.PublicKey) (uint64, error) {
+	balance, err := client.RPC.GetBalance(
+		ctx,
 		pubkey,
-		rpc.CommitmentFinalized,
+		client.Commitment,
 	)
 	if err != nil {
 		return 0, err
@@ -0,0 +1,100 @@
+package multisiglist
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+)
+
+// NewCommand creates the command for finding multisigs
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Find the multisigs a wallet belongs to",
+		Long: `Find Squads multisigs by member, create key or config authority.
+
+Filters combine: a multisig must match every one given. Searching by member
+downloads every multisig of the program, which some public RPC endpoints
+refuse; use a dedicated RPC endpoint if the request is rejected.
+
+Examples:
+# Every multisig a wallet is a member of
+squads-cli multisig list --member MEMBER_PUBLIC_KEY
+
+# The multisig seeded by a create key
+squads-cli multisig list --create-key CREATE_KEY
+
+# Multisigs controlled by a config authority
+squads-cli multisig list --config-authority AUTHORITY_PUBLIC_KEY
+`,
+		Run: runList,
+	}
+
+	cmd.Flags().String("member", "", "Member public key")
+	cmd.Flags().String("create-key", "", "Create key the multisig was seeded with")
+	cmd.Flags().String("config-authority", "", "Config authority of a controlled multisig")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	var filter accounts.MultisigFilter
+	for flag, dst := range map[string]**solana.PublicKey{
+		"member":           &filter.Member,
+		"create-key":       &filter.CreateKey,
+		"config-authority": &filter.ConfigAuthority,
+	} {
+		value, _ := cmd.Flags().GetString(flag)
+		if value == "" {
+			continue
+		}
+		key, err := solana.PublicKeyFromBase58(value)
+		if err != nil {
+			log.Fatalf("Invalid --%s: %v", flag, err)
+		}
+		*dst = &key
+	}
+	if filter.Member == nil && filter.CreateKey == nil && filter.ConfigAuthority == nil {
+		log.Fatalf("At least one of --member, --create-key or --config-authority is required")
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, false)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	multisigs, err := client.ListMultisigs(ctx, filter)
+	if err != nil {
+		log.Fatalf("Failed to list multisigs: %v", err)
+	}
+
+	fmt.Println("═════════════════════════════════════════")
+	fmt.Printf("           MULTISIGS FOUND: %d\n", len(multisigs))
+	fmt.Println("═════════════════════════════════════════")
+	for i, ms := range multisigs {
+		vaultPDA, _, err := client.VaultPDA(ms.Address, 0)
+		if err != nil {
+			log.Fatalf("Failed to derive vault PDA: %v", err)
+		}
+		fmt.Printf("%d. %s\n", i+1, ms.Address)
+		fmt.Printf("   Threshold: %d of %d members\n", ms.Account.Threshold, len(ms.Account.Members))
+		fmt.Printf("   Default Vault (Index 0): %s\n", vaultPDA)
+		if !ms.Account.ConfigAuthority.IsZero() {
+			fmt.Printf("   Config Authority: %s\n", ms.Account.ConfigAuthority)
+		}
+		fmt.Printf("   Transaction Count: %d\n", ms.Account.TransactionIndex)
+	}
+	if len(multisigs) > 0 {
+		fmt.Println("\nShow details with:")
+		fmt.Printf("  squads-cli multisig info --address %s\n", multisigs[0].Address)
+	}
+}
@@ -0,0 +1,116 @@
+package multisigtransaction
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewActivateCommand creates the command for opening a draft proposal for
+// voting
+func NewActivateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "activate",
+		Short: "Open a draft proposal for voting",
+		Long: `Activate a draft proposal so that members can vote on it.
+
+Proposals created with --draft cannot be approved, rejected or cancelled until
+they are activated. Any member with "Propose" permission can activate a draft
+that is not stale.
+
+Example:
+squads-cli transaction activate \
+--multisig MULTISIG_ADDRESS \
+--transaction TRANSACTION_INDEX \
+--payer /path/to/payer.json
+`,
+		Run: runActivate,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().Uint64P("transaction", "t", 0, "Transaction index of the draft proposal (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Member keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+	cliutil.AddExportFlag(cmd)
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("transaction")
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+func runActivate(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	transactionIndex, _ := cmd.Flags().GetUint64("transaction")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	input := transaction.ProposalActivateInput{
+		Multisig:         multisigPDA,
+		TransactionIndex: transactionIndex,
+		Member:           payer,
+	}
+
+	if exportPath != "" {
+		tx, output, err := client.PrepareActivateProposal(ctx, input)
+		if err != nil {
+			log.Fatalf("Failed to prepare activation: %v", err)
+		}
+		err = cliutil.ExportTransaction(exportPath, tx, transactionIndex,
+			"Action: activate draft proposal",
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+			fmt.Sprintf("Transaction Index: %d", transactionIndex),
+			fmt.Sprintf("Proposal PDA: %s", output.ProposalPDA),
+			fmt.Sprintf("Member: %s", payer.PublicKey()),
+		)
+		if err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.ActivateProposal(ctxWithTimeout, input)
+	if err != nil {
+		log.Fatalf("Failed to activate proposal: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("          PROPOSAL ACTIVATED")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", output.Signature)
+	fmt.Printf("Transaction Index: %d\n", transactionIndex)
+	fmt.Printf("Proposal PDA: %s\n", output.ProposalPDA)
+	fmt.Printf("\nThe proposal is open for voting and needs %d approvals. To approve, run:\n", output.Threshold)
+	fmt.Printf("  squads-cli transaction approve --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+		multisigPDA, transactionIndex)
+}
@@ -0,0 +1,43 @@
+package multisigtransaction
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCancelCommand creates the command for cancelling an approved transaction
+func NewCancelCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "Cancel an approved transaction before it is executed",
+		Long: `Cancel an approved transaction proposal before it is executed.
+
+Approval cannot be withdrawn, but members with "Vote" permission can vote to
+cancel an approved proposal that has not been executed yet, for example during
+its time lock. Once the cancellations reach the threshold, the proposal
+becomes Cancelled and can no longer be executed. The command reports whether
+your cancellation tipped it.
+
+Examples:
+# Cancel an approved proposal
+squads-cli transaction cancel \
+--multisig MULTISIG_ADDRESS \
+--transaction TRANSACTION_INDEX \
+--memo "superseded by #42" \
+--payer /path/to/payer.json
+
+# Export the cancellation for an offline member to sign
+squads-cli transaction cancel \
+--multisig MULTISIG_ADDRESS \
+--transaction TRANSACTION_INDEX \
+--payer MEMBER_PUBLIC_KEY \
+--export cancel.json
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runVote(cmd, "cancel")
+		},
+	}
+
+	addVoteFlags(cmd, "cancel")
+
+	return cmd
+}
@@ -0,0 +1,112 @@
+package multisigtransaction
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewCloseBufferCommand creates the command for closing an abandoned
+// transaction buffer
+func NewCloseBufferCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "close-buffer",
+		Short: "Close a transaction buffer left by an interrupted upload",
+		Long: `Close a transaction buffer and reclaim its rent.
+
+"transaction propose" uploads vault transactions too large for one transaction
+through a buffer, which the program closes once the proposal is created. An
+upload that is interrupted and not resumed leaves the buffer open; only its
+creator can close it.
+
+Example:
+squads-cli transaction close-buffer \
+--multisig MULTISIG_ADDRESS \
+--payer /path/to/creator.json
+`,
+		Run: runCloseBuffer,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Buffer creator keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().Uint8("buffer-index", 0, "Index of the buffer to close")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+	cliutil.AddExportFlag(cmd)
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+func runCloseBuffer(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	bufferIndex, _ := cmd.Flags().GetUint8("buffer-index")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	input := transaction.TransactionBufferCloseInput{
+		Multisig:    multisigPDA,
+		Creator:     payer,
+		BufferIndex: bufferIndex,
+	}
+
+	if exportPath != "" {
+		tx, output, err := client.PrepareCloseTransactionBuffer(ctx, input)
+		if err != nil {
+			log.Fatalf("Failed to prepare transaction: %v", err)
+		}
+		err = cliutil.ExportTransaction(exportPath, tx, 0,
+			"Action: close transaction buffer",
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+			fmt.Sprintf("Transaction Buffer: %s", output.TransactionBuffer),
+			fmt.Sprintf("Creator: %s", payer.PublicKey()),
+		)
+		if err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.CloseTransactionBuffer(ctxWithTimeout, input)
+	if err != nil {
+		log.Fatalf("Failed to close transaction buffer: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("       TRANSACTION BUFFER CLOSED")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", output.Signature)
+	fmt.Printf("Transaction Buffer: %s\n", output.TransactionBuffer)
+	fmt.Printf("Discarded: %d bytes\n", output.Size)
+	fmt.Printf("Rent refunded to: %s\n", payer.PublicKey())
+}
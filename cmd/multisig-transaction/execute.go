@@ -8,11 +8,9 @@ import (
 	"time"
 
 	"github.com/gagliardetto/solana-go"
-	"github.com/gagliardetto/solana-go/rpc"
-	"github.com/gagliardetto/solana-go/rpc/ws"
 	"github.com/spf13/cobra"
 
-	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
 	"github.com/hogyzen12/squads-go/pkg/transaction"
 )
 
@@ -33,14 +31,22 @@ squads-cli transaction execute \
 --multisig MULTISIG_ADDRESS \
 --transaction TRANSACTION_INDEX \
 --payer /path/to/payer.json
+
+# Export the execution for an offline member to sign
+squads-cli transaction execute \
+--multisig MULTISIG_ADDRESS \
+--transaction TRANSACTION_INDEX \
+--payer MEMBER_PUBLIC_KEY \
+--export execute.json
 `,
 		Run: runExecuteTransaction,
 	}
 
 	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
 	cmd.Flags().Uint64P("transaction", "t", 0, "Transaction index to execute (REQUIRED)")
-	cmd.Flags().StringP("payer", "p", "", "Member keypair path for execution (REQUIRED)")
-	cmd.Flags().Uint32P("timeout", "", 120, "Transaction confirmation timeout in seconds (default 120)")
+	cmd.Flags().StringP("payer", "p", "", "Member keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+	cliutil.AddExportFlag(cmd)
 
 	cmd.MarkFlagRequired("multisig")
 	cmd.MarkFlagRequired("transaction")
@@ -52,15 +58,12 @@ squads-cli transaction execute \
 func runExecuteTransaction(cmd *cobra.Command, args []string) {
 	ctx := context.Background()
 
-	// Load RPC endpoints
-	rpcEndpoint, _ := cmd.Parent().Parent().Flags().GetString("rpc")
-	wsEndpoint, _ := cmd.Parent().Parent().Flags().GetString("ws")
-
 	// Get flags
 	multisigStr, _ := cmd.Flags().GetString("multisig")
 	transactionIndex, _ := cmd.Flags().GetUint64("transaction")
 	payerPath, _ := cmd.Flags().GetString("payer")
 	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
 
 	// Parse multisig address
 	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
@@ -68,23 +71,32 @@ func runExecuteTransaction(cmd *cobra.Command, args []string) {
 		log.Fatalf("Invalid multisig address: %v", err)
 	}
 
-	// Load payer keypair
-	executor, err := transaction.LoadKeypair(payerPath)
+	// Load the executor's signer
+	executor, err := cliutil.LoadSigner(ctx, payerPath)
 	if err != nil {
-		log.Fatalf("Failed to load payer keypair: %v", err)
+		log.Fatalf("Failed to load payer signer: %v", err)
 	}
 
-	// Set up RPC and WebSocket clients
-	client := rpc.New(rpcEndpoint)
-	wsClient, err := ws.Connect(ctx, wsEndpoint)
+	// Set up the Squads client
+	cluster, err := cliutil.ResolveCluster(cmd)
+	if err != nil {
+		log.Fatalf("Invalid cluster: %v", err)
+	}
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
 	if err != nil {
-		log.Fatalf("Failed to connect to WebSocket: %v", err)
+		log.Fatalf("Failed to set up client: %v", err)
 	}
-	defer wsClient.Close()
+	defer client.Close()
 
 	// Calculate transaction and proposal PDAs for logging
-	txPDA, _ := multisig.GetTransactionPDA(multisigPDA, transactionIndex)
-	proposalPDA, _ := multisig.GetProposalPDA(multisigPDA, transactionIndex)
+	txPDA, _, err := client.TransactionPDA(multisigPDA, transactionIndex)
+	if err != nil {
+		log.Fatalf("Failed to derive transaction PDA: %v", err)
+	}
+	proposalPDA, _, err := client.ProposalPDA(multisigPDA, transactionIndex)
+	if err != nil {
+		log.Fatalf("Failed to derive proposal PDA: %v", err)
+	}
 
 	// Log starting execution
 	log.Printf("Executing transaction #%d on multisig %s...", transactionIndex, multisigPDA)
@@ -92,12 +104,37 @@ func runExecuteTransaction(cmd *cobra.Command, args []string) {
 	log.Printf("Proposal PDA: %s", proposalPDA)
 	log.Printf("Executor: %s", executor.PublicKey())
 
+	input := transaction.ProposalExecuteInput{
+		Multisig:         multisigPDA,
+		TransactionIndex: transactionIndex,
+		Executor:         executor,
+	}
+
+	if exportPath != "" {
+		tx, _, err := client.PrepareExecute(ctx, input)
+		if err != nil {
+			log.Fatalf("Failed to prepare execution: %v", err)
+		}
+		err = cliutil.ExportTransaction(exportPath, tx, transactionIndex,
+			"Action: execute proposal",
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+			fmt.Sprintf("Transaction Index: %d", transactionIndex),
+			fmt.Sprintf("Transaction PDA: %s", txPDA),
+			fmt.Sprintf("Proposal PDA: %s", proposalPDA),
+			fmt.Sprintf("Executor: %s", executor.PublicKey()),
+		)
+		if err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
 	// Set context with timeout
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
 	defer cancel()
 
 	// Execute the transaction
-	output, err := transaction.ExecuteProposal(ctxWithTimeout, multisigPDA, transactionIndex, executor, client, wsClient)
+	output, err := client.ExecuteProposal(ctxWithTimeout, input)
 	if err != nil {
 		log.Fatalf("Failed to execute transaction: %v", err)
 	}
@@ -113,9 +150,9 @@ func runExecuteTransaction(cmd *cobra.Command, args []string) {
 	fmt.Println("\nYou can view this transaction on Solana Explorer:")
 
 	// Check network type to determine explorer URL
-	if strings.Contains(rpcEndpoint, "devnet") {
+	if cluster.Name == "devnet" || strings.Contains(cluster.RPC, "devnet") {
 		fmt.Printf("https://explorer.solana.com/tx/%s?cluster=devnet\n", output.Signature)
-	} else if strings.Contains(rpcEndpoint, "testnet") {
+	} else if cluster.Name == "testnet" || strings.Contains(cluster.RPC, "testnet") {
 		fmt.Printf("https://explorer.solana.com/tx/%s?cluster=testnet\n", output.Signature)
 	} else {
 		fmt.Printf("https://explorer.solana.com/tx/%s\n", output.Signature)
@@ -0,0 +1,155 @@
+package multisigtransaction
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewListCommand creates the command for listing transaction proposals
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the transaction proposals of a Squads Multisig",
+		Long: `List the transaction proposals of a Squads Multisig, newest first.
+
+Proposals are fetched in batches, so long histories are cheap to scan. When
+more proposals match than --limit, the command prints a --cursor value that
+continues the listing.
+
+Examples:
+# Latest proposals
+squads-cli transaction list --multisig MULTISIG_ADDRESS
+
+# Proposals waiting for votes, excluding stale ones
+squads-cli transaction list --multisig MULTISIG_ADDRESS --status active --hide-stale
+
+# Everything a member proposed between index 100 and 200
+squads-cli transaction list --multisig MULTISIG_ADDRESS --proposer MEMBER --from 100 --to 200
+`,
+		Run: runListTransactions,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().Uint64("from", 0, "Lowest transaction index to scan (default 1)")
+	cmd.Flags().Uint64("to", 0, "Highest transaction index to scan (default: the latest)")
+	cmd.Flags().StringSlice("status", nil, "Only these states: draft, active, rejected, approved, executing, executed, cancelled")
+	cmd.Flags().String("proposer", "", "Only transactions created by this member")
+	cmd.Flags().Bool("stale", false, "Only stale proposals")
+	cmd.Flags().Bool("hide-stale", false, "Exclude stale proposals")
+	cmd.Flags().Int("limit", 20, "Maximum number of proposals to show")
+	cmd.Flags().Uint64("cursor", 0, "Continue a previous listing from this cursor")
+
+	cmd.MarkFlagRequired("multisig")
+
+	return cmd
+}
+
+func runListTransactions(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	from, _ := cmd.Flags().GetUint64("from")
+	to, _ := cmd.Flags().GetUint64("to")
+	statuses, _ := cmd.Flags().GetStringSlice("status")
+	proposerStr, _ := cmd.Flags().GetString("proposer")
+	onlyStale, _ := cmd.Flags().GetBool("stale")
+	hideStale, _ := cmd.Flags().GetBool("hide-stale")
+	limit, _ := cmd.Flags().GetInt("limit")
+	cursor, _ := cmd.Flags().GetUint64("cursor")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	var filter transaction.ProposalFilter
+	for _, status := range statuses {
+		state, err := transaction.ParseProposalState(status)
+		if err != nil {
+			log.Fatalf("Invalid --status: %v", err)
+		}
+		filter.States = append(filter.States, state)
+	}
+	if proposerStr != "" {
+		proposer, err := solana.PublicKeyFromBase58(proposerStr)
+		if err != nil {
+			log.Fatalf("Invalid proposer: %v", err)
+		}
+		filter.Proposer = &proposer
+	}
+	if onlyStale && hideStale {
+		log.Fatalf("--stale and --hide-stale are mutually exclusive")
+	}
+	if onlyStale || hideStale {
+		filter.Stale = &onlyStale
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, false)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	page, err := client.ListProposals(ctx, transaction.ListProposalsInput{
+		Multisig: multisigPDA,
+		From:     from,
+		To:       to,
+		Filter:   filter,
+		Limit:    limit,
+		Cursor:   cursor,
+	})
+	if err != nil {
+		log.Fatalf("Failed to list proposals: %v", err)
+	}
+
+	fmt.Println("═════════════════════════════════════════")
+	fmt.Println("           TRANSACTION PROPOSALS         ")
+	fmt.Println("═════════════════════════════════════════")
+	if len(page.Proposals) == 0 {
+		fmt.Println("No matching proposals.")
+	}
+	for _, entry := range page.Proposals {
+		state := string(entry.State())
+		if state == "" {
+			state = "no proposal"
+		}
+		if entry.Stale {
+			state += ", stale"
+		}
+		fmt.Printf("#%d  %s  [%s]\n", entry.TransactionIndex, describeTransaction(entry.Transaction), state)
+		if creator := entry.Creator(); !creator.IsZero() {
+			fmt.Printf("    Creator: %s\n", creator)
+		}
+		if entry.Proposal != nil {
+			fmt.Printf("    Approvals: %d, Rejections: %d, Cancellations: %d\n",
+				len(entry.Proposal.Approved), len(entry.Proposal.Rejected), len(entry.Proposal.Cancelled))
+		}
+	}
+
+	if page.NextCursor != 0 {
+		fmt.Println("\nMore proposals match. Continue with:")
+		fmt.Printf("  --cursor %d\n", page.NextCursor)
+	}
+}
+
+// describeTransaction names the kind of transaction behind a proposal.
+func describeTransaction(tx interface{}) string {
+	switch tx := tx.(type) {
+	case *squads_multisig_program.VaultTransaction:
+		return fmt.Sprintf("Vault transaction (vault %d)", tx.VaultIndex)
+	case *squads_multisig_program.ConfigTransaction:
+		return fmt.Sprintf("Config transaction (%d actions)", len(tx.Actions))
+	case *squads_multisig_program.Batch:
+		return fmt.Sprintf("Batch (vault %d, %d transactions)", tx.VaultIndex, tx.Size)
+	default:
+		return "Closed transaction"
+	}
+}
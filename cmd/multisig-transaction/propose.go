@@ -0,0 +1,278 @@
+package multisigtransaction
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+	"github.com/spf13/cobra"
+
+	squads "github.com/hogyzen12/squads-go"
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewProposeCommand creates the command for proposing arbitrary instructions
+func NewProposeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose",
+		Short: "Propose arbitrary instructions for the vault to execute",
+		Long: `Propose a vault transaction carrying arbitrary instructions.
+
+The instructions come either from a JSON file or from a transaction built by
+another tool. Inside them, only the vault (and, with --ephemeral-signers, the
+transaction's ephemeral signer PDAs) may be signers. Ephemeral signers are
+derived from the next transaction index; print them with
+"squads-cli pda ephemeral-signer".
+
+--instructions takes a JSON array:
+[
+  {
+    "programId": "PROGRAM_ID",
+    "accounts": [{"pubkey": "VAULT_ADDRESS", "isSigner": true, "isWritable": true}],
+    "data": "BASE64_DATA"
+  }
+]
+Data is base64 unless it starts with 0x or the instruction sets "encoding": "hex".
+
+--from-tx takes a base64 transaction (or @FILE containing one), for example
+one built by a dApp with the vault as signer. Its fee payer and signatures are
+dropped; lookup tables it uses are fetched and reused.
+
+--lookup-table compiles the vault transaction against address lookup tables,
+so that transactions touching many accounts fit. Executing it later fetches
+the same tables automatically.
+
+A vault transaction still too large for one transaction is uploaded in chunks
+to a transaction buffer first (up to 4000 bytes), which takes several
+transactions. --timeout covers the whole upload, not each transaction, so
+raise it for large uploads on a congested network. If the upload is
+interrupted, running the same command again resumes it; "squads-cli
+transaction close-buffer" abandons it instead.
+--buffer-index picks another buffer, e.g. to upload two proposals at once.
+
+Examples:
+squads-cli transaction propose \
+--multisig MULTISIG_ADDRESS \
+--instructions instructions.json \
+--payer /path/to/payer.json
+
+squads-cli transaction propose \
+--multisig MULTISIG_ADDRESS \
+--from-tx @transaction.b64 \
+--payer /path/to/payer.json
+`,
+		Run: runPropose,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().StringP("instructions", "i", "", "JSON file with the instructions to propose")
+	cmd.Flags().String("from-tx", "", "Base64 transaction to import, or @FILE")
+	cmd.Flags().StringP("payer", "p", "", "Proposer keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().Uint8P("vault-index", "v", 0, "Vault index (default 0)")
+	cmd.Flags().StringSlice("lookup-table", nil, "Address lookup tables to compile the vault transaction against (repeatable)")
+	cmd.Flags().Uint8("ephemeral-signers", 0, "Number of ephemeral signer PDAs the instructions use as signers")
+	cmd.Flags().Uint8("buffer-index", 0, "Transaction buffer to upload through if the transaction is too large")
+	cmd.Flags().StringP("memo", "", "", "Transaction memo (optional)")
+	cmd.Flags().BoolP("approve", "", true, "Auto-approve the transaction (default true)")
+	cmd.Flags().Bool("draft", false, "Create the proposal as a draft, activated later with \"transaction activate\"")
+	cmd.Flags().Uint32P("timeout", "", 300, "Confirmation timeout in seconds, for the whole upload when using a buffer (default 300)")
+	cliutil.AddExportFlag(cmd)
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+func runPropose(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	instructionsPath, _ := cmd.Flags().GetString("instructions")
+	fromTx, _ := cmd.Flags().GetString("from-tx")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	vaultIndex, _ := cmd.Flags().GetUint8("vault-index")
+	ephemeralSigners, _ := cmd.Flags().GetUint8("ephemeral-signers")
+	bufferIndex, _ := cmd.Flags().GetUint8("buffer-index")
+	lookupTableStrs, _ := cmd.Flags().GetStringSlice("lookup-table")
+	memo, _ := cmd.Flags().GetString("memo")
+	autoApprove, _ := cmd.Flags().GetBool("approve")
+	draft, _ := cmd.Flags().GetBool("draft")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+	// A draft cannot be voted on, so --approve defaults to off for drafts and
+	// asking for both is an error rather than silently dropping the approval.
+	if draft && cmd.Flags().Changed("approve") && autoApprove {
+		log.Fatalf("--draft and --approve cannot be used together: a draft cannot be approved until it is activated with \"transaction activate\"")
+	}
+	if draft {
+		autoApprove = false
+	}
+
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	lookupTables, err := fetchLookupTables(ctx, client, lookupTableStrs)
+	if err != nil {
+		log.Fatalf("Failed to load lookup tables: %v", err)
+	}
+
+	var instructions []solana.Instruction
+	switch {
+	case instructionsPath != "" && fromTx != "":
+		log.Fatalf("--instructions and --from-tx are mutually exclusive")
+	case instructionsPath != "":
+		data, err := os.ReadFile(instructionsPath)
+		if err != nil {
+			log.Fatalf("Failed to read instructions: %v", err)
+		}
+		instructions, err = transaction.ParseInstructionsJSON(data)
+		if err != nil {
+			log.Fatalf("Invalid instructions file: %v", err)
+		}
+	case fromTx != "":
+		if strings.HasPrefix(fromTx, "@") {
+			data, err := os.ReadFile(strings.TrimPrefix(fromTx, "@"))
+			if err != nil {
+				log.Fatalf("Failed to read transaction: %v", err)
+			}
+			fromTx = string(data)
+		}
+		tx, err := solana.TransactionFromBase64(strings.TrimSpace(fromTx))
+		if err != nil {
+			log.Fatalf("Invalid --from-tx transaction: %v", err)
+		}
+		// Accounts the imported transaction loads from lookup tables are
+		// resolved with, and compiled against, the same tables.
+		if lookups := tx.Message.GetAddressTableLookups(); len(lookups) > 0 {
+			importedTables, err := transaction.FetchAddressLookupTables(ctx, client.Options(), lookups.GetTableIDs())
+			if err != nil {
+				log.Fatalf("Failed to fetch the transaction's lookup tables: %v", err)
+			}
+			if err := tx.Message.SetAddressTables(transaction.AddressTables(importedTables)); err != nil {
+				log.Fatalf("Failed to resolve the transaction's lookup tables: %v", err)
+			}
+			lookupTables = append(lookupTables, importedTables...)
+		}
+		instructions, err = transaction.InstructionsFromTransaction(tx)
+		if err != nil {
+			log.Fatalf("Failed to import transaction: %v", err)
+		}
+	default:
+		log.Fatalf("One of --instructions or --from-tx is required")
+	}
+
+	log.Printf("Proposing %d instructions from vault %d", len(instructions), vaultIndex)
+
+	input := transaction.VaultTransactionCreateInput{
+		Multisig:            multisigPDA,
+		Creator:             payer,
+		Instructions:        instructions,
+		VaultIndex:          vaultIndex,
+		Memo:                memo,
+		Draft:               draft,
+		AutoApprove:         autoApprove,
+		EphemeralSigners:    ephemeralSigners,
+		AddressLookupTables: lookupTables,
+		BufferIndex:         bufferIndex,
+	}
+
+	if exportPath != "" {
+		tx, output, err := client.PrepareVaultTransaction(ctx, input)
+		if err != nil {
+			log.Fatalf("Failed to prepare transaction: %v", err)
+		}
+		summary := []string{
+			"Action: create vault transaction",
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+			fmt.Sprintf("Transaction Index: %d", output.TransactionIndex),
+			fmt.Sprintf("Vault: %s", output.VaultPDA),
+		}
+		for i, ix := range instructions {
+			summary = append(summary, fmt.Sprintf("Instruction %d: %s", i+1, describeInstruction(ix)))
+		}
+		summary = append(summary,
+			fmt.Sprintf("Creator: %s", payer.PublicKey()),
+			fmt.Sprintf("Auto-approve: %t", autoApprove),
+			fmt.Sprintf("Draft: %t", draft))
+		if err := cliutil.ExportTransaction(exportPath, tx, output.TransactionIndex, summary...); err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.CreateVaultTransaction(ctxWithTimeout, input)
+	if err != nil {
+		log.Fatalf("Failed to create transaction: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("       TRANSACTION CREATED SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", output.Signature)
+	fmt.Printf("Transaction Index: %d\n", output.TransactionIndex)
+	fmt.Printf("Transaction PDA: %s\n", output.TransactionPDA)
+	fmt.Printf("Proposal PDA: %s\n", output.ProposalPDA)
+	fmt.Printf("Vault: %s\n", output.VaultPDA)
+	if !output.TransactionBuffer.IsZero() {
+		fmt.Printf("Uploaded Through Buffer: %s (closed)\n", output.TransactionBuffer)
+	}
+	for i, ix := range instructions {
+		fmt.Printf("Instruction %d: %s\n", i+1, describeInstruction(ix))
+	}
+	for i, ephemeralSigner := range output.EphemeralSigners {
+		fmt.Printf("Ephemeral Signer %d: %s\n", i, ephemeralSigner)
+	}
+
+	switch {
+	case draft:
+		fmt.Println("\nThe proposal is a draft and must be activated before members can vote:")
+		fmt.Printf("  squads-cli transaction activate --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+			multisigPDA, output.TransactionIndex)
+	case autoApprove && output.Threshold <= 1:
+		fmt.Println("\nThe proposal was approved by the creator and has reached its threshold.")
+	case autoApprove:
+		fmt.Printf("\nThe proposal was approved by the creator. Waiting for %d more approvals.\n", output.Threshold-1)
+	default:
+		fmt.Println("\nTransaction requires explicit approval. Use the following command to approve:")
+		fmt.Printf("  squads-cli transaction approve --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+			multisigPDA, output.TransactionIndex)
+	}
+}
+
+// describeInstruction renders an instruction's program and account count.
+func describeInstruction(ix solana.Instruction) string {
+	data, _ := ix.Data()
+	return fmt.Sprintf("program %s, %d accounts, %d bytes of data", ix.ProgramID(), len(ix.Accounts()), len(data))
+}
+
+// fetchLookupTables loads the address lookup tables given on the command line.
+func fetchLookupTables(ctx context.Context, client *squads.Client, values []string) ([]addresslookuptable.KeyedAddressLookupTable, error) {
+	keys, err := cliutil.ParseKeys(values)
+	if err != nil {
+		return nil, err
+	}
+	return transaction.FetchAddressLookupTables(ctx, client.Options(), keys)
+}
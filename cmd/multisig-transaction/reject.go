@@ -0,0 +1,43 @@
+package multisigtransaction
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewRejectCommand creates the command for rejecting a transaction proposal
+func NewRejectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reject",
+		Short: "Reject a transaction proposal for a Squads Multisig",
+		Long: `Reject an active transaction proposal for a Squads Multisig.
+
+Each member with "Vote" permission can reject an active proposal once; a
+member who approved it can change the vote to a rejection. The proposal
+becomes Rejected once so many members have rejected it that the others can no
+longer reach the threshold: the cutoff is the number of voters minus the
+threshold, plus one. The command reports whether your rejection tipped it.
+
+Examples:
+# Reject a proposal, explaining why
+squads-cli transaction reject \
+--multisig MULTISIG_ADDRESS \
+--transaction TRANSACTION_INDEX \
+--memo "wrong recipient" \
+--payer /path/to/payer.json
+
+# Export the rejection for an offline member to sign
+squads-cli transaction reject \
+--multisig MULTISIG_ADDRESS \
+--transaction TRANSACTION_INDEX \
+--payer MEMBER_PUBLIC_KEY \
+--export reject.json
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runVote(cmd, "reject")
+		},
+	}
+
+	addVoteFlags(cmd, "reject")
+
+	return cmd
+}
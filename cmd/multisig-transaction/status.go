@@ -0,0 +1,123 @@
+package multisigtransaction
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewStatusCommand creates the command for showing where a proposal stands
+func NewStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status and votes of a proposal",
+		Long: `Show the status of one proposal, its votes against the threshold and what
+can be done with it next.
+
+A draft is not open for voting yet; it must be activated first. An active
+proposal collects approvals and rejections until it is approved or rejected.
+
+Example:
+squads-cli transaction status --multisig MULTISIG_ADDRESS --transaction TRANSACTION_INDEX
+`,
+		Run: runStatus,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().Uint64P("transaction", "t", 0, "Transaction index (REQUIRED)")
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("transaction")
+
+	return cmd
+}
+
+func runStatus(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	transactionIndex, _ := cmd.Flags().GetUint64("transaction")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, false)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	multisigAccount, err := client.FetchMultisig(ctx, multisigPDA)
+	if err != nil {
+		log.Fatalf("Failed to fetch multisig account: %v", err)
+	}
+	if transactionIndex == 0 || transactionIndex > multisigAccount.TransactionIndex {
+		log.Fatalf("Transaction #%d does not exist; the multisig has %d transactions", transactionIndex, multisigAccount.TransactionIndex)
+	}
+	page, err := client.ListProposals(ctx, transaction.ListProposalsInput{
+		Multisig: multisigPDA,
+		From:     transactionIndex,
+		To:       transactionIndex,
+	})
+	if err != nil {
+		log.Fatalf("Failed to fetch proposal: %v", err)
+	}
+	if len(page.Proposals) == 0 {
+		fmt.Printf("Transaction #%d and its proposal have been closed.\n", transactionIndex)
+		return
+	}
+	entry := page.Proposals[0]
+
+	fmt.Println("═════════════════════════════════════════")
+	fmt.Println("            PROPOSAL STATUS              ")
+	fmt.Println("═════════════════════════════════════════")
+	fmt.Printf("Transaction #%d: %s\n", entry.TransactionIndex, describeTransaction(entry.Transaction))
+	fmt.Printf("Transaction PDA: %s\n", entry.TransactionPDA)
+	fmt.Printf("Proposal PDA: %s\n", entry.ProposalPDA)
+	if creator := entry.Creator(); !creator.IsZero() {
+		fmt.Printf("Creator: %s\n", creator)
+	}
+	if entry.Proposal == nil {
+		fmt.Println("Status: No proposal")
+		return
+	}
+	fmt.Printf("Status: %s\n", transaction.DescribeProposalStatus(entry.Proposal.Status))
+	if entry.Stale {
+		fmt.Println("Stale: yes, the multisig's config changed after it was created")
+	}
+	fmt.Printf("Approvals: %d/%d\n", len(entry.Proposal.Approved), multisigAccount.Threshold)
+	fmt.Printf("Rejections: %d/%d\n", len(entry.Proposal.Rejected), transaction.RejectionCutoff(multisigAccount))
+	fmt.Printf("Cancellations: %d/%d\n", len(entry.Proposal.Cancelled), multisigAccount.Threshold)
+
+	switch state := entry.State(); {
+	case entry.Stale && (state == transaction.StateDraft || state == transaction.StateActive):
+		fmt.Println("\nThe proposal is stale and can no longer be activated or voted on.")
+	case state == transaction.StateDraft:
+		fmt.Println("\nThe proposal is a draft and not open for voting. To activate it, run:")
+		fmt.Printf("  squads-cli transaction activate --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+			multisigPDA, transactionIndex)
+	case state == transaction.StateActive:
+		fmt.Println("\nThe proposal is open for voting. To approve it, run:")
+		fmt.Printf("  squads-cli transaction approve --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+			multisigPDA, transactionIndex)
+	case state == transaction.StateApproved:
+		execute := fmt.Sprintf("transaction execute --multisig %s --transaction %d", multisigPDA, transactionIndex)
+		switch entry.Transaction.(type) {
+		case *squads_multisig_program.ConfigTransaction:
+			execute = fmt.Sprintf("config execute --multisig %s --transaction %d", multisigPDA, transactionIndex)
+		case *squads_multisig_program.Batch:
+			execute = fmt.Sprintf("batch execute --multisig %s --batch %d", multisigPDA, transactionIndex)
+		}
+		fmt.Println("\nThe proposal is approved. To execute it, run:")
+		fmt.Printf("  squads-cli %s --payer /path/to/keypair.json\n", execute)
+	}
+}
@@ -0,0 +1,147 @@
+package multisigtransaction
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// voteBanners holds the banner title shown after each vote action.
+var voteBanners = map[string]string{
+	"approve": "      TRANSACTION APPROVED SUCCESSFULLY",
+	"reject":  "      TRANSACTION REJECTED SUCCESSFULLY",
+	"cancel":  "     TRANSACTION CANCELLED SUCCESSFULLY",
+}
+
+// addVoteFlags adds the flags shared by the approve, reject and cancel
+// commands.
+func addVoteFlags(cmd *cobra.Command, action string) {
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().Uint64P("transaction", "t", 0, fmt.Sprintf("Transaction index to %s (REQUIRED)", action))
+	cmd.Flags().StringP("payer", "p", "", "Member keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().StringP("memo", "", "", fmt.Sprintf("Optional memo for the %s vote", action))
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+	cliutil.AddExportFlag(cmd)
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("transaction")
+	cmd.MarkFlagRequired("payer")
+}
+
+// runVote casts an approve, reject or cancel vote from the flags added by
+// addVoteFlags and reports where it leaves the proposal.
+func runVote(cmd *cobra.Command, action string) {
+	ctx := context.Background()
+
+	// Get flags
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	transactionIndex, _ := cmd.Flags().GetUint64("transaction")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	memo, _ := cmd.Flags().GetString("memo")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
+
+	// Parse multisig address
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	// Load the member's signer
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	// Set up the Squads client
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	input := transaction.ProposalVoteInput{
+		Multisig:         multisigPDA,
+		TransactionIndex: transactionIndex,
+		Voter:            payer,
+		Memo:             memo,
+		Action:           action,
+	}
+
+	if exportPath != "" {
+		tx, output, err := client.PrepareVote(ctx, input)
+		if err != nil {
+			log.Fatalf("Failed to prepare %s vote: %v", action, err)
+		}
+		err = cliutil.ExportTransaction(exportPath, tx, transactionIndex,
+			fmt.Sprintf("Action: %s proposal", action),
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+			fmt.Sprintf("Transaction Index: %d", transactionIndex),
+			fmt.Sprintf("Proposal PDA: %s", output.ProposalPDA),
+			fmt.Sprintf("Voter: %s", payer.PublicKey()),
+			fmt.Sprintf("Settles the proposal: %t", output.Tipped),
+		)
+		if err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
+	log.Printf("Casting %s vote on transaction #%d of multisig %s...", action, transactionIndex, multisigPDA)
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.VoteOnProposal(ctxWithTimeout, input)
+	if err != nil {
+		log.Fatalf("Failed to %s transaction: %v", action, err)
+	}
+
+	// Display successful result
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println(voteBanners[action])
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", output.Signature)
+	fmt.Printf("Transaction Status: %s\n", output.CurrentStatus)
+
+	switch action {
+	case "approve":
+		fmt.Printf("Approvals: %d/%d\n", output.Approvals, output.Threshold)
+		if !output.Tipped {
+			fmt.Printf("\nTransaction needs %d more approval(s) to reach threshold.\n", int(output.Threshold)-output.Approvals)
+			return
+		}
+		fmt.Println("\nTransaction has reached approval threshold! 🎉")
+		if output.ExecutableAfter != nil && output.ExecutableAfter.After(time.Now()) {
+			fmt.Printf("Due to timelock, it will be executable after: %s\n",
+				output.ExecutableAfter.Format("2006-01-02 15:04:05"))
+			return
+		}
+		fmt.Println("Transaction is ready for execution!")
+		fmt.Printf("\nTo execute this transaction, run:\n")
+		fmt.Printf("  squads-cli transaction execute --multisig %s --transaction %d --payer %s\n",
+			multisigPDA, transactionIndex, payerPath)
+	case "reject":
+		fmt.Printf("Rejections: %d/%d\n", output.Rejections, output.Cutoff)
+		if output.Tipped {
+			fmt.Printf("\nThis rejection tipped the proposal into Rejected: the other members can no longer reach the threshold of %d.\n",
+				output.Threshold)
+		} else {
+			fmt.Printf("\nThe proposal stays active; %d more rejection(s) would reject it.\n", output.Cutoff-output.Rejections)
+		}
+	case "cancel":
+		fmt.Printf("Cancellations: %d/%d\n", output.Cancelled, output.Threshold)
+		if output.Tipped {
+			fmt.Println("\nThis cancellation tipped the proposal into Cancelled; it can no longer be executed.")
+		} else {
+			fmt.Printf("\nThe proposal stays approved; %d more cancellation(s) would cancel it.\n", int(output.Threshold)-output.Cancelled)
+		}
+	}
+}
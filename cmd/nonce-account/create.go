@@ -0,0 +1,124 @@
+package nonceaccount
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewCreateCommand creates the command for creating a durable nonce account
+func NewCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a durable nonce account",
+		Long: `Create a durable nonce account for use with --nonce-account.
+
+Transactions built with a durable nonce stay valid until the nonce is
+advanced, instead of expiring with the recent blockhash after about a
+minute. This makes multi-party and offline signing practical.
+
+The nonce authority signs every transaction that uses the nonce. It defaults
+to the payer. With --multisig the vault becomes the authority, so the nonce
+can only be advanced, withdrawn or re-authorized through vault transactions.
+
+Examples:
+# Nonce controlled by the payer
+squads-cli nonce create --payer /path/to/payer.json
+
+# Nonce controlled by a cold member
+squads-cli nonce create --payer /path/to/payer.json --authority MEMBER_PUBLIC_KEY
+
+# Nonce owned by the multisig vault
+squads-cli nonce create --payer /path/to/payer.json --multisig MULTISIG_ADDRESS
+`,
+		Run: runCreate,
+	}
+
+	cmd.Flags().StringP("payer", "p", "", "Payer keypair path or remote signer URL (REQUIRED)")
+	cmd.Flags().String("authority", "", "Nonce authority public key (default: the payer)")
+	cmd.Flags().StringP("multisig", "m", "", "Make the vault of this multisig the nonce authority")
+	cmd.Flags().Uint8P("vault-index", "v", 0, "Vault index used with --multisig (default 0)")
+	cmd.Flags().String("nonce-keypair", "", "Keypair for the nonce account address (default: a new random key)")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+
+	payerPath, _ := cmd.Flags().GetString("payer")
+	authorityStr, _ := cmd.Flags().GetString("authority")
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	vaultIndex, _ := cmd.Flags().GetUint8("vault-index")
+	nonceKeypairPath, _ := cmd.Flags().GetString("nonce-keypair")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+
+	if authorityStr != "" && multisigStr != "" {
+		log.Fatalf("--authority and --multisig are mutually exclusive")
+	}
+
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	nonceKey := solana.NewWallet().PrivateKey
+	if nonceKeypairPath != "" {
+		nonceKey, err = transaction.LoadKeypair(nonceKeypairPath)
+		if err != nil {
+			log.Fatalf("Failed to load nonce keypair: %v", err)
+		}
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, true)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	authority := payer.PublicKey()
+	switch {
+	case authorityStr != "":
+		authority, err = solana.PublicKeyFromBase58(authorityStr)
+		if err != nil {
+			log.Fatalf("Invalid authority: %v", err)
+		}
+	case multisigStr != "":
+		multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+		if err != nil {
+			log.Fatalf("Invalid multisig address: %v", err)
+		}
+		authority, _, err = client.VaultPDA(multisigPDA, vaultIndex)
+		if err != nil {
+			log.Fatalf("Failed to derive vault PDA: %v", err)
+		}
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	sig, err := client.CreateNonceAccount(ctxWithTimeout, payer, signer.NewLocal(nonceKey), authority)
+	if err != nil {
+		log.Fatalf("Failed to create nonce account: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("      NONCE ACCOUNT CREATED SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", sig)
+	fmt.Printf("Nonce Account: %s\n", nonceKey.PublicKey())
+	fmt.Printf("Nonce Authority: %s\n", authority)
+	fmt.Println("\nUse it with any command by adding:")
+	fmt.Printf("  --nonce-account %s\n", nonceKey.PublicKey())
+}
@@ -0,0 +1,57 @@
+package nonceaccount
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+)
+
+// NewShowCommand creates the command for displaying a durable nonce account
+func NewShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the current value and authority of a nonce account",
+		Long: `Show the current value and authority of a durable nonce account.
+
+Examples:
+squads-cli nonce show --account NONCE_ACCOUNT
+`,
+		Run: runShow,
+	}
+
+	cmd.Flags().StringP("account", "a", "", "Nonce account address (REQUIRED)")
+	cmd.MarkFlagRequired("account")
+
+	return cmd
+}
+
+func runShow(cmd *cobra.Command, args []string) {
+	accountStr, _ := cmd.Flags().GetString("account")
+	account, err := solana.PublicKeyFromBase58(accountStr)
+	if err != nil {
+		log.Fatalf("Invalid nonce account: %v", err)
+	}
+
+	client, err := cliutil.NewClient(cmd.Context(), cmd, false)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	nonce, err := client.FetchNonce(cmd.Context(), account)
+	if err != nil {
+		log.Fatalf("Failed to fetch nonce account: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("            NONCE ACCOUNT")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Address: %s\n", account)
+	fmt.Printf("Authority: %s\n", nonce.AuthorizedPubkey)
+	fmt.Printf("Nonce: %s\n", solana.Hash(nonce.Nonce))
+	fmt.Printf("Fee per Signature: %d lamports\n", nonce.FeeCalculator.LamportsPerSignature)
+}
@@ -0,0 +1,135 @@
+package offlinesigning
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/offline"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewSignCommand creates the command for signing an exported transaction offline
+func NewSignCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign",
+		Short: "Sign an exported transaction on an offline machine",
+		Long: `Sign a transaction exported with --export using a local keypair.
+
+This command never touches the network, so it can run on an air-gapped
+machine. Every instruction is decoded from the transaction itself and printed
+with its program and accounts; Squads instructions also show their name,
+multisig and transaction index. Review those before signing: the summary
+comes from the file and anyone who can edit the file can change it. The
+signature is added to the file in place unless --out is given.
+
+Examples:
+# Sign an exported approval
+squads-cli sign --in approve.json --keypair /path/to/cold.json
+`,
+		Run: runSign,
+	}
+
+	cmd.Flags().String("in", "", "Exported transaction file (REQUIRED)")
+	cmd.Flags().String("keypair", "", "Keypair used to sign (REQUIRED)")
+	cmd.Flags().String("out", "", "Write the signed transaction here instead of overwriting --in")
+
+	cmd.MarkFlagRequired("in")
+	cmd.MarkFlagRequired("keypair")
+
+	return cmd
+}
+
+func runSign(cmd *cobra.Command, args []string) {
+	inPath, _ := cmd.Flags().GetString("in")
+	keypairPath, _ := cmd.Flags().GetString("keypair")
+	outPath, _ := cmd.Flags().GetString("out")
+	if outPath == "" {
+		outPath = inPath
+	}
+
+	env, err := offline.ReadFile(inPath)
+	if err != nil {
+		log.Fatalf("Failed to read exported transaction: %v", err)
+	}
+
+	key, err := transaction.LoadKeypair(keypairPath)
+	if err != nil {
+		log.Fatalf("Failed to load keypair: %v", err)
+	}
+
+	programIDStr, _ := cmd.Flags().GetString("program-id")
+	var programID solana.PublicKey
+	if programIDStr != "" {
+		programID, err = solana.PublicKeyFromBase58(programIDStr)
+		if err != nil {
+			log.Fatalf("Invalid program ID: %v", err)
+		}
+	}
+	instructions, err := env.Instructions(programID)
+	if err != nil {
+		log.Fatalf("Failed to decode transaction: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("        TRANSACTION TO SIGN")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Println("Summary (from the file, NOT verified; check the instructions below):")
+	for _, line := range env.Summary {
+		fmt.Printf("  %s\n", line)
+	}
+	fmt.Println("\nInstructions (decoded from the transaction):")
+	for i, ix := range instructions {
+		if ix.Name != "" {
+			fmt.Printf("  #%d Squads %s\n", i+1, ix.Name)
+		} else {
+			fmt.Printf("  #%d Non-Squads instruction, %d bytes of data\n", i+1, len(ix.Data))
+		}
+		fmt.Printf("     Program: %s\n", ix.ProgramID)
+		if !ix.Multisig.IsZero() {
+			fmt.Printf("     Multisig: %s\n", ix.Multisig)
+		}
+		switch {
+		case ix.IndexNotFound:
+			fmt.Printf("     WARNING: transaction index not found (tried the file's index and 1..%d); "+
+				"check the proposal and transaction accounts below\n", offline.MaxIndexSearch)
+		case ix.TransactionIndex != 0 && env.TransactionIndex != 0 && ix.TransactionIndex != env.TransactionIndex:
+			fmt.Printf("     Transaction Index: %d (WARNING: the file claims %d)\n", ix.TransactionIndex, env.TransactionIndex)
+		case ix.TransactionIndex != 0:
+			fmt.Printf("     Transaction Index: %d\n", ix.TransactionIndex)
+		}
+		fmt.Println("     Accounts:")
+		for _, account := range ix.Accounts {
+			fmt.Printf("       - %s\n", account)
+		}
+	}
+	fmt.Println()
+	if nonceAccount, ok := sender.UsesNonce(env.Transaction); ok {
+		fmt.Printf("Durable Nonce: %s (account %s)\n", env.Transaction.Message.RecentBlockhash, nonceAccount)
+	} else {
+		fmt.Printf("Recent Blockhash: %s\n", env.Transaction.Message.RecentBlockhash)
+	}
+	fmt.Printf("Signing as: %s\n", key.PublicKey())
+
+	if err := env.Sign(context.Background(), signer.NewLocal(key)); err != nil {
+		log.Fatalf("Failed to sign transaction: %v", err)
+	}
+	if err := env.WriteFile(outPath); err != nil {
+		log.Fatalf("Failed to write signed transaction: %v", err)
+	}
+
+	cliutil.PrintSigners(env)
+	fmt.Printf("\nSigned transaction written to %s\n", outPath)
+	if env.Complete() {
+		fmt.Println("All signatures present. Broadcast it from an online machine with:")
+		fmt.Printf("  squads-cli submit --in %s\n", outPath)
+	} else {
+		fmt.Println("More signatures are required before it can be submitted.")
+	}
+}
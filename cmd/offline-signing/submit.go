@@ -0,0 +1,80 @@
+package offlinesigning
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/offline"
+)
+
+// NewSubmitCommand creates the command for broadcasting a signed transaction
+func NewSubmitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submit",
+		Short: "Broadcast a transaction signed offline",
+		Long: `Broadcast a transaction that was exported with --export and signed
+with 'squads-cli sign'. Every required signature must be present.
+
+Examples:
+# Submit a signed approval
+squads-cli submit --in approve.json
+`,
+		Run: runSubmit,
+	}
+
+	cmd.Flags().String("in", "", "Signed transaction file (REQUIRED)")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+
+	cmd.MarkFlagRequired("in")
+
+	return cmd
+}
+
+func runSubmit(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	inPath, _ := cmd.Flags().GetString("in")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+
+	env, err := offline.ReadFile(inPath)
+	if err != nil {
+		log.Fatalf("Failed to read signed transaction: %v", err)
+	}
+	if !env.Complete() {
+		for _, s := range env.Signers() {
+			if !s.Signed {
+				log.Printf("Missing signature from %s", s.PublicKey)
+			}
+		}
+		log.Fatalf("Transaction is not fully signed")
+	}
+
+	// Set up the Squads client
+	client, err := cliutil.NewClient(ctx, cmd, true)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	result, err := client.Submit(ctxWithTimeout, env.Transaction)
+	if err != nil {
+		log.Fatalf("Failed to submit transaction: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("      TRANSACTION SUBMITTED SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	for _, line := range env.Summary {
+		fmt.Println(line)
+	}
+	fmt.Printf("Transaction Signature: %s\n", result.Signature)
+	fmt.Printf("Slot: %d\n", result.Slot)
+}
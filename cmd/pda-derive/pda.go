@@ -0,0 +1,146 @@
+package pdaderive
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	squads "github.com/hogyzen12/squads-go"
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+)
+
+// NewCommand creates the command group for deriving program addresses
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pda",
+		Short: "Derive Squads program addresses",
+		Long: `Derive the program-derived addresses used by the Squads program and
+print them with their bumps. Nothing is fetched from the network, so the
+output can be used to verify addresses independently. Use --program-id for a
+custom deployment.
+
+Examples:
+squads-cli pda multisig --create-key CREATE_KEY
+squads-cli pda vault --multisig MULTISIG_ADDRESS --index 0
+squads-cli pda proposal --multisig MULTISIG_ADDRESS --transaction 7
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		newKindCommand("program-config", "Derive the program config address", nil,
+			func(c *squads.Client, cmd *cobra.Command) (solana.PublicKey, uint8, error) {
+				return c.ProgramConfigPDA()
+			}),
+		newKindCommand("multisig", "Derive a multisig address from its create key", []string{"create-key"},
+			func(c *squads.Client, cmd *cobra.Command) (solana.PublicKey, uint8, error) {
+				return c.MultisigPDA(keyFlag(cmd, "create-key"))
+			}),
+		newKindCommand("vault", "Derive a vault address", []string{"multisig", "index"},
+			func(c *squads.Client, cmd *cobra.Command) (solana.PublicKey, uint8, error) {
+				return c.VaultPDA(keyFlag(cmd, "multisig"), uint8(indexFlag(cmd, "index", 0xff)))
+			}),
+		newKindCommand("transaction", "Derive a vault, config or batch transaction address", []string{"multisig", "transaction"},
+			func(c *squads.Client, cmd *cobra.Command) (solana.PublicKey, uint8, error) {
+				return c.TransactionPDA(keyFlag(cmd, "multisig"), indexFlag(cmd, "transaction", ^uint64(0)))
+			}),
+		newKindCommand("proposal", "Derive a proposal address", []string{"multisig", "transaction"},
+			func(c *squads.Client, cmd *cobra.Command) (solana.PublicKey, uint8, error) {
+				return c.ProposalPDA(keyFlag(cmd, "multisig"), indexFlag(cmd, "transaction", ^uint64(0)))
+			}),
+		newKindCommand("batch-transaction", "Derive the address of a transaction inside a batch", []string{"multisig", "batch", "index"},
+			func(c *squads.Client, cmd *cobra.Command) (solana.PublicKey, uint8, error) {
+				return c.BatchTransactionPDA(keyFlag(cmd, "multisig"), indexFlag(cmd, "batch", ^uint64(0)), uint32(indexFlag(cmd, "index", 0xffffffff)))
+			}),
+		newKindCommand("ephemeral-signer", "Derive an ephemeral signer of a vault or batch transaction", []string{"multisig", "transaction", "index"},
+			func(c *squads.Client, cmd *cobra.Command) (solana.PublicKey, uint8, error) {
+				txPDA, _, err := c.TransactionPDA(keyFlag(cmd, "multisig"), indexFlag(cmd, "transaction", ^uint64(0)))
+				if err != nil {
+					return solana.PublicKey{}, 0, err
+				}
+				return c.EphemeralSignerPDA(txPDA, uint8(indexFlag(cmd, "index", 0xff)))
+			}),
+		newKindCommand("spending-limit", "Derive a spending limit address from its create key", []string{"multisig", "create-key"},
+			func(c *squads.Client, cmd *cobra.Command) (solana.PublicKey, uint8, error) {
+				return c.SpendingLimitPDA(keyFlag(cmd, "multisig"), keyFlag(cmd, "create-key"))
+			}),
+		newKindCommand("transaction-buffer", "Derive a transaction buffer address", []string{"multisig", "creator", "index"},
+			func(c *squads.Client, cmd *cobra.Command) (solana.PublicKey, uint8, error) {
+				return c.TransactionBufferPDA(keyFlag(cmd, "multisig"), keyFlag(cmd, "creator"), uint8(indexFlag(cmd, "index", 0xff)))
+			}),
+	)
+
+	return cmd
+}
+
+// flagUsage describes the flags shared by the derivation commands.
+var flagUsage = map[string]string{
+	"create-key":  "Create key the account was seeded with",
+	"multisig":    "Multisig PDA address",
+	"creator":     "Member that created the buffer",
+	"index":       "Vault, signer, buffer or inner batch index",
+	"transaction": "Transaction index",
+	"batch":       "Transaction index of the batch",
+}
+
+// newKindCommand builds one derivation command with the given required flags.
+func newKindCommand(
+	kind, short string,
+	flags []string,
+	derive func(*squads.Client, *cobra.Command) (solana.PublicKey, uint8, error),
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   kind,
+		Short: short,
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := cliutil.NewClient(context.Background(), cmd, false)
+			if err != nil {
+				log.Fatalf("Failed to set up client: %v", err)
+			}
+			defer client.Close()
+
+			address, bump, err := derive(client, cmd)
+			if err != nil {
+				log.Fatalf("Failed to derive %s PDA: %v", kind, err)
+			}
+			fmt.Printf("Kind: %s\n", kind)
+			fmt.Printf("Address: %s\n", address)
+			fmt.Printf("Bump: %d\n", bump)
+			fmt.Printf("Program: %s\n", client.ProgramID)
+		},
+	}
+	for _, name := range flags {
+		switch name {
+		case "create-key", "multisig", "creator":
+			cmd.Flags().String(name, "", flagUsage[name]+" (REQUIRED)")
+		default:
+			cmd.Flags().Uint64(name, 0, flagUsage[name]+" (REQUIRED)")
+		}
+		cmd.MarkFlagRequired(name)
+	}
+	return cmd
+}
+
+// keyFlag parses a public key flag.
+func keyFlag(cmd *cobra.Command, name string) solana.PublicKey {
+	value, _ := cmd.Flags().GetString(name)
+	key, err := solana.PublicKeyFromBase58(value)
+	if err != nil {
+		log.Fatalf("Invalid --%s: %v", name, err)
+	}
+	return key
+}
+
+// indexFlag reads an index flag and checks it fits in max.
+func indexFlag(cmd *cobra.Command, name string, max uint64) uint64 {
+	value, _ := cmd.Flags().GetUint64(name)
+	if value > max {
+		log.Fatalf("--%s must be at most %d", name, max)
+	}
+	return value
+}
@@ -0,0 +1,207 @@
+package spendinglimits
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewCommand creates the command group for spending limits
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "spending-limit",
+		Short: "Add, inspect and use spending limits",
+		Long: `Add, inspect and use spending limits.
+
+A spending limit lets the members it lists send up to an amount of SOL or of
+one token per period from a vault, without a proposal. Periods are one-time,
+day, week or month (30 days); the remaining amount refills on the first use
+after a full period has passed.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		NewAddCommand(),
+		NewListCommand(),
+		NewUseCommand(),
+	)
+
+	return cmd
+}
+
+// NewAddCommand creates the command for adding a spending limit
+func NewAddCommand() *cobra.Command {
+	action := addSpendingLimitAction()
+
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a spending limit to a multisig",
+		Long: `Add a spending limit to a multisig.
+
+On an autonomous multisig this proposes a config transaction that members vote
+on and apply with "squads-cli config execute". On a controlled multisig --payer
+must be the config authority and the limit is added immediately.
+
+Example:
+squads-cli spending-limit add \
+--multisig MULTISIG_ADDRESS \
+--amount 1000000000 --period day --members MEMBER1,MEMBER2 \
+--payer /path/to/payer.json
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runAdd(cmd, action.Action(cmd).(*squads_multisig_program.ConfigActionAddSpendingLimit))
+		},
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Proposer, or config authority of a controlled multisig: keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().StringP("memo", "", "", "Memo recorded with the change (optional)")
+	cmd.Flags().BoolP("approve", "", true, "Auto-approve the proposal (default true)")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+	cliutil.AddExportFlag(cmd)
+	action.AddFlags(cmd)
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+// addSpendingLimitAction returns the shared add-spending-limit flags and parser.
+func addSpendingLimitAction() cliutil.ConfigActionCommand {
+	for _, action := range cliutil.ConfigActionCommands {
+		if action.Name == "add-spending-limit" {
+			return action
+		}
+	}
+	panic("add-spending-limit config action is not defined")
+}
+
+func runAdd(cmd *cobra.Command, action *squads_multisig_program.ConfigActionAddSpendingLimit) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	memo, _ := cmd.Flags().GetString("memo")
+	autoApprove, _ := cmd.Flags().GetBool("approve")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	multisigAccount, err := client.FetchMultisig(ctx, multisigPDA)
+	if err != nil {
+		log.Fatalf("Failed to fetch multisig: %v", err)
+	}
+	spendingLimitPDA, _, err := client.SpendingLimitPDA(multisigPDA, action.CreateKey)
+	if err != nil {
+		log.Fatalf("Failed to derive spending limit PDA: %v", err)
+	}
+	description := cliutil.DescribeConfigAction(action)
+	actions := []squads_multisig_program.ConfigAction{action}
+	controlled := !multisigAccount.ConfigAuthority.IsZero()
+
+	if exportPath != "" {
+		summary := []string{
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+			fmt.Sprintf("Change: %s", description),
+			fmt.Sprintf("Spending Limit: %s", spendingLimitPDA),
+		}
+		var tx *solana.Transaction
+		var transactionIndex uint64 // of the proposal, if one is created
+		if controlled {
+			tx, _, err = client.PrepareAdminister(ctx, multisig.AdminInput{
+				Multisig: multisigPDA, Authority: payer, Actions: actions, Memo: memo,
+			})
+			summary = append([]string{"Action: add spending limit (config authority)"}, summary...)
+			summary = append(summary, fmt.Sprintf("Config Authority: %s", payer.PublicKey()))
+		} else {
+			var output *transaction.ConfigTransactionCreateOutput
+			tx, output, err = client.PrepareConfigTransaction(ctx, transaction.ConfigTransactionCreateInput{
+				Multisig: multisigPDA, Creator: payer, Actions: actions, Memo: memo, AutoApprove: autoApprove,
+			})
+			if err == nil {
+				transactionIndex = output.TransactionIndex
+				summary = append([]string{"Action: propose spending limit"}, summary...)
+				summary = append(summary,
+					fmt.Sprintf("Transaction Index: %d", output.TransactionIndex),
+					fmt.Sprintf("Creator: %s", payer.PublicKey()),
+					fmt.Sprintf("Auto-approve: %t", autoApprove))
+			}
+		}
+		if err != nil {
+			log.Fatalf("Failed to prepare transaction: %v", err)
+		}
+		if err := cliutil.ExportTransaction(exportPath, tx, transactionIndex, summary...); err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	if controlled {
+		output, err := client.Administer(ctxWithTimeout, multisig.AdminInput{
+			Multisig: multisigPDA, Authority: payer, Actions: actions, Memo: memo,
+		})
+		if err != nil {
+			log.Fatalf("Failed to add spending limit: %v", err)
+		}
+
+		fmt.Println("\n════════════════════════════════════════")
+		fmt.Println("      SPENDING LIMIT ADDED SUCCESSFULLY")
+		fmt.Println("════════════════════════════════════════")
+		fmt.Printf("Transaction Signature: %s\n", output.Signature)
+		fmt.Printf("Spending Limit: %s\n", spendingLimitPDA)
+		fmt.Printf("Limit: %s\n", description)
+		fmt.Println("\nInspect it with:")
+		fmt.Printf("  squads-cli spending-limit list --multisig %s\n", multisigPDA)
+		return
+	}
+
+	output, err := client.CreateConfigTransaction(ctxWithTimeout, transaction.ConfigTransactionCreateInput{
+		Multisig: multisigPDA, Creator: payer, Actions: actions, Memo: memo, AutoApprove: autoApprove,
+	})
+	if err != nil {
+		log.Fatalf("Failed to propose spending limit: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("    SPENDING LIMIT PROPOSED SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", output.Signature)
+	fmt.Printf("Transaction Index: %d\n", output.TransactionIndex)
+	fmt.Printf("Proposal PDA: %s\n", output.ProposalPDA)
+	fmt.Printf("Spending Limit: %s (created on execution)\n", spendingLimitPDA)
+	fmt.Printf("Limit: %s\n", description)
+	fmt.Println("\nOnce approved, apply it with:")
+	fmt.Printf("  squads-cli config execute --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+		multisigPDA, output.TransactionIndex)
+}
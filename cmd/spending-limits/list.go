@@ -0,0 +1,117 @@
+package spendinglimits
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/spendinglimit"
+	"github.com/hogyzen12/squads-go/pkg/token"
+)
+
+// NewListCommand creates the command for listing a multisig's spending limits
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the spending limits of a multisig",
+		Long: `List the spending limits of a multisig with what each allows right now.
+
+The remaining amount accounts for a reset that is due but not yet recorded on
+chain. Listing scans the program's accounts, so use an RPC endpoint that
+allows getProgramAccounts.
+
+Example:
+squads-cli spending-limit list --multisig MULTISIG_ADDRESS
+`,
+		Run: runList,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.MarkFlagRequired("multisig")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, false)
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	spendingLimits, err := client.ListSpendingLimits(ctx, multisigPDA)
+	if err != nil {
+		log.Fatalf("Failed to list spending limits: %v", err)
+	}
+
+	now := time.Now()
+	decimals := map[solana.PublicKey]uint8{{}: token.SOLDecimals}
+
+	fmt.Println("═════════════════════════════════════════")
+	fmt.Printf("        SPENDING LIMITS FOUND: %d\n", len(spendingLimits))
+	fmt.Println("═════════════════════════════════════════")
+	for i, keyed := range spendingLimits {
+		sl := keyed.Account
+
+		asset := "SOL"
+		if !sl.Mint.IsZero() {
+			asset = sl.Mint.String()
+			if _, ok := decimals[sl.Mint]; !ok {
+				mint, err := token.FetchMint(ctx, client.Options(), sl.Mint)
+				if err != nil {
+					log.Printf("Warning: showing base units for %s: %v", sl.Mint, err)
+				} else {
+					decimals[sl.Mint] = mint.Decimals
+				}
+			}
+		}
+		format := func(amount uint64) string {
+			if d, ok := decimals[sl.Mint]; ok {
+				return token.FormatAmount(amount, d)
+			}
+			return fmt.Sprintf("%d base units", amount)
+		}
+
+		fmt.Printf("%d. %s\n", i+1, keyed.Address)
+		fmt.Printf("   Vault Index: %d\n", sl.VaultIndex)
+		fmt.Printf("   Asset: %s\n", asset)
+		fmt.Printf("   Amount: %s\n", format(sl.Amount))
+		fmt.Printf("   Period: %s\n", sl.Period)
+		fmt.Printf("   Remaining: %s\n", format(spendinglimit.Available(sl, now)))
+		if next, ok := spendinglimit.NextReset(sl, now); ok {
+			fmt.Printf("   Next Reset: %s\n", next.Local().Format(time.RFC1123))
+		} else {
+			fmt.Println("   Next Reset: never (one-time)")
+		}
+		fmt.Println("   Members:")
+		for _, member := range sl.Members {
+			fmt.Printf("     - %s\n", member)
+		}
+		if len(sl.Destinations) == 0 {
+			fmt.Println("   Destinations: any")
+		} else {
+			fmt.Println("   Destinations:")
+			for _, destination := range sl.Destinations {
+				fmt.Printf("     - %s\n", destination)
+			}
+		}
+	}
+	if len(spendingLimits) > 0 {
+		fmt.Println("\nSpend through one with:")
+		fmt.Printf("  squads-cli spending-limit use --multisig %s --spending-limit %s --to DESTINATION --amount AMOUNT --payer /path/to/keypair.json\n",
+			multisigPDA, spendingLimits[0].Address)
+	}
+}
@@ -0,0 +1,158 @@
+package spendinglimits
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/spendinglimit"
+	"github.com/hogyzen12/squads-go/pkg/token"
+)
+
+// NewUseCommand creates the command for spending through a spending limit
+func NewUseCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use",
+		Short: "Send SOL or tokens from a vault through a spending limit",
+		Long: `Send SOL or tokens from a vault through a spending limit, without a proposal.
+
+--payer must be one of the limit's members. The destination and amount are
+checked against the limit before sending. For tokens, --to is the receiving
+wallet; its associated token account is created if needed.
+
+Example:
+squads-cli spending-limit use \
+--multisig MULTISIG_ADDRESS \
+--spending-limit SPENDING_LIMIT_ADDRESS \
+--to RECIPIENT_ADDRESS \
+--amount 0.5 \
+--payer /path/to/member.json
+`,
+		Run: runUse,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().StringP("spending-limit", "s", "", "Spending limit address (REQUIRED)")
+	cmd.Flags().StringP("to", "t", "", "Recipient wallet address (REQUIRED)")
+	cmd.Flags().StringP("amount", "a", "", "Amount in SOL or whole tokens, e.g. 1.5 (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Member keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().StringP("memo", "", "", "Transfer memo (optional)")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+	cliutil.AddExportFlag(cmd)
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("spending-limit")
+	cmd.MarkFlagRequired("to")
+	cmd.MarkFlagRequired("amount")
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+func runUse(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	spendingLimitStr, _ := cmd.Flags().GetString("spending-limit")
+	toStr, _ := cmd.Flags().GetString("to")
+	amountStr, _ := cmd.Flags().GetString("amount")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	memo, _ := cmd.Flags().GetString("memo")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+	spendingLimitPDA, err := solana.PublicKeyFromBase58(spendingLimitStr)
+	if err != nil {
+		log.Fatalf("Invalid spending limit address: %v", err)
+	}
+	destination, err := solana.PublicKeyFromBase58(toStr)
+	if err != nil {
+		log.Fatalf("Invalid recipient address: %v", err)
+	}
+
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	// The amount is given in the limit's asset, so look up its decimals first.
+	spendingLimit, err := accounts.FetchSpendingLimit(ctx, client.Options(), spendingLimitPDA)
+	if err != nil {
+		log.Fatalf("Failed to fetch spending limit: %v", err)
+	}
+	asset, decimals := "SOL", uint8(token.SOLDecimals)
+	if !spendingLimit.Mint.IsZero() {
+		mint, err := token.FetchMint(ctx, client.Options(), spendingLimit.Mint)
+		if err != nil {
+			log.Fatalf("Failed to fetch mint: %v", err)
+		}
+		asset, decimals = spendingLimit.Mint.String(), mint.Decimals
+	}
+	amount, err := token.ParseAmount(amountStr, decimals)
+	if err != nil {
+		log.Fatalf("Invalid --amount: %v", err)
+	}
+	transfer := fmt.Sprintf("%s %s to %s", token.FormatAmount(amount, decimals), asset, destination)
+
+	input := spendinglimit.UseInput{
+		Multisig:      multisigPDA,
+		SpendingLimit: spendingLimitPDA,
+		Member:        payer,
+		Destination:   destination,
+		Amount:        amount,
+		Memo:          memo,
+	}
+
+	if exportPath != "" {
+		tx, output, err := client.PrepareUseSpendingLimit(ctx, input)
+		if err != nil {
+			log.Fatalf("Failed to prepare transfer: %v", err)
+		}
+		err = cliutil.ExportTransaction(exportPath, tx, 0,
+			"Action: use spending limit",
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+			fmt.Sprintf("Spending Limit: %s", spendingLimitPDA),
+			fmt.Sprintf("Transfer: %s from vault %s", transfer, output.VaultPDA),
+			fmt.Sprintf("Member: %s", payer.PublicKey()),
+		)
+		if err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.UseSpendingLimit(ctxWithTimeout, input)
+	if err != nil {
+		log.Fatalf("Failed to use spending limit: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("        TRANSFER SENT SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", output.Signature)
+	fmt.Printf("Transfer: %s\n", transfer)
+	fmt.Printf("Vault: %s\n", output.VaultPDA)
+	if !output.DestinationTokenAccount.IsZero() {
+		fmt.Printf("Destination Token Account: %s\n", output.DestinationTokenAccount)
+	}
+	fmt.Printf("Remaining: %s %s\n", token.FormatAmount(output.RemainingAmount, decimals), asset)
+}
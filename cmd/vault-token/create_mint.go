@@ -0,0 +1,192 @@
+package vaulttoken
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/spf13/cobra"
+
+	"github.com/hogyzen12/squads-go/cmd/cliutil"
+	"github.com/hogyzen12/squads-go/pkg/token"
+	"github.com/hogyzen12/squads-go/pkg/transaction"
+)
+
+// NewCommand creates the command group for vault-owned tokens
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Propose token operations for a vault",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(
+		NewCreateMintCommand(),
+	)
+
+	return cmd
+}
+
+// NewCreateMintCommand creates the command for proposing a new mint owned by
+// a vault
+func NewCreateMintCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-mint",
+		Short: "Propose creating a token mint with the vault as mint authority",
+		Long: `Propose a vault transaction that creates a new token mint.
+
+The mint account is an ephemeral signer of the vault transaction: a PDA the
+Squads program signs for when the transaction executes, so no mint keypair is
+needed. The vault pays the mint's rent and becomes its mint authority, and
+optionally its freeze authority.
+
+Execute the proposal with "squads-cli transaction execute" once approved.
+
+Example:
+squads-cli token create-mint \
+--multisig MULTISIG_ADDRESS \
+--decimals 6 \
+--payer /path/to/payer.json
+`,
+		Run: runCreateMint,
+	}
+
+	cmd.Flags().StringP("multisig", "m", "", "Multisig PDA address (REQUIRED)")
+	cmd.Flags().StringP("payer", "p", "", "Proposer keypair path, remote signer URL, or public key with --export (REQUIRED)")
+	cmd.Flags().Uint8P("vault-index", "v", 0, "Vault index (default 0)")
+	cmd.Flags().Uint8("decimals", 9, "Decimals of the new mint")
+	cmd.Flags().Bool("freeze-authority", false, "Make the vault the freeze authority as well")
+	cmd.Flags().Bool("token-2022", false, "Create the mint under the Token-2022 program")
+	cmd.Flags().StringP("memo", "", "", "Transaction memo (optional)")
+	cmd.Flags().BoolP("approve", "", true, "Auto-approve the transaction (default true)")
+	cmd.Flags().Uint32P("timeout", "", 180, "Transaction confirmation timeout in seconds (default 180)")
+	cliutil.AddExportFlag(cmd)
+
+	cmd.MarkFlagRequired("multisig")
+	cmd.MarkFlagRequired("payer")
+
+	return cmd
+}
+
+func runCreateMint(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+
+	multisigStr, _ := cmd.Flags().GetString("multisig")
+	payerPath, _ := cmd.Flags().GetString("payer")
+	vaultIndex, _ := cmd.Flags().GetUint8("vault-index")
+	decimals, _ := cmd.Flags().GetUint8("decimals")
+	withFreezeAuthority, _ := cmd.Flags().GetBool("freeze-authority")
+	token2022, _ := cmd.Flags().GetBool("token-2022")
+	memo, _ := cmd.Flags().GetString("memo")
+	autoApprove, _ := cmd.Flags().GetBool("approve")
+	timeoutSecs, _ := cmd.Flags().GetUint32("timeout")
+	exportPath, _ := cmd.Flags().GetString("export")
+
+	multisigPDA, err := solana.PublicKeyFromBase58(multisigStr)
+	if err != nil {
+		log.Fatalf("Invalid multisig address: %v", err)
+	}
+
+	payer, err := cliutil.LoadSigner(ctx, payerPath)
+	if err != nil {
+		log.Fatalf("Failed to load payer signer: %v", err)
+	}
+
+	client, err := cliutil.NewClient(ctx, cmd, exportPath == "")
+	if err != nil {
+		log.Fatalf("Failed to set up client: %v", err)
+	}
+	defer client.Close()
+
+	tokenProgram := solana.TokenProgramID
+	if token2022 {
+		tokenProgram = solana.Token2022ProgramID
+	}
+
+	rent, err := client.RPC.GetMinimumBalanceForRentExemption(ctx, token.MintSize, client.Commitment)
+	if err != nil {
+		log.Fatalf("Failed to fetch the mint's rent exemption: %v", err)
+	}
+
+	vaultPDA, _, err := client.VaultPDA(multisigPDA, vaultIndex)
+	if err != nil {
+		log.Fatalf("Failed to derive vault PDA: %v", err)
+	}
+	if balance, err := client.RPC.GetBalance(ctx, vaultPDA, client.Commitment); err != nil {
+		log.Printf("Warning: Unable to fetch vault balance: %v", err)
+	} else if balance.Value < rent {
+		log.Printf("Warning: Vault holds %s SOL; it needs %s SOL for the mint's rent by the time the proposal executes",
+			token.FormatAmount(balance.Value, token.SOLDecimals), token.FormatAmount(rent, token.SOLDecimals))
+	}
+
+	input := transaction.VaultTransactionCreateInput{
+		Multisig:         multisigPDA,
+		Creator:          payer,
+		VaultIndex:       vaultIndex,
+		Memo:             memo,
+		AutoApprove:      autoApprove,
+		EphemeralSigners: 1,
+		BuildInstructions: func(vaultPDA solana.PublicKey, ephemeralSigners []solana.PublicKey) ([]solana.Instruction, error) {
+			var freezeAuthority *solana.PublicKey
+			if withFreezeAuthority {
+				freezeAuthority = &vaultPDA
+			}
+			return token.CreateMintInstructions(vaultPDA, ephemeralSigners[0], decimals, vaultPDA, freezeAuthority, rent, tokenProgram), nil
+		},
+	}
+
+	if exportPath != "" {
+		tx, output, err := client.PrepareVaultTransaction(ctx, input)
+		if err != nil {
+			log.Fatalf("Failed to prepare transaction: %v", err)
+		}
+		err = cliutil.ExportTransaction(exportPath, tx, output.TransactionIndex,
+			"Action: create vault transaction",
+			fmt.Sprintf("Multisig: %s", multisigPDA),
+			fmt.Sprintf("Transaction Index: %d", output.TransactionIndex),
+			fmt.Sprintf("Create Mint: %s (%d decimals, program %s)", output.EphemeralSigners[0], decimals, tokenProgram),
+			fmt.Sprintf("Mint Authority: vault %s", output.VaultPDA),
+			fmt.Sprintf("Creator: %s", payer.PublicKey()),
+			fmt.Sprintf("Auto-approve: %t", autoApprove),
+		)
+		if err != nil {
+			log.Fatalf("Failed to export transaction: %v", err)
+		}
+		return
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, time.Duration(timeoutSecs)*time.Second)
+	defer cancel()
+
+	output, err := client.CreateVaultTransaction(ctxWithTimeout, input)
+	if err != nil {
+		log.Fatalf("Failed to create transaction: %v", err)
+	}
+
+	fmt.Println("\n════════════════════════════════════════")
+	fmt.Println("       TRANSACTION CREATED SUCCESSFULLY")
+	fmt.Println("════════════════════════════════════════")
+	fmt.Printf("Transaction Signature: %s\n", output.Signature)
+	fmt.Printf("Transaction Index: %d\n", output.TransactionIndex)
+	fmt.Printf("Transaction PDA: %s\n", output.TransactionPDA)
+	fmt.Printf("Proposal PDA: %s\n", output.ProposalPDA)
+	fmt.Printf("Mint (after execution): %s\n", output.EphemeralSigners[0])
+	fmt.Printf("Decimals: %d\n", decimals)
+	fmt.Printf("Token Program: %s\n", tokenProgram)
+	fmt.Printf("Mint Authority: %s\n", output.VaultPDA)
+	if withFreezeAuthority {
+		fmt.Printf("Freeze Authority: %s\n", output.VaultPDA)
+	}
+
+	if autoApprove && output.Threshold <= 1 {
+		fmt.Println("\nThe proposal was approved by the creator and has reached its threshold. Execute it with:")
+	} else {
+		fmt.Println("\nOnce the proposal is approved, execute it with:")
+	}
+	fmt.Printf("  squads-cli transaction execute --multisig %s --transaction %d --payer /path/to/keypair.json\n",
+		multisigPDA, output.TransactionIndex)
+}
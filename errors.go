@@ -0,0 +1,65 @@
+package squads
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+//go:generate go run ./generated/generrors -idl generated/idl.json -out program_errors.go
+
+// ProgramError is a custom error of the Squads program as defined in the IDL.
+type ProgramError struct {
+	Code    uint32
+	Name    string
+	Message string
+}
+
+func (e *ProgramError) Error() string {
+	return fmt.Sprintf("%s (%s, error %d)", e.Message, e.Name, e.Code)
+}
+
+// ProgramErrorByCode looks up a custom error of the Squads program.
+func ProgramErrorByCode(code uint32) (*ProgramError, bool) {
+	e, ok := programErrors[code]
+	return e, ok
+}
+
+// programFailure ties a decoded program error to the failure it came from,
+// so errors.Is matches both.
+type programFailure struct {
+	program *ProgramError
+	cause   error
+}
+
+func (e *programFailure) Error() string {
+	var confirmErr *sender.ConfirmError
+	if errors.As(e.cause, &confirmErr) {
+		return fmt.Sprintf("transaction %s failed: %v", confirmErr.Result.Signature, e.program)
+	}
+	return e.program.Error()
+}
+
+func (e *programFailure) Unwrap() []error {
+	return []error{e.program, e.cause}
+}
+
+// DecodeError maps a custom error code raised by the Squads program at
+// programID to its ProgramError, so errors.Is(err, ErrNotAMember) works.
+// The code is only mapped when the logs show the Squads program itself
+// raising it: a vault transaction's inner programs fail through the Squads
+// instruction with codes of their own. Other errors are returned as is.
+func DecodeError(err error, programID solana.PublicKey) error {
+	var ixErr *sender.InstructionError
+	if err == nil || !errors.As(err, &ixErr) || ixErr.Custom == nil || !ixErr.FailedProgram.Equals(programID) {
+		return err
+	}
+	program, ok := programErrors[*ixErr.Custom]
+	if !ok {
+		return err
+	}
+	return &programFailure{program: program, cause: err}
+}
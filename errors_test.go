@@ -0,0 +1,54 @@
+package squads
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+func TestDecodeError(t *testing.T) {
+	code := ErrNotAMember.Code
+	failed := &sender.ConfirmError{
+		Result:      &sender.Result{Status: sender.StatusFailed},
+		Instruction: &sender.InstructionError{
+			Program:       multisig.DefaultProgramID,
+			Custom:        &code,
+			FailedProgram: multisig.DefaultProgramID,
+		},
+	}
+	err := DecodeError(fmt.Errorf("failed to send voting transaction: %w", failed), multisig.DefaultProgramID)
+
+	require.True(t, errors.Is(err, ErrNotAMember))
+	require.True(t, errors.Is(err, sender.ErrFailed))
+	require.False(t, errors.Is(err, ErrUnauthorized))
+	require.Contains(t, err.Error(), ErrNotAMember.Message)
+
+	// The same code raised by a program the vault transaction calls is not
+	// a Squads error, even though the Squads instruction failed.
+	other := solana.NewWallet().PublicKey()
+	failed.Instruction.FailedProgram = other
+	err = DecodeError(failed, multisig.DefaultProgramID)
+	require.False(t, errors.Is(err, ErrNotAMember))
+	require.True(t, errors.Is(err, sender.ErrFailed))
+
+	// Without logs the failing program is unknown.
+	failed.Instruction.FailedProgram = solana.PublicKey{}
+	require.False(t, errors.Is(DecodeError(failed, multisig.DefaultProgramID), ErrNotAMember))
+
+	require.NoError(t, DecodeError(nil, multisig.DefaultProgramID))
+}
+
+func TestProgramErrorByCode(t *testing.T) {
+	e, ok := ProgramErrorByCode(6000)
+	require.True(t, ok)
+	require.Equal(t, ErrDuplicateMember, e)
+
+	_, ok = ProgramErrorByCode(42)
+	require.False(t, ok)
+}
@@ -0,0 +1,77 @@
+// Command generrors generates Go error values for the custom errors listed in
+// the Squads program IDL.
+//
+// Usage:
+//
+//	go run ./generated/generrors -idl generated/idl.json -out program_errors.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"go/format"
+	"log"
+	"os"
+	"text/template"
+)
+
+type idlError struct {
+	Code uint32 `json:"code"`
+	Name string `json:"name"`
+	Msg  string `json:"msg"`
+}
+
+var fileTemplate = template.Must(template.New("errors").Parse(`// Code generated by generated/generrors from generated/idl.json. DO NOT EDIT.
+
+package {{.Package}}
+
+// Custom errors of the Squads program. Match them with errors.Is.
+var (
+{{- range .Errors}}
+	// Err{{.Name}}: {{.Msg}}
+	Err{{.Name}} = &ProgramError{Code: {{.Code}}, Name: {{printf "%q" .Name}}, Message: {{printf "%q" .Msg}}}
+{{- end}}
+)
+
+// programErrors indexes the custom errors by code.
+var programErrors = map[uint32]*ProgramError{
+{{- range .Errors}}
+	{{.Code}}: Err{{.Name}},
+{{- end}}
+}
+`))
+
+func main() {
+	idlPath := flag.String("idl", "generated/idl.json", "IDL file")
+	outPath := flag.String("out", "program_errors.go", "output file")
+	pkg := flag.String("package", "squads", "package name of the output file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*idlPath)
+	if err != nil {
+		log.Fatalf("Failed to read IDL: %v", err)
+	}
+	var idl struct {
+		Errors []idlError `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &idl); err != nil {
+		log.Fatalf("Failed to parse IDL: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err = fileTemplate.Execute(&buf, map[string]interface{}{
+		"Package": *pkg,
+		"Errors":  idl.Errors,
+	})
+	if err != nil {
+		log.Fatalf("Failed to render errors: %v", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("Failed to format generated code: %v", err)
+	}
+	if err := os.WriteFile(*outPath, src, 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", *outPath, err)
+	}
+}
@@ -11,7 +11,7 @@ import (
 )
 
 func TestEncodeDecode_ConfigTransactionCreate(t *testing.T) {
-	fu := ag_gofuzz.New().NilChance(0)
+	fu := ag_gofuzz.New().NilChance(0).Funcs(fuzzConfigAction)
 	for i := 0; i < 1; i++ {
 		t.Run("ConfigTransactionCreate"+strconv.Itoa(i), func(t *testing.T) {
 			{
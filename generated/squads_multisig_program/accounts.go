@@ -264,7 +264,7 @@ func (obj ConfigTransaction) MarshalWithEncoder(encoder *ag_binary.Encoder) (err
 		return err
 	}
 	// Serialize `Actions` param:
-	err = encoder.Encode(obj.Actions)
+	err = encodeConfigActions(encoder, obj.Actions)
 	if err != nil {
 		return err
 	}
@@ -306,7 +306,7 @@ func (obj *ConfigTransaction) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (
 		return err
 	}
 	// Deserialize `Actions`:
-	err = decoder.Decode(&obj.Actions)
+	obj.Actions, err = decodeConfigActions(decoder)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,98 @@
+package squads_multisig_program
+
+import (
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+)
+
+// Vectors of complex enums are not handled by the generator: encoding a
+// []ConfigAction directly drops the variant index, and decoding cannot pick
+// the concrete type. ConfigTransaction and ConfigTransactionCreateArgs use
+// these helpers instead.
+
+// configActionIndex returns the Borsh variant index of action.
+func configActionIndex(action ConfigAction) (uint8, error) {
+	switch action.(type) {
+	case *ConfigActionAddMember:
+		return 0, nil
+	case *ConfigActionRemoveMember:
+		return 1, nil
+	case *ConfigActionChangeThreshold:
+		return 2, nil
+	case *ConfigActionSetTimeLock:
+		return 3, nil
+	case *ConfigActionAddSpendingLimit:
+		return 4, nil
+	case *ConfigActionRemoveSpendingLimit:
+		return 5, nil
+	case *ConfigActionSetRentCollector:
+		return 6, nil
+	default:
+		return 0, fmt.Errorf("unknown config action %T", action)
+	}
+}
+
+func encodeConfigActions(encoder *ag_binary.Encoder, actions []ConfigAction) error {
+	err := encoder.WriteUint32(uint32(len(actions)), ag_binary.LE)
+	if err != nil {
+		return err
+	}
+	for _, action := range actions {
+		index, err := configActionIndex(action)
+		if err != nil {
+			return err
+		}
+		err = encoder.WriteUint8(index)
+		if err != nil {
+			return err
+		}
+		err = encoder.Encode(action)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeConfigActions(decoder *ag_binary.Decoder) ([]ConfigAction, error) {
+	count, err := decoder.ReadUint32(ag_binary.LE)
+	if err != nil {
+		return nil, err
+	}
+	if int(count) > decoder.Remaining() {
+		return nil, fmt.Errorf("config action count %d exceeds remaining data", count)
+	}
+	actions := make([]ConfigAction, 0, count)
+	for i := uint32(0); i < count; i++ {
+		index, err := decoder.ReadUint8()
+		if err != nil {
+			return nil, err
+		}
+		var action ConfigAction
+		switch index {
+		case 0:
+			action = new(ConfigActionAddMember)
+		case 1:
+			action = new(ConfigActionRemoveMember)
+		case 2:
+			action = new(ConfigActionChangeThreshold)
+		case 3:
+			action = new(ConfigActionSetTimeLock)
+		case 4:
+			action = new(ConfigActionAddSpendingLimit)
+		case 5:
+			action = new(ConfigActionRemoveSpendingLimit)
+		case 6:
+			action = new(ConfigActionSetRentCollector)
+		default:
+			return nil, fmt.Errorf("unknown enum index: %v", index)
+		}
+		err = decoder.Decode(action)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
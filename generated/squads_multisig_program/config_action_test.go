@@ -0,0 +1,53 @@
+package squads_multisig_program
+
+import (
+	"bytes"
+	"testing"
+
+	ag_gofuzz "github.com/gagliardetto/gofuzz"
+	ag_solanago "github.com/gagliardetto/solana-go"
+	ag_require "github.com/stretchr/testify/require"
+)
+
+// fuzzConfigAction fills a ConfigAction with a random variant; gofuzz cannot
+// pick a concrete type for an interface on its own.
+func fuzzConfigAction(action *ConfigAction, c ag_gofuzz.Continue) {
+	variants := []ConfigAction{
+		new(ConfigActionAddMember),
+		new(ConfigActionRemoveMember),
+		new(ConfigActionChangeThreshold),
+		new(ConfigActionSetTimeLock),
+		new(ConfigActionAddSpendingLimit),
+		new(ConfigActionRemoveSpendingLimit),
+		new(ConfigActionSetRentCollector),
+	}
+	*action = variants[c.Intn(len(variants))]
+	c.Fuzz(*action)
+}
+
+func TestConfigActionsWireFormat(t *testing.T) {
+	args := ConfigTransactionCreateArgs{
+		Actions: []ConfigAction{
+			&ConfigActionChangeThreshold{NewThreshold: 2},
+			&ConfigActionRemoveMember{OldMember: ag_solanago.PublicKey{7}},
+		},
+	}
+	buf := new(bytes.Buffer)
+	ag_require.NoError(t, encodeT(args, buf))
+
+	want := []byte{2, 0, 0, 0, 2, 2, 0, 1, 7}
+	want = append(want, make([]byte, 31)...)
+	want = append(want, 0) // no memo
+	ag_require.Equal(t, want, buf.Bytes())
+
+	got := new(ConfigTransactionCreateArgs)
+	ag_require.NoError(t, decodeT(got, buf.Bytes()))
+	ag_require.Equal(t, &args, got)
+}
+
+func TestConfigActionsUnknownVariant(t *testing.T) {
+	got := new(ConfigTransactionCreateArgs)
+	err := decodeT(got, []byte{1, 0, 0, 0, 9})
+	ag_require.Error(t, err)
+	ag_require.Contains(t, err.Error(), "unknown enum index")
+}
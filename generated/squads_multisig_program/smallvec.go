@@ -101,6 +101,9 @@ func (e *Encoder) Encode(v interface{}) error {
 	case ag_solanago.PublicKey:
 		_, err := e.w.Write(val[:])
 		return err
+	case *ag_solanago.PublicKey:
+		_, err := e.w.Write(val[:])
+		return err
 	default:
 		return fmt.Errorf("unsupported type: %T", v)
 	}
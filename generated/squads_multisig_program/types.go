@@ -103,7 +103,7 @@ type ConfigTransactionCreateArgs struct {
 
 func (obj ConfigTransactionCreateArgs) MarshalWithEncoder(encoder *ag_binary.Encoder) (err error) {
 	// Serialize `Actions` param:
-	err = encoder.Encode(obj.Actions)
+	err = encodeConfigActions(encoder, obj.Actions)
 	if err != nil {
 		return err
 	}
@@ -130,7 +130,7 @@ func (obj ConfigTransactionCreateArgs) MarshalWithEncoder(encoder *ag_binary.Enc
 
 func (obj *ConfigTransactionCreateArgs) UnmarshalWithDecoder(decoder *ag_binary.Decoder) (err error) {
 	// Deserialize `Actions`:
-	err = decoder.Decode(&obj.Actions)
+	obj.Actions, err = decodeConfigActions(decoder)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,165 @@
+// Package rpctest provides a fake Solana JSON-RPC server for package tests.
+package rpctest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+// Account is an account served by a Server.
+type Account struct {
+	Owner solana.PublicKey
+	Data  []byte
+}
+
+// Handler answers one JSON-RPC method. It returns the request's result.
+type Handler func(params []json.RawMessage) interface{}
+
+// Server is a JSON-RPC stand-in serving accounts from memory. It answers
+// getAccountInfo, getMultipleAccounts and getProgramAccounts itself; other
+// methods are answered by handlers registered with Handle, or with a null
+// result. Serve it with httptest.NewServer.
+type Server struct {
+	owner solana.PublicKey
+
+	mu       sync.Mutex
+	accounts map[solana.PublicKey]Account
+	handlers map[string]Handler
+	calls    map[string]int
+	params   map[string][]json.RawMessage
+}
+
+// NewServer creates a server without accounts. Accounts added with Add are
+// owned by owner.
+func NewServer(owner solana.PublicKey) *Server {
+	return &Server{
+		owner:    owner,
+		accounts: map[solana.PublicKey]Account{},
+		handlers: map[string]Handler{},
+		calls:    map[string]int{},
+		params:   map[string][]json.RawMessage{},
+	}
+}
+
+// Add serves the Borsh encoding of account at address.
+func (s *Server) Add(t *testing.T, address solana.PublicKey, account ag_binary.EncoderDecoder) {
+	var buf bytes.Buffer
+	require.NoError(t, account.MarshalWithEncoder(ag_binary.NewBorshEncoder(&buf)))
+	s.SetAccount(address, Account{Owner: s.owner, Data: buf.Bytes()})
+}
+
+// SetAccount serves account at address, replacing any account already there.
+func (s *Server) SetAccount(address solana.PublicKey, account Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts[address] = account
+}
+
+// Handle registers the handler answering method.
+func (s *Server) Handle(method string, handler Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// Calls returns how many requests for method the server has answered.
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// Params returns the parameters of the last request for method.
+func (s *Server) Params(method string) []json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.params[method]
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     interface{}       `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+
+	s.mu.Lock()
+	s.calls[req.Method]++
+	s.params[req.Method] = req.Params
+	handler := s.handlers[req.Method]
+	s.mu.Unlock()
+
+	var result interface{}
+	switch {
+	case handler != nil:
+		result = handler(req.Params)
+	case req.Method == "getAccountInfo":
+		var address solana.PublicKey
+		json.Unmarshal(req.Params[0], &address)
+		result = withContext(s.account(address))
+	case req.Method == "getMultipleAccounts":
+		var addresses []solana.PublicKey
+		json.Unmarshal(req.Params[0], &addresses)
+		values := make([]interface{}, len(addresses))
+		for i, address := range addresses {
+			values[i] = s.account(address)
+		}
+		result = withContext(values)
+	case req.Method == "getProgramAccounts":
+		var program solana.PublicKey
+		json.Unmarshal(req.Params[0], &program)
+		result = s.programAccounts(program)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+}
+
+// account renders the account at address, or nil if there is none.
+func (s *Server) account(address solana.PublicKey) interface{} {
+	s.mu.Lock()
+	account, ok := s.accounts[address]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return encodeAccount(account)
+}
+
+// programAccounts renders every account owned by program. Filters are left
+// to the caller to check through Params.
+func (s *Server) programAccounts(program solana.PublicKey) []interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := []interface{}{}
+	for address, account := range s.accounts {
+		if account.Owner.Equals(program) {
+			result = append(result, map[string]interface{}{
+				"pubkey":  address.String(),
+				"account": encodeAccount(account),
+			})
+		}
+	}
+	return result
+}
+
+func encodeAccount(account Account) map[string]interface{} {
+	return map[string]interface{}{
+		"data":       []string{base64.StdEncoding.EncodeToString(account.Data), "base64"},
+		"owner":      account.Owner.String(),
+		"lamports":   1,
+		"executable": false,
+		"rentEpoch":  0,
+	}
+}
+
+func withContext(value interface{}) map[string]interface{} {
+	return map[string]interface{}{"context": map[string]interface{}{"slot": 1}, "value": value}
+}
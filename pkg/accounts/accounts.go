@@ -0,0 +1,120 @@
+// Package accounts fetches and decodes the accounts owned by the Squads program.
+//
+// Every decoder checks the 8-byte Anchor discriminator, so an address that
+// holds a different account type is reported instead of decoded as garbage.
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+// DiscriminatorSize is the length of the Anchor account discriminator.
+const DiscriminatorSize = 8
+
+var (
+	// ErrNotFound is returned when the account does not exist.
+	ErrNotFound = errors.New("account not found")
+	// ErrUnknownDiscriminator is returned by DecodeAny for data that is not a Squads account.
+	ErrUnknownDiscriminator = errors.New("unknown account discriminator")
+)
+
+// decodable is implemented by every generated account type.
+type decodable interface {
+	UnmarshalWithDecoder(decoder *ag_binary.Decoder) error
+}
+
+// Kind names a Squads account type.
+type Kind string
+
+const (
+	KindBatch                 Kind = "Batch"
+	KindVaultBatchTransaction Kind = "VaultBatchTransaction"
+	KindConfigTransaction     Kind = "ConfigTransaction"
+	KindMultisig              Kind = "Multisig"
+	KindProgramConfig         Kind = "ProgramConfig"
+	KindProposal              Kind = "Proposal"
+	KindSpendingLimit         Kind = "SpendingLimit"
+	KindTransactionBuffer     Kind = "TransactionBuffer"
+	KindVaultTransaction      Kind = "VaultTransaction"
+)
+
+// kinds maps each discriminator defined by the generated bindings to its
+// account type and a constructor for an empty value.
+var kinds = map[[DiscriminatorSize]byte]struct {
+	kind Kind
+	new  func() decodable
+}{
+	squads_multisig_program.BatchDiscriminator:                 {KindBatch, func() decodable { return new(squads_multisig_program.Batch) }},
+	squads_multisig_program.VaultBatchTransactionDiscriminator: {KindVaultBatchTransaction, func() decodable { return new(squads_multisig_program.VaultBatchTransaction) }},
+	squads_multisig_program.ConfigTransactionDiscriminator:     {KindConfigTransaction, func() decodable { return new(squads_multisig_program.ConfigTransaction) }},
+	squads_multisig_program.MultisigDiscriminator:              {KindMultisig, func() decodable { return new(squads_multisig_program.Multisig) }},
+	squads_multisig_program.ProgramConfigDiscriminator:         {KindProgramConfig, func() decodable { return new(squads_multisig_program.ProgramConfig) }},
+	squads_multisig_program.ProposalDiscriminator:              {KindProposal, func() decodable { return new(squads_multisig_program.Proposal) }},
+	squads_multisig_program.SpendingLimitDiscriminator:         {KindSpendingLimit, func() decodable { return new(squads_multisig_program.SpendingLimit) }},
+	squads_multisig_program.TransactionBufferDiscriminator:     {KindTransactionBuffer, func() decodable { return new(squads_multisig_program.TransactionBuffer) }},
+	squads_multisig_program.VaultTransactionDiscriminator:      {KindVaultTransaction, func() decodable { return new(squads_multisig_program.VaultTransaction) }},
+}
+
+// KindOf returns the account type named by the discriminator of data.
+func KindOf(data []byte) (Kind, error) {
+	if len(data) < DiscriminatorSize {
+		return "", fmt.Errorf("account data too short: %d bytes", len(data))
+	}
+	var discriminator [DiscriminatorSize]byte
+	copy(discriminator[:], data)
+	entry, ok := kinds[discriminator]
+	if !ok {
+		return "", fmt.Errorf("%w %x", ErrUnknownDiscriminator, discriminator)
+	}
+	return entry.kind, nil
+}
+
+// DecodeAny decodes the data of any Squads account, choosing the type by its
+// discriminator. The result is a pointer to one of the generated account
+// types, such as *squads_multisig_program.Multisig; use a type switch on it.
+func DecodeAny(data []byte) (interface{}, error) {
+	kind, err := KindOf(data)
+	if err != nil {
+		return nil, err
+	}
+	var discriminator [DiscriminatorSize]byte
+	copy(discriminator[:], data)
+	account := kinds[discriminator].new()
+	if err := account.UnmarshalWithDecoder(ag_binary.NewBorshDecoder(data)); err != nil {
+		return nil, fmt.Errorf("failed to decode %s account: %w", kind, err)
+	}
+	return account, nil
+}
+
+// decode checks the length of data and decodes it into account. The
+// generated decoders reject a mismatched discriminator.
+func decode(data []byte, account decodable) error {
+	if len(data) < DiscriminatorSize {
+		return fmt.Errorf("account data too short: %d bytes", len(data))
+	}
+	return account.UnmarshalWithDecoder(ag_binary.NewBorshDecoder(data))
+}
+
+// fetch loads the account at address and decodes it into account.
+func fetch(ctx context.Context, opts sender.Options, address solana.PublicKey, kind Kind, account decodable) error {
+	accountInfo, err := opts.GetAccountInfo(ctx, address)
+	if errors.Is(err, rpc.ErrNotFound) || (err == nil && (accountInfo == nil || accountInfo.Value == nil)) {
+		return fmt.Errorf("%s account %s: %w", kind, address, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s account %s: %w", kind, address, err)
+	}
+	if err := decode(accountInfo.Value.Data.GetBinary(), account); err != nil {
+		return fmt.Errorf("failed to decode %s account %s: %w", kind, address, err)
+	}
+	return nil
+}
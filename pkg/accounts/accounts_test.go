@@ -0,0 +1,68 @@
+package accounts
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+)
+
+func encode(t *testing.T, account ag_binary.EncoderDecoder) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, account.MarshalWithEncoder(ag_binary.NewBorshEncoder(&buf)))
+	return buf.Bytes()
+}
+
+func TestDecodeAny(t *testing.T) {
+	member := solana.NewWallet().PublicKey()
+	multisigData := encode(t, &squads_multisig_program.Multisig{
+		CreateKey: solana.NewWallet().PublicKey(),
+		Threshold: 2,
+		Members:   []squads_multisig_program.Member{{Key: member}},
+	})
+	batchData := encode(t, &squads_multisig_program.Batch{Index: 7, Size: 3})
+
+	decoded, err := DecodeAny(multisigData)
+	require.NoError(t, err)
+	ms, ok := decoded.(*squads_multisig_program.Multisig)
+	require.True(t, ok, "got %T", decoded)
+	require.EqualValues(t, 2, ms.Threshold)
+	require.Equal(t, member, ms.Members[0].Key)
+
+	decoded, err = DecodeAny(batchData)
+	require.NoError(t, err)
+	batch, ok := decoded.(*squads_multisig_program.Batch)
+	require.True(t, ok, "got %T", decoded)
+	require.EqualValues(t, 7, batch.Index)
+
+	kind, err := KindOf(batchData)
+	require.NoError(t, err)
+	require.Equal(t, KindBatch, kind)
+
+	_, err = DecodeAny(make([]byte, 16))
+	require.True(t, errors.Is(err, ErrUnknownDiscriminator))
+
+	_, err = DecodeAny([]byte{1, 2, 3})
+	require.Error(t, err)
+}
+
+func TestDecodeRejectsOtherAccountType(t *testing.T) {
+	batchData := encode(t, &squads_multisig_program.Batch{Index: 7})
+
+	var proposal squads_multisig_program.Proposal
+	require.Error(t, decode(batchData, &proposal))
+}
+
+func TestEveryDiscriminatorIsRegistered(t *testing.T) {
+	require.Len(t, kinds, 9)
+	for discriminator, entry := range kinds {
+		kind, err := KindOf(discriminator[:])
+		require.NoError(t, err)
+		require.Equal(t, entry.kind, kind)
+	}
+}
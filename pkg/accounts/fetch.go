@@ -0,0 +1,91 @@
+package accounts
+
+import (
+	"context"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+// FetchBatch fetches and decodes a batch account.
+func FetchBatch(ctx context.Context, opts sender.Options, address solana.PublicKey) (*squads_multisig_program.Batch, error) {
+	var account squads_multisig_program.Batch
+	if err := fetch(ctx, opts, address, KindBatch, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// FetchVaultBatchTransaction fetches and decodes a vault batch transaction account.
+func FetchVaultBatchTransaction(ctx context.Context, opts sender.Options, address solana.PublicKey) (*squads_multisig_program.VaultBatchTransaction, error) {
+	var account squads_multisig_program.VaultBatchTransaction
+	if err := fetch(ctx, opts, address, KindVaultBatchTransaction, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// FetchConfigTransaction fetches and decodes a config transaction account.
+func FetchConfigTransaction(ctx context.Context, opts sender.Options, address solana.PublicKey) (*squads_multisig_program.ConfigTransaction, error) {
+	var account squads_multisig_program.ConfigTransaction
+	if err := fetch(ctx, opts, address, KindConfigTransaction, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// FetchMultisig fetches and decodes a multisig account.
+func FetchMultisig(ctx context.Context, opts sender.Options, address solana.PublicKey) (*squads_multisig_program.Multisig, error) {
+	var account squads_multisig_program.Multisig
+	if err := fetch(ctx, opts, address, KindMultisig, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// FetchProgramConfig fetches and decodes a program config account.
+func FetchProgramConfig(ctx context.Context, opts sender.Options, address solana.PublicKey) (*squads_multisig_program.ProgramConfig, error) {
+	var account squads_multisig_program.ProgramConfig
+	if err := fetch(ctx, opts, address, KindProgramConfig, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// FetchProposal fetches and decodes a proposal account.
+func FetchProposal(ctx context.Context, opts sender.Options, address solana.PublicKey) (*squads_multisig_program.Proposal, error) {
+	var account squads_multisig_program.Proposal
+	if err := fetch(ctx, opts, address, KindProposal, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// FetchSpendingLimit fetches and decodes a spending limit account.
+func FetchSpendingLimit(ctx context.Context, opts sender.Options, address solana.PublicKey) (*squads_multisig_program.SpendingLimit, error) {
+	var account squads_multisig_program.SpendingLimit
+	if err := fetch(ctx, opts, address, KindSpendingLimit, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// FetchTransactionBuffer fetches and decodes a transaction buffer account.
+func FetchTransactionBuffer(ctx context.Context, opts sender.Options, address solana.PublicKey) (*squads_multisig_program.TransactionBuffer, error) {
+	var account squads_multisig_program.TransactionBuffer
+	if err := fetch(ctx, opts, address, KindTransactionBuffer, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// FetchVaultTransaction fetches and decodes a vault transaction account.
+func FetchVaultTransaction(ctx context.Context, opts sender.Options, address solana.PublicKey) (*squads_multisig_program.VaultTransaction, error) {
+	var account squads_multisig_program.VaultTransaction
+	if err := fetch(ctx, opts, address, KindVaultTransaction, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
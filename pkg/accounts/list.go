@@ -0,0 +1,151 @@
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+// Offsets of the fixed-position Multisig fields, used for server-side filters.
+const (
+	multisigCreateKeyOffset       = DiscriminatorSize
+	multisigConfigAuthorityOffset = multisigCreateKeyOffset + solana.PublicKeyLength
+)
+
+// MultisigFilter narrows ListMultisigs. Nil fields match any multisig.
+type MultisigFilter struct {
+	Member          *solana.PublicKey // a member with any permissions
+	CreateKey       *solana.PublicKey
+	ConfigAuthority *solana.PublicKey // the zero key matches autonomous multisigs
+}
+
+// KeyedMultisig is a multisig account together with its address.
+type KeyedMultisig struct {
+	Address solana.PublicKey
+	Account *squads_multisig_program.Multisig
+}
+
+// ListMultisigs finds the multisigs of the program at programID that match
+// filter, sorted by address. The create key and config authority are matched
+// by the RPC node; members sit at a variable offset and are matched after
+// decoding, so a member-only search downloads every multisig of the program.
+func ListMultisigs(
+	ctx context.Context,
+	opts sender.Options,
+	programID solana.PublicKey,
+	filter MultisigFilter,
+) ([]KeyedMultisig, error) {
+	var filters []rpc.RPCFilter
+	if filter.CreateKey != nil {
+		filters = append(filters, memcmp(multisigCreateKeyOffset, filter.CreateKey[:]))
+	}
+	if filter.ConfigAuthority != nil {
+		filters = append(filters, memcmp(multisigConfigAuthorityOffset, filter.ConfigAuthority[:]))
+	}
+
+	keyed, err := programAccounts(ctx, opts, programID, squads_multisig_program.MultisigDiscriminator, filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []KeyedMultisig
+	for _, account := range keyed {
+		var ms squads_multisig_program.Multisig
+		if err := decode(account.Account.Data.GetBinary(), &ms); err != nil {
+			return nil, fmt.Errorf("failed to decode multisig account %s: %w", account.Pubkey, err)
+		}
+		if filter.Member != nil && !isMember(&ms, *filter.Member) {
+			continue
+		}
+		out = append(out, KeyedMultisig{Address: account.Pubkey, Account: &ms})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return bytes.Compare(out[i].Address[:], out[j].Address[:]) < 0
+	})
+	return out, nil
+}
+
+// isMember reports whether key is a member of ms.
+func isMember(ms *squads_multisig_program.Multisig, key solana.PublicKey) bool {
+	for _, member := range ms.Members {
+		if member.Key.Equals(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// memcmp builds a filter matching data at offset.
+func memcmp(offset uint64, data []byte) rpc.RPCFilter {
+	return rpc.RPCFilter{Memcmp: &rpc.RPCFilterMemcmp{Offset: offset, Bytes: solana.Base58(data)}}
+}
+
+// programAccounts returns the accounts of the program at programID that
+// carry the given discriminator and match every extra filter.
+func programAccounts(
+	ctx context.Context,
+	opts sender.Options,
+	programID solana.PublicKey,
+	discriminator [DiscriminatorSize]byte,
+	filters ...rpc.RPCFilter,
+) (rpc.GetProgramAccountsResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	out, err := opts.Client.GetProgramAccountsWithOpts(ctx, programID, &rpc.GetProgramAccountsOpts{
+		Commitment: opts.GetCommitment(),
+		Encoding:   solana.EncodingBase64,
+		Filters:    append([]rpc.RPCFilter{memcmp(0, discriminator[:])}, filters...),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program accounts: %w", err)
+	}
+	return out, nil
+}
+
+// spendingLimitMultisigOffset is where SpendingLimit stores its multisig.
+const spendingLimitMultisigOffset = DiscriminatorSize
+
+// KeyedSpendingLimit is a spending limit account together with its address.
+type KeyedSpendingLimit struct {
+	Address solana.PublicKey
+	Account *squads_multisig_program.SpendingLimit
+}
+
+// ListSpendingLimits finds the spending limits of a multisig, sorted by
+// vault index and then by address.
+func ListSpendingLimits(
+	ctx context.Context,
+	opts sender.Options,
+	programID solana.PublicKey,
+	multisigPDA solana.PublicKey,
+) ([]KeyedSpendingLimit, error) {
+	keyed, err := programAccounts(ctx, opts, programID, squads_multisig_program.SpendingLimitDiscriminator,
+		memcmp(spendingLimitMultisigOffset, multisigPDA[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]KeyedSpendingLimit, 0, len(keyed))
+	for _, account := range keyed {
+		var spendingLimit squads_multisig_program.SpendingLimit
+		if err := decode(account.Account.Data.GetBinary(), &spendingLimit); err != nil {
+			return nil, fmt.Errorf("failed to decode spending limit account %s: %w", account.Pubkey, err)
+		}
+		out = append(out, KeyedSpendingLimit{Address: account.Pubkey, Account: &spendingLimit})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Account.VaultIndex != out[j].Account.VaultIndex {
+			return out[i].Account.VaultIndex < out[j].Account.VaultIndex
+		}
+		return bytes.Compare(out[i].Address[:], out[j].Address[:]) < 0
+	})
+	return out, nil
+}
@@ -0,0 +1,81 @@
+package accounts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/internal/rpctest"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+// filters returns the filters of the server's last getProgramAccounts request.
+func filters(t *testing.T, server *rpctest.Server) []interface{} {
+	params := server.Params("getProgramAccounts")
+	require.Len(t, params, 2)
+	var config struct {
+		Filters []interface{} `json:"filters"`
+	}
+	require.NoError(t, json.Unmarshal(params[1], &config))
+	return config.Filters
+}
+
+func TestListMultisigs(t *testing.T) {
+	member := solana.NewWallet().PublicKey()
+	withMember := solana.NewWallet().PublicKey()
+	without := solana.NewWallet().PublicKey()
+
+	programID := solana.NewWallet().PublicKey()
+	server := rpctest.NewServer(programID)
+	server.Add(t, withMember, &squads_multisig_program.Multisig{Threshold: 1, Members: []squads_multisig_program.Member{{Key: member}}})
+	server.Add(t, without, &squads_multisig_program.Multisig{Threshold: 1, Members: []squads_multisig_program.Member{{Key: solana.NewWallet().PublicKey()}}})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	opts := sender.Options{Client: rpc.New(httpServer.URL)}
+
+	found, err := ListMultisigs(context.Background(), opts, programID, MultisigFilter{Member: &member})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, withMember, found[0].Address)
+	require.Len(t, filters(t, server), 1, "members are matched client-side")
+
+	createKey := solana.NewWallet().PublicKey()
+	found, err = ListMultisigs(context.Background(), opts, programID, MultisigFilter{CreateKey: &createKey})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	createKeyFilters := filters(t, server)
+	require.Len(t, createKeyFilters, 2)
+	createKeyFilter := createKeyFilters[1].(map[string]interface{})["memcmp"].(map[string]interface{})
+	require.EqualValues(t, multisigCreateKeyOffset, createKeyFilter["offset"])
+	require.Equal(t, createKey.String(), createKeyFilter["bytes"])
+}
+
+func TestListSpendingLimits(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	first, second := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	programID := solana.NewWallet().PublicKey()
+	server := rpctest.NewServer(programID)
+	server.Add(t, first, &squads_multisig_program.SpendingLimit{Multisig: multisigPDA, VaultIndex: 1, Amount: 10})
+	server.Add(t, second, &squads_multisig_program.SpendingLimit{Multisig: multisigPDA, VaultIndex: 0, Amount: 20})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	found, err := ListSpendingLimits(context.Background(), sender.Options{Client: rpc.New(httpServer.URL)}, programID, multisigPDA)
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	require.Equal(t, second, found[0].Address, "sorted by vault index")
+	require.EqualValues(t, 20, found[0].Account.Amount)
+
+	multisigFilters := filters(t, server)
+	require.Len(t, multisigFilters, 2)
+	multisigFilter := multisigFilters[1].(map[string]interface{})["memcmp"].(map[string]interface{})
+	require.EqualValues(t, spendingLimitMultisigOffset, multisigFilter["offset"])
+	require.Equal(t, multisigPDA.String(), multisigFilter["bytes"])
+}
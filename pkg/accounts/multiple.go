@@ -0,0 +1,42 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+// MaxMultipleAccounts is the most addresses a getMultipleAccounts call accepts.
+const MaxMultipleAccounts = 100
+
+// FetchMultiple fetches the accounts at addresses with as few
+// getMultipleAccounts calls as possible. The result is index-aligned with
+// addresses; accounts that don't exist are nil.
+func FetchMultiple(ctx context.Context, opts sender.Options, addresses []solana.PublicKey) ([]*rpc.Account, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	out := make([]*rpc.Account, 0, len(addresses))
+	for start := 0; start < len(addresses); start += MaxMultipleAccounts {
+		end := start + MaxMultipleAccounts
+		if end > len(addresses) {
+			end = len(addresses)
+		}
+		res, err := opts.Client.GetMultipleAccountsWithOpts(ctx, addresses[start:end], &rpc.GetMultipleAccountsOpts{
+			Commitment: opts.GetCommitment(),
+			Encoding:   solana.EncodingBase64,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get accounts: %w", err)
+		}
+		if len(res.Value) != end-start {
+			return nil, fmt.Errorf("expected %d accounts, got %d", end-start, len(res.Value))
+		}
+		out = append(out, res.Value...)
+	}
+	return out, nil
+}
@@ -0,0 +1,313 @@
+package multisig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// AdminInput defines input parameters for changing a controlled multisig
+// directly through its config authority, without a vote
+type AdminInput struct {
+	// Required inputs
+	Multisig  solana.PublicKey
+	Authority signer.Signer // the multisig's config authority
+
+	// Optional inputs; at least one of Actions and NewConfigAuthority is required
+	Actions []squads_multisig_program.ConfigAction // applied in order
+	// NewConfigAuthority hands the authority over after the actions are
+	// applied; the zero key renounces it, making the multisig autonomous.
+	NewConfigAuthority *solana.PublicKey
+	Memo               string
+	ProgramID          solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
+// AdminOutput defines return values from changing a controlled multisig
+type AdminOutput struct {
+	Signature       solana.Signature
+	Members         []squads_multisig_program.Member // member set after the change
+	Threshold       uint16
+	TimeLock        uint32
+	ConfigAuthority solana.PublicKey   // zero if the authority was renounced
+	SpendingLimits  []solana.PublicKey // spending limit accounts created or closed
+}
+
+// Administer applies config changes to a controlled multisig in one
+// transaction signed by its config authority. The member set and threshold
+// after each change are checked against the program's invariants before
+// sending; see ActionError.
+func Administer(ctx context.Context, input AdminInput) (*AdminOutput, error) {
+	instructions, output, err := buildAdmin(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := input.SendAndConfirm(ctx, instructions, input.Authority)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	output.Signature = result.Signature
+	return output, nil
+}
+
+// PrepareAdminister builds the admin transaction without submitting it.
+// Signatures from offline signers are left empty; see sender.Options.Prepare.
+func PrepareAdminister(ctx context.Context, input AdminInput) (*solana.Transaction, *AdminOutput, error) {
+	instructions, output, err := buildAdmin(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := input.Prepare(ctx, instructions, input.Authority)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, output, nil
+}
+
+// buildAdmin checks the authority, simulates the changes and returns one
+// instruction per change.
+func buildAdmin(ctx context.Context, input AdminInput) ([]solana.Instruction, *AdminOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if input.Authority == nil {
+		return nil, nil, errors.New("config authority signer is required")
+	}
+	if len(input.Actions) == 0 && input.NewConfigAuthority == nil {
+		return nil, nil, errors.New("at least one change is required")
+	}
+
+	authority := input.Authority.PublicKey()
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+	}
+	if multisigAccount.ConfigAuthority.IsZero() {
+		return nil, nil, errors.New("multisig is autonomous; propose a config transaction instead")
+	}
+	if !multisigAccount.ConfigAuthority.Equals(authority) {
+		return nil, nil, fmt.Errorf("%s is not the config authority of this multisig (%s is)",
+			authority, multisigAccount.ConfigAuthority)
+	}
+
+	output, err := applyAdminActions(multisigAccount, input.Actions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var memo *string
+	if input.Memo != "" {
+		memo = &input.Memo
+	}
+	rentPayer := input.Payer(input.Authority).PublicKey()
+
+	var instructions []solana.Instruction
+	for _, action := range input.Actions {
+		var ix solana.Instruction
+		switch action := action.(type) {
+		case *squads_multisig_program.ConfigActionAddMember:
+			ix = squads_multisig_program.NewMultisigAddMemberInstruction(
+				squads_multisig_program.MultisigAddMemberArgs{NewMember: action.NewMember, Memo: memo},
+				input.Multisig, authority, rentPayer, solana.SystemProgramID,
+			).Build()
+		case *squads_multisig_program.ConfigActionRemoveMember:
+			ix = squads_multisig_program.NewMultisigRemoveMemberInstruction(
+				squads_multisig_program.MultisigRemoveMemberArgs{OldMember: action.OldMember, Memo: memo},
+				input.Multisig, authority, rentPayer, solana.SystemProgramID,
+			).Build()
+		case *squads_multisig_program.ConfigActionChangeThreshold:
+			ix = squads_multisig_program.NewMultisigChangeThresholdInstruction(
+				squads_multisig_program.MultisigChangeThresholdArgs{NewThreshold: action.NewThreshold, Memo: memo},
+				input.Multisig, authority, rentPayer, solana.SystemProgramID,
+			).Build()
+		case *squads_multisig_program.ConfigActionSetTimeLock:
+			ix = squads_multisig_program.NewMultisigSetTimeLockInstruction(
+				squads_multisig_program.MultisigSetTimeLockArgs{TimeLock: action.NewTimeLock, Memo: memo},
+				input.Multisig, authority, rentPayer, solana.SystemProgramID,
+			).Build()
+		case *squads_multisig_program.ConfigActionSetRentCollector:
+			ix = squads_multisig_program.NewMultisigSetRentCollectorInstruction(
+				squads_multisig_program.MultisigSetRentCollectorArgs{RentCollector: action.NewRentCollector, Memo: memo},
+				input.Multisig, authority, rentPayer, solana.SystemProgramID,
+			).Build()
+		case *squads_multisig_program.ConfigActionAddSpendingLimit:
+			spendingLimit, _, err := pda.SpendingLimit(input.Multisig, action.CreateKey, input.ProgramID)
+			if err != nil {
+				return nil, nil, err
+			}
+			ix = squads_multisig_program.NewMultisigAddSpendingLimitInstruction(
+				squads_multisig_program.MultisigAddSpendingLimitArgs{
+					CreateKey:    action.CreateKey,
+					VaultIndex:   action.VaultIndex,
+					Mint:         action.Mint,
+					Amount:       action.Amount,
+					Period:       action.Period,
+					Members:      action.Members,
+					Destinations: action.Destinations,
+					Memo:         memo,
+				},
+				input.Multisig, authority, spendingLimit, rentPayer, solana.SystemProgramID,
+			).Build()
+			output.SpendingLimits = append(output.SpendingLimits, spendingLimit)
+		case *squads_multisig_program.ConfigActionRemoveSpendingLimit:
+			ix = squads_multisig_program.NewMultisigRemoveSpendingLimitInstruction(
+				squads_multisig_program.MultisigRemoveSpendingLimitArgs{Memo: memo},
+				input.Multisig, authority, action.SpendingLimit, rentPayer,
+			).Build()
+			output.SpendingLimits = append(output.SpendingLimits, action.SpendingLimit)
+		default:
+			return nil, nil, fmt.Errorf("unsupported config action %T", action)
+		}
+		instructions = append(instructions, ix)
+	}
+
+	output.ConfigAuthority = multisigAccount.ConfigAuthority
+	if input.NewConfigAuthority != nil {
+		output.ConfigAuthority = *input.NewConfigAuthority
+		instructions = append(instructions, squads_multisig_program.NewMultisigSetConfigAuthorityInstruction(
+			squads_multisig_program.MultisigSetConfigAuthorityArgs{ConfigAuthority: *input.NewConfigAuthority, Memo: memo},
+			input.Multisig, authority, rentPayer, solana.SystemProgramID,
+		).Build())
+	}
+
+	instructions, err = BindProgramID(instructions, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Printf("Applying %d admin changes to multisig %s", len(instructions), input.Multisig)
+
+	return instructions, output, nil
+}
+
+// ActionError reports the admin action that failed. Each action is its own
+// instruction and the program checks its invariants after every one, so an
+// action that leaves an invalid multisig fails even if a later one would fix
+// it; reorder the actions instead.
+type ActionError struct {
+	Index  int // position in AdminInput.Actions
+	Action squads_multisig_program.ConfigAction
+	Err    error
+}
+
+func (e *ActionError) Error() string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", e.Action), "*squads_multisig_program.ConfigAction")
+	return fmt.Sprintf("action %d (%s): %v", e.Index+1, name, e.Err)
+}
+
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
+
+// applyAdminActions returns the member set, threshold and time lock the
+// actions would leave behind, rejecting members added twice or removed
+// without being present. The state after every action is checked with
+// ValidateMembers, as the program does after every instruction; the first
+// failure is returned as an *ActionError.
+func applyAdminActions(multisigAccount *squads_multisig_program.Multisig, actions []squads_multisig_program.ConfigAction) (*AdminOutput, error) {
+	output := &AdminOutput{
+		Members:   append([]squads_multisig_program.Member(nil), multisigAccount.Members...),
+		Threshold: multisigAccount.Threshold,
+		TimeLock:  multisigAccount.TimeLock,
+	}
+	for i, action := range actions {
+		switch action := action.(type) {
+		case *squads_multisig_program.ConfigActionAddMember:
+			if memberIndex(output.Members, action.NewMember.Key) >= 0 {
+				return nil, &ActionError{Index: i, Action: action, Err: fmt.Errorf("%s is already a member", action.NewMember.Key)}
+			}
+			output.Members = append(output.Members, action.NewMember)
+		case *squads_multisig_program.ConfigActionRemoveMember:
+			at := memberIndex(output.Members, action.OldMember)
+			if at < 0 {
+				return nil, &ActionError{Index: i, Action: action, Err: fmt.Errorf("%s is not a member", action.OldMember)}
+			}
+			output.Members = append(output.Members[:at], output.Members[at+1:]...)
+		case *squads_multisig_program.ConfigActionChangeThreshold:
+			output.Threshold = action.NewThreshold
+		case *squads_multisig_program.ConfigActionSetTimeLock:
+			output.TimeLock = action.NewTimeLock
+		case *squads_multisig_program.ConfigActionSetRentCollector,
+			*squads_multisig_program.ConfigActionAddSpendingLimit,
+			*squads_multisig_program.ConfigActionRemoveSpendingLimit:
+			// These leave the member set, threshold and time lock unchanged.
+		case nil:
+			return nil, &ActionError{Index: i, Err: errors.New("config action is nil")}
+		default:
+			return nil, &ActionError{Index: i, Action: action, Err: errors.New("unsupported config action")}
+		}
+		if err := ValidateMembers(output.Members, output.Threshold); err != nil {
+			return nil, &ActionError{
+				Index:  i,
+				Action: action,
+				Err:    fmt.Errorf("it would leave an invalid multisig, which the program rejects even if a later action fixes it: %w", err),
+			}
+		}
+	}
+	return output, nil
+}
+
+// ValidateMembers checks a member set and threshold against the invariants
+// the program enforces on every multisig: no duplicate members, only known
+// permission bits, at least one proposer, voter and executor, and a threshold
+// between 1 and the number of voters.
+func ValidateMembers(members []squads_multisig_program.Member, threshold uint16) error {
+	if len(members) == 0 {
+		return errors.New("a multisig needs at least one member")
+	}
+	var proposers, voters, executors int
+	seen := make(map[solana.PublicKey]bool, len(members))
+	for _, member := range members {
+		if seen[member.Key] {
+			return fmt.Errorf("duplicate member %s", member.Key)
+		}
+		seen[member.Key] = true
+		mask := member.Permissions.Mask
+		if mask > PermissionFull {
+			return fmt.Errorf("member %s has unknown permissions %d", member.Key, mask)
+		}
+		if mask&PermissionPropose != 0 {
+			proposers++
+		}
+		if mask&PermissionVote != 0 {
+			voters++
+		}
+		if mask&PermissionExecute != 0 {
+			executors++
+		}
+	}
+	switch {
+	case proposers == 0:
+		return errors.New("no member has propose permission")
+	case voters == 0:
+		return errors.New("no member has vote permission")
+	case executors == 0:
+		return errors.New("no member has execute permission")
+	case threshold == 0:
+		return errors.New("threshold must be at least 1")
+	case int(threshold) > voters:
+		return fmt.Errorf("threshold %d exceeds the %d members with vote permission", threshold, voters)
+	}
+	return nil
+}
+
+func memberIndex(members []squads_multisig_program.Member, key solana.PublicKey) int {
+	for i, member := range members {
+		if member.Key.Equals(key) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,124 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+)
+
+func member(key solana.PublicKey, mask uint8) squads_multisig_program.Member {
+	return squads_multisig_program.Member{Key: key, Permissions: squads_multisig_program.Permissions{Mask: mask}}
+}
+
+func TestValidateMembers(t *testing.T) {
+	alice, bob := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	tests := []struct {
+		name      string
+		members   []squads_multisig_program.Member
+		threshold uint16
+		wantErr   string
+	}{
+		{"valid", []squads_multisig_program.Member{member(alice, PermissionFull), member(bob, PermissionVote)}, 2, ""},
+		{"empty", nil, 1, "at least one member"},
+		{"duplicate", []squads_multisig_program.Member{member(alice, PermissionFull), member(alice, PermissionVote)}, 1, "duplicate"},
+		{"unknown bits", []squads_multisig_program.Member{member(alice, 8)}, 1, "unknown permissions"},
+		{"no executor", []squads_multisig_program.Member{member(alice, PermissionPropose|PermissionVote)}, 1, "execute"},
+		{"zero threshold", []squads_multisig_program.Member{member(alice, PermissionFull)}, 0, "at least 1"},
+		{"threshold above voters", []squads_multisig_program.Member{member(alice, PermissionFull), member(bob, PermissionPropose)}, 2, "exceeds"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMembers(tt.members, tt.threshold)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestApplyAdminActions(t *testing.T) {
+	alice, bob, carol := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	account := &squads_multisig_program.Multisig{
+		Threshold: 1,
+		TimeLock:  60,
+		Members:   []squads_multisig_program.Member{member(alice, PermissionFull), member(bob, PermissionFull)},
+	}
+
+	output, err := applyAdminActions(account, []squads_multisig_program.ConfigAction{
+		&squads_multisig_program.ConfigActionAddMember{NewMember: member(carol, PermissionVote)},
+		&squads_multisig_program.ConfigActionRemoveMember{OldMember: alice},
+		&squads_multisig_program.ConfigActionChangeThreshold{NewThreshold: 2},
+		&squads_multisig_program.ConfigActionSetTimeLock{NewTimeLock: 0},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []squads_multisig_program.Member{member(bob, PermissionFull), member(carol, PermissionVote)}, output.Members)
+	require.EqualValues(t, 2, output.Threshold)
+	require.Zero(t, output.TimeLock)
+	require.Len(t, account.Members, 2, "the fetched account is left untouched")
+	require.True(t, account.Members[0].Key.Equals(alice))
+
+	_, err = applyAdminActions(account, []squads_multisig_program.ConfigAction{
+		&squads_multisig_program.ConfigActionRemoveMember{OldMember: carol},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not a member")
+
+	_, err = applyAdminActions(account, []squads_multisig_program.ConfigAction{
+		&squads_multisig_program.ConfigActionAddMember{NewMember: member(bob, PermissionVote)},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already a member")
+}
+
+func TestApplyAdminActionsChecksEveryStep(t *testing.T) {
+	alice, bob, carol := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	account := &squads_multisig_program.Multisig{
+		Threshold: 3,
+		Members: []squads_multisig_program.Member{
+			member(alice, PermissionFull), member(bob, PermissionFull), member(carol, PermissionFull),
+		},
+	}
+
+	// Removing a member from a 3-of-3 leaves a threshold above the voters,
+	// which the program rejects before the threshold change runs.
+	_, err := applyAdminActions(account, []squads_multisig_program.ConfigAction{
+		&squads_multisig_program.ConfigActionRemoveMember{OldMember: carol},
+		&squads_multisig_program.ConfigActionChangeThreshold{NewThreshold: 2},
+	})
+	var actionErr *ActionError
+	require.ErrorAs(t, err, &actionErr)
+	require.Equal(t, 0, actionErr.Index)
+	require.Contains(t, err.Error(), "action 1 (RemoveMember)")
+	require.Contains(t, err.Error(), "exceeds")
+
+	// The same change in the other order is valid at every step.
+	output, err := applyAdminActions(account, []squads_multisig_program.ConfigAction{
+		&squads_multisig_program.ConfigActionChangeThreshold{NewThreshold: 2},
+		&squads_multisig_program.ConfigActionRemoveMember{OldMember: carol},
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, output.Threshold)
+	require.Len(t, output.Members, 2)
+
+	// Spending limit and rent collector actions leave the members alone.
+	output, err = applyAdminActions(account, []squads_multisig_program.ConfigAction{
+		&squads_multisig_program.ConfigActionSetRentCollector{},
+		&squads_multisig_program.ConfigActionRemoveSpendingLimit{SpendingLimit: solana.NewWallet().PublicKey()},
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Members, output.Members)
+
+	_, err = applyAdminActions(account, []squads_multisig_program.ConfigAction{
+		&squads_multisig_program.ConfigActionSetTimeLock{},
+		nil,
+	})
+	require.ErrorAs(t, err, &actionErr)
+	require.Equal(t, 1, actionErr.Index)
+}
@@ -2,66 +2,71 @@ package multisig
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
 
-	ag_binary "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
-	"github.com/gagliardetto/solana-go/rpc"
-	confirm "github.com/gagliardetto/solana-go/rpc/sendAndConfirmTransaction"
-	"github.com/gagliardetto/solana-go/rpc/ws"
 )
 
 // Instruction is an alias for solana.Instruction to avoid unused variable warning
 type Instruction = solana.Instruction
 
 // FetchProgramConfig fetches and decodes the program config from the blockchain
-func FetchProgramConfig(client *rpc.Client, programConfigPDA solana.PublicKey) (*squads_multisig_program.ProgramConfig, error) {
-	accountInfo, err := client.GetAccountInfo(context.Background(), programConfigPDA)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch program config: %w", err)
-	}
-
-	if accountInfo.Value == nil {
-		return nil, fmt.Errorf("program config account does not exist")
-	}
+func FetchProgramConfig(ctx context.Context, opts sender.Options, programConfigPDA solana.PublicKey) (*squads_multisig_program.ProgramConfig, error) {
+	return accounts.FetchProgramConfig(ctx, opts, programConfigPDA)
+}
 
-	// Skip the 8-byte discriminator
-	data := accountInfo.Value.Data.GetBinary()
-	if len(data) <= 8 {
-		return nil, fmt.Errorf("account data too short")
-	}
+// FetchMultisig fetches and decodes a multisig account
+func FetchMultisig(ctx context.Context, opts sender.Options, multisigPDA solana.PublicKey) (*squads_multisig_program.Multisig, error) {
+	return accounts.FetchMultisig(ctx, opts, multisigPDA)
+}
 
-	var programConfig squads_multisig_program.ProgramConfig
-	decoder := ag_binary.NewBorshDecoder(data)
-	err = programConfig.UnmarshalWithDecoder(decoder)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode program config: %w", err)
-	}
+// FetchProposal fetches and decodes a proposal account
+func FetchProposal(ctx context.Context, opts sender.Options, proposalPDA solana.PublicKey) (*squads_multisig_program.Proposal, error) {
+	return accounts.FetchProposal(ctx, opts, proposalPDA)
+}
 
-	return &programConfig, nil
+// FetchVaultTransaction fetches and decodes a vault transaction account
+func FetchVaultTransaction(ctx context.Context, opts sender.Options, transactionPDA solana.PublicKey) (*squads_multisig_program.VaultTransaction, error) {
+	return accounts.FetchVaultTransaction(ctx, opts, transactionPDA)
 }
 
 // CreateMultisig creates a new multisig on the Solana blockchain
 func CreateMultisig(
-	client *rpc.Client,
-	wsClient *ws.Client,
-	payer solana.PrivateKey,
-	createKey solana.PrivateKey,
+	ctx context.Context,
+	opts sender.Options,
+	payer signer.Signer,
+	createKey signer.Signer,
 	members []squads_multisig_program.Member,
 	threshold uint16,
 	timeLock uint32,
 	programID solana.PublicKey,
 ) (string, solana.PublicKey, error) {
-	ctx := context.Background()
+	if err := opts.Validate(); err != nil {
+		return "", solana.PublicKey{}, err
+	}
+	if payer == nil || createKey == nil {
+		return "", solana.PublicKey{}, errors.New("payer and create key signers are required")
+	}
 
 	// Get PDAs
-	multisigPDA, _ := GetMultisigPDA(createKey.PublicKey(), programID)
-	programConfigPDA, _ := GetProgramConfigPDA(programID)
+	multisigPDA, _, err := pda.Multisig(createKey.PublicKey(), programID)
+	if err != nil {
+		return "", solana.PublicKey{}, err
+	}
+	programConfigPDA, _, err := pda.ProgramConfig(programID)
+	if err != nil {
+		return "", solana.PublicKey{}, err
+	}
 
 	// Fetch the program config to get the treasury
-	programConfig, err := FetchProgramConfig(client, programConfigPDA)
+	programConfig, err := FetchProgramConfig(ctx, opts, programConfigPDA)
 	if err != nil {
 		// If the program config doesn't exist, we need to initialize it
 		// This is a rare case and usually only happens in testing environments
@@ -82,58 +87,24 @@ func CreateMultisig(
 	}
 
 	// Build the instruction using the generated method
-	instruction := squads_multisig_program.NewMultisigCreateV2Instruction(
+	instruction, err := WithProgramID(squads_multisig_program.NewMultisigCreateV2Instruction(
 		args,
 		programConfigPDA,
 		treasury,
 		multisigPDA,
 		createKey.PublicKey(),
-		payer.PublicKey(),
+		opts.Payer(payer).PublicKey(),
 		solana.SystemProgramID,
-	).Build()
-
-	// Get latest blockhash
-	hash, err := client.GetLatestBlockhash(ctx, rpc.CommitmentFinalized)
-	if err != nil {
-		return "", solana.PublicKey{}, fmt.Errorf("failed to get latest blockhash: %w", err)
-	}
-
-	// Create transaction
-	tx, err := solana.NewTransaction(
-		[]solana.Instruction{instruction},
-		hash.Value.Blockhash,
-		solana.TransactionPayer(payer.PublicKey()),
-	)
-	if err != nil {
-		return "", solana.PublicKey{}, fmt.Errorf("failed to create transaction: %w", err)
-	}
-
-	// Sign transaction
-	_, err = tx.Sign(
-		func(key solana.PublicKey) *solana.PrivateKey {
-			if key.Equals(payer.PublicKey()) {
-				return &payer
-			}
-			if key.Equals(createKey.PublicKey()) {
-				return &createKey
-			}
-			return nil
-		},
-	)
+	).Build(), programID)
 	if err != nil {
-		return "", solana.PublicKey{}, fmt.Errorf("failed to sign transaction: %w", err)
+		return "", solana.PublicKey{}, err
 	}
 
 	// Send transaction
-	sig, err := confirm.SendAndConfirmTransaction(
-		ctx,
-		client,
-		wsClient,
-		tx,
-	)
+	result, err := opts.SendAndConfirm(ctx, []solana.Instruction{instruction}, payer, createKey)
 	if err != nil {
 		return "", solana.PublicKey{}, fmt.Errorf("failed to send transaction: %w", err)
 	}
 
-	return sig.String(), multisigPDA, nil
+	return result.Signature.String(), multisigPDA, nil
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/gagliardetto/solana-go/rpc"
 	"github.com/gagliardetto/solana-go/rpc/ws"
 	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
 )
 
 // (the Permission* constants and data-types stay exactly the same)
@@ -43,8 +45,7 @@ func CreateMultisigWithParams(
 		return
 	}
 	if p.ProgramID.IsZero() {
-		p.ProgramID = solana.MustPublicKeyFromBase58(
-			"SQDS4ep65T869zMMBKyuUq6aD6EgTu8psMjkvj52pCf")
+		p.ProgramID = DefaultProgramID
 	}
 
 	// 2. clients
@@ -70,10 +71,17 @@ func CreateMultisigWithParams(
 	// 4. delegate to the original low-level helper
 	createKey = solana.NewWallet().PrivateKey
 	sigStr, multisigPDA, errRaw := CreateMultisig(
-		rpcClient,
-		wsClient,
+		ctx,
+		sender.Options{
+			Client:     rpcClient,
+			WsClient:   wsClient,
+			Commitment: p.Commitment,
+			Nonce:      p.Nonce,
+
+			ComputeBudget: p.ComputeBudget,
+		},
 		p.Payer,
-		createKey,
+		signer.NewLocal(createKey),
 		gen,
 		p.Threshold,
 		p.TimeLock,
@@ -3,11 +3,12 @@ package multisig
 import (
 	"context"
 
-	ag_binary "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
 
 	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
 )
 
 // MultisigInfo is returned to callers (GUI, etc.).
@@ -26,25 +27,26 @@ func FetchMultisigInfo(
 	ctx context.Context,
 	rpcURL string,
 	addr solana.PublicKey,
+	programID ...solana.PublicKey,
 ) (*MultisigInfo, error) {
 
-	client := rpc.New(rpcURL)
+	opts := sender.Options{Client: rpc.New(rpcURL)}
 
-	acc, err := client.GetAccountInfo(ctx, addr)
+	ms, err := FetchMultisig(ctx, opts, addr)
 	if err != nil {
 		return nil, err
 	}
 
-	// Decode just like cmd/multisig-info does.
-	var ms squads_multisig_program.Multisig
-	dec := ag_binary.NewBorshDecoder(acc.Value.Data.GetBinary())
-	if err := ms.UnmarshalWithDecoder(dec); err != nil {
+	// Derive the default vault (index 0) for convenience.
+	var pid solana.PublicKey
+	if len(programID) > 0 {
+		pid = programID[0]
+	}
+	vault0, _, err := pda.Vault(addr, 0, pid)
+	if err != nil {
 		return nil, err
 	}
 
-	// Derive the default vault (index 0) for convenience.
-	vault0, _ := GetVaultPDA(addr, 0)
-
 	info := &MultisigInfo{
 		Address:               addr,
 		Threshold:             ms.Threshold,
@@ -0,0 +1,46 @@
+package multisig
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+)
+
+// DefaultProgramID is the Squads v4 program deployed on mainnet-beta and devnet.
+var DefaultProgramID = pda.DefaultProgramID
+
+// WithProgramID rebinds an instruction built by the generated bindings to the
+// given Squads deployment. The generated builders always target the package
+// level squads_multisig_program.ProgramID, which is shared global state, so
+// custom deployments are handled here instead of calling SetProgramID.
+// Instructions for any other program are returned unchanged.
+func WithProgramID(ix solana.Instruction, programID solana.PublicKey) (solana.Instruction, error) {
+	if programID.IsZero() ||
+		programID.Equals(ix.ProgramID()) ||
+		!ix.ProgramID().Equals(squads_multisig_program.ProgramID) {
+		return ix, nil
+	}
+
+	data, err := ix.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode instruction data: %w", err)
+	}
+
+	return solana.NewInstruction(programID, ix.Accounts(), data), nil
+}
+
+// BindProgramID applies WithProgramID to every instruction in the slice.
+func BindProgramID(instructions []solana.Instruction, programID solana.PublicKey) ([]solana.Instruction, error) {
+	bound := make([]solana.Instruction, len(instructions))
+	for i, ix := range instructions {
+		var err error
+		bound[i], err = WithProgramID(ix, programID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bound, nil
+}
@@ -1,6 +1,12 @@
 package multisig
 
-import "github.com/gagliardetto/solana-go"
+import (
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
 
 // -------------------------------------------------------------------
 // Permission bits – one canonical definition for the whole package.
@@ -21,11 +27,15 @@ type Member struct {
 }
 
 type CreateParams struct {
-	RPCURL    string
-	WSURL     string
-	Payer     solana.PrivateKey
-	Members   []Member
-	Threshold uint16
-	TimeLock  uint32
-	ProgramID solana.PublicKey
+	RPCURL     string
+	WSURL      string
+	Payer      signer.Signer
+	Members    []Member
+	Threshold  uint16
+	TimeLock   uint32
+	ProgramID  solana.PublicKey
+	Commitment rpc.CommitmentType
+
+	Nonce         *sender.Nonce         // optional; durable nonce instead of a recent blockhash
+	ComputeBudget *sender.ComputeBudget // optional; priority fee and compute unit limit
 }
@@ -0,0 +1,207 @@
+package offline
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+)
+
+// MaxIndexSearch bounds the transaction indexes tried when recovering the
+// index of a Squads instruction from its proposal or transaction account.
+const MaxIndexSearch = 10000
+
+// Instruction is one instruction of an exported transaction, decoded from the
+// transaction message itself. Unlike the summary it cannot be made to say
+// something other than what will be signed.
+type Instruction struct {
+	ProgramID solana.PublicKey
+	Accounts  []AccountRef
+	Data      []byte
+
+	// Set for instructions of the Squads program.
+	Name             string // e.g. "ProposalApprove"; empty for other programs
+	Multisig         solana.PublicKey
+	TransactionIndex uint64 // 0 if the instruction names none or it was not found
+	IndexNotFound    bool   // the instruction acts on a proposal or transaction whose index was not found
+}
+
+// AccountRef is an account used by an instruction. Accounts loaded from an
+// address lookup table cannot be resolved without the network, so they are
+// identified by the table and the entry instead of an address.
+type AccountRef struct {
+	PublicKey solana.PublicKey // zero for lookup table entries
+	Table     solana.PublicKey // set for lookup table entries
+	Entry     uint8
+	Signer    bool
+	Writable  bool
+}
+
+// Lookup reports whether the account is loaded from an address lookup table.
+func (a AccountRef) Lookup() bool {
+	return !a.Table.IsZero()
+}
+
+func (a AccountRef) String() string {
+	name := a.PublicKey.String()
+	if a.Lookup() {
+		name = fmt.Sprintf("lookup table %s entry %d", a.Table, a.Entry)
+	}
+	var flags string
+	switch {
+	case a.Signer && a.Writable:
+		flags = "signer, writable"
+	case a.Signer:
+		flags = "signer"
+	case a.Writable:
+		flags = "writable"
+	default:
+		flags = "readonly"
+	}
+	return fmt.Sprintf("%s (%s)", name, flags)
+}
+
+// Instructions decodes the instructions of the transaction. Instructions of
+// the Squads program at programID (zero for the default) are decoded further
+// into their name, multisig and transaction index. The index is the
+// envelope's TransactionIndex if it derives the proposal or transaction the
+// instruction acts on, or else found by trying each index up to
+// MaxIndexSearch.
+func (e *Envelope) Instructions(programID solana.PublicKey) ([]Instruction, error) {
+	programID = pda.ProgramIDOrDefault(programID)
+	message := e.Transaction.Message
+	refs, err := accountRefs(message)
+	if err != nil {
+		return nil, err
+	}
+
+	instructions := make([]Instruction, len(message.Instructions))
+	for i, compiled := range message.Instructions {
+		if int(compiled.ProgramIDIndex) >= len(refs) || refs[compiled.ProgramIDIndex].Lookup() {
+			return nil, fmt.Errorf("instruction %d: invalid program index %d", i+1, compiled.ProgramIDIndex)
+		}
+		ix := Instruction{
+			ProgramID: refs[compiled.ProgramIDIndex].PublicKey,
+			Data:      compiled.Data,
+		}
+		for _, index := range compiled.Accounts {
+			if int(index) >= len(refs) {
+				return nil, fmt.Errorf("instruction %d: invalid account index %d", i+1, index)
+			}
+			ix.Accounts = append(ix.Accounts, refs[index])
+		}
+		if ix.ProgramID.Equals(programID) {
+			if err := describeSquads(&ix, programID, e.TransactionIndex); err != nil {
+				return nil, fmt.Errorf("instruction %d: %w", i+1, err)
+			}
+		}
+		instructions[i] = ix
+	}
+	return instructions, nil
+}
+
+// accountRefs lists the accounts of message in index order: the static keys,
+// then the writable and then the readonly lookup table entries.
+func accountRefs(message solana.Message) ([]AccountRef, error) {
+	header := message.Header
+	static := len(message.AccountKeys)
+	signers := int(header.NumRequiredSignatures)
+	if signers > static || int(header.NumReadonlySignedAccounts) > signers ||
+		int(header.NumReadonlyUnsignedAccounts) > static-signers {
+		return nil, fmt.Errorf("invalid message header")
+	}
+
+	refs := make([]AccountRef, 0, static+message.NumLookups())
+	for i, key := range message.AccountKeys {
+		ref := AccountRef{PublicKey: key, Signer: i < signers}
+		if ref.Signer {
+			ref.Writable = i < signers-int(header.NumReadonlySignedAccounts)
+		} else {
+			ref.Writable = i < static-int(header.NumReadonlyUnsignedAccounts)
+		}
+		refs = append(refs, ref)
+	}
+	for _, lookup := range message.AddressTableLookups {
+		for _, entry := range lookup.WritableIndexes {
+			refs = append(refs, AccountRef{Table: lookup.AccountKey, Entry: entry, Writable: true})
+		}
+	}
+	for _, lookup := range message.AddressTableLookups {
+		for _, entry := range lookup.ReadonlyIndexes {
+			refs = append(refs, AccountRef{Table: lookup.AccountKey, Entry: entry})
+		}
+	}
+	return refs, nil
+}
+
+// describeSquads fills in the name, multisig and transaction index of a
+// Squads instruction. Only ProposalCreate carries the index in its data;
+// otherwise it is recovered by deriving the proposal or transaction PDA from
+// hint, and failing that from each index up to MaxIndexSearch.
+func describeSquads(ix *Instruction, programID solana.PublicKey, hint uint64) error {
+	metas := make([]*solana.AccountMeta, len(ix.Accounts))
+	for i, ref := range ix.Accounts {
+		metas[i] = &solana.AccountMeta{PublicKey: ref.PublicKey, IsSigner: ref.Signer, IsWritable: ref.Writable}
+	}
+	decoded, err := squads_multisig_program.DecodeInstruction(metas, ix.Data)
+	if err != nil {
+		return fmt.Errorf("unrecognized Squads instruction: %w", err)
+	}
+	ix.Name = squads_multisig_program.InstructionIDToName(decoded.TypeID)
+
+	if named, ok := decoded.Impl.(interface{ GetMultisigAccount() *solana.AccountMeta }); ok {
+		if meta := named.GetMultisigAccount(); meta != nil {
+			ix.Multisig = meta.PublicKey
+		}
+	}
+	if create, ok := decoded.Impl.(*squads_multisig_program.ProposalCreate); ok && create.Args != nil {
+		ix.TransactionIndex = create.Args.TransactionIndex
+		return nil
+	}
+	if ix.Multisig.IsZero() {
+		return nil
+	}
+
+	// The batch of BatchAddTransaction and BatchExecuteTransaction is its
+	// transaction account; theirs is the inner batch transaction.
+	var derive func(solana.PublicKey, uint64, solana.PublicKey) (solana.PublicKey, uint8, error)
+	var target *solana.AccountMeta
+	if named, ok := decoded.Impl.(interface{ GetProposalAccount() *solana.AccountMeta }); ok && named.GetProposalAccount() != nil {
+		derive, target = pda.Proposal, named.GetProposalAccount()
+	} else if named, ok := decoded.Impl.(interface{ GetBatchAccount() *solana.AccountMeta }); ok && named.GetBatchAccount() != nil {
+		derive, target = pda.Transaction, named.GetBatchAccount()
+	} else if named, ok := decoded.Impl.(interface{ GetTransactionAccount() *solana.AccountMeta }); ok && named.GetTransactionAccount() != nil {
+		derive, target = pda.Transaction, named.GetTransactionAccount()
+	}
+	if target == nil || target.PublicKey.IsZero() {
+		return nil
+	}
+	matches := func(index uint64) (bool, error) {
+		address, _, err := derive(ix.Multisig, index, programID)
+		return address.Equals(target.PublicKey), err
+	}
+	if hint != 0 {
+		ok, err := matches(hint)
+		if err != nil {
+			return err
+		}
+		if ok {
+			ix.TransactionIndex = hint
+			return nil
+		}
+	}
+	for index := uint64(1); index <= MaxIndexSearch; index++ {
+		ok, err := matches(index)
+		if err != nil {
+			return err
+		}
+		if ok {
+			ix.TransactionIndex = index
+			return nil
+		}
+	}
+	ix.IndexNotFound = true
+	return nil
+}
@@ -0,0 +1,148 @@
+// Package offline carries unsigned transactions between an online machine that
+// builds them, an air-gapped machine that signs them and an online machine
+// that broadcasts them.
+//
+// Transactions travel as a small JSON file holding the base64 wire encoding
+// next to a human-readable summary. The summary is not verified; the signer
+// reviews what they are about to approve with Envelope.Instructions, which
+// decodes the transaction itself without network access.
+package offline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// Envelope is an exported transaction together with its summary.
+type Envelope struct {
+	Summary     []string // human-readable description, one line per entry
+	Transaction *solana.Transaction
+
+	// TransactionIndex is the index of the proposal the transaction acts on,
+	// or 0. Like the summary it is not trusted: Instructions only reports it
+	// after deriving the proposal or transaction address from it.
+	TransactionIndex uint64
+}
+
+// SignerStatus reports whether a required signer has signed.
+type SignerStatus struct {
+	PublicKey solana.PublicKey `json:"publicKey"`
+	Signed    bool             `json:"signed"`
+}
+
+// file is the on-disk layout. Blockhash and Signers are derived from the
+// transaction on every write and ignored on read.
+type file struct {
+	Summary          []string       `json:"summary"`
+	TransactionIndex uint64         `json:"transactionIndex,omitempty"`
+	Blockhash        string         `json:"recentBlockhash"`
+	Signers          []SignerStatus `json:"signers"`
+	Transaction      string         `json:"transaction"`
+}
+
+// New wraps tx for export.
+func New(tx *solana.Transaction, summary ...string) *Envelope {
+	return &Envelope{Summary: summary, Transaction: tx}
+}
+
+// Signers lists the required signers in message order.
+func (e *Envelope) Signers() []SignerStatus {
+	required := int(e.Transaction.Message.Header.NumRequiredSignatures)
+	statuses := make([]SignerStatus, required)
+	for i, key := range e.Transaction.Message.AccountKeys[:required] {
+		statuses[i] = SignerStatus{
+			PublicKey: key,
+			Signed:    i < len(e.Transaction.Signatures) && !e.Transaction.Signatures[i].IsZero(),
+		}
+	}
+	return statuses
+}
+
+// Complete reports whether every required signature is present.
+func (e *Envelope) Complete() bool {
+	return len(signer.Missing(e.Transaction)) == 0
+}
+
+// Sign adds the signature of s. It fails if s is not a required signer, so a
+// wrong keypair is caught on the offline machine rather than at submission.
+func (e *Envelope) Sign(ctx context.Context, s signer.Signer) error {
+	required := false
+	for _, status := range e.Signers() {
+		if status.PublicKey.Equals(s.PublicKey()) {
+			required = true
+			break
+		}
+	}
+	if !required {
+		return fmt.Errorf("%s is not a required signer of this transaction", s.PublicKey())
+	}
+	return signer.SignPartial(ctx, e.Transaction, s)
+}
+
+// Write encodes the envelope as indented JSON.
+func (e *Envelope) Write(w io.Writer) error {
+	encoded, err := e.Transaction.ToBase64()
+	if err != nil {
+		return fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(file{
+		Summary:          e.Summary,
+		TransactionIndex: e.TransactionIndex,
+		Blockhash:        e.Transaction.Message.RecentBlockhash.String(),
+		Signers:          e.Signers(),
+		Transaction:      encoded,
+	})
+}
+
+// WriteFile writes the envelope to path.
+func (e *Envelope) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := e.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read decodes an envelope written by Write.
+func Read(r io.Reader) (*Envelope, error) {
+	var f file
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("failed to decode offline transaction: %w", err)
+	}
+	if f.Transaction == "" {
+		return nil, errors.New("offline transaction file has no transaction")
+	}
+	tx, err := solana.TransactionFromBase64(f.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction: %w", err)
+	}
+	if len(tx.Signatures) != int(tx.Message.Header.NumRequiredSignatures) {
+		return nil, fmt.Errorf("transaction has %d signature slots, expected %d",
+			len(tx.Signatures), tx.Message.Header.NumRequiredSignatures)
+	}
+	return &Envelope{Summary: f.Summary, Transaction: tx, TransactionIndex: f.TransactionIndex}, nil
+}
+
+// ReadFile reads an envelope from path.
+func ReadFile(path string) (*Envelope, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
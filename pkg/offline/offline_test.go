@@ -0,0 +1,136 @@
+package offline
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+func TestExportSignRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	hot := signer.NewLocal(solana.NewWallet().PrivateKey)
+	coldKey := solana.NewWallet().PrivateKey
+
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{
+			system.NewTransferInstruction(1, coldKey.PublicKey(), hot.PublicKey()).Build(),
+		},
+		solana.Hash{7},
+		solana.TransactionPayer(hot.PublicKey()),
+	)
+	require.NoError(t, err)
+
+	// The online machine signs what it can and exports the rest.
+	require.NoError(t, signer.SignPartial(ctx, tx, hot, signer.NewOffline(coldKey.PublicKey())))
+	var buf bytes.Buffer
+	require.NoError(t, New(tx, "Action: transfer").Write(&buf))
+
+	// The offline machine reads, signs and writes it back.
+	env, err := Read(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Action: transfer"}, env.Summary)
+	require.False(t, env.Complete())
+	require.Equal(t, []SignerStatus{
+		{PublicKey: hot.PublicKey(), Signed: true},
+		{PublicKey: coldKey.PublicKey(), Signed: false},
+	}, env.Signers())
+
+	require.Error(t, env.Sign(ctx, signer.NewLocal(solana.NewWallet().PrivateKey)))
+	require.NoError(t, env.Sign(ctx, signer.NewLocal(coldKey)))
+
+	buf.Reset()
+	require.NoError(t, env.Write(&buf))
+	env, err = Read(&buf)
+	require.NoError(t, err)
+	require.True(t, env.Complete())
+	require.NoError(t, env.Transaction.VerifySignatures())
+}
+
+func TestInstructionsDecodesMessage(t *testing.T) {
+	member := solana.NewWallet().PublicKey()
+	multisigPDA := solana.NewWallet().PublicKey()
+	proposalPDA, _, err := pda.Proposal(multisigPDA, 42, solana.PublicKey{})
+	require.NoError(t, err)
+	recipient, table := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	approve := squads_multisig_program.NewProposalApproveInstruction(
+		squads_multisig_program.ProposalVoteArgs{},
+		multisigPDA,
+		member,
+		proposalPDA,
+	).Build()
+	transfer := system.NewTransferInstruction(1, member, recipient).Build()
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{approve, transfer},
+		solana.Hash{7},
+		solana.TransactionPayer(member),
+		solana.TransactionAddressTables(map[solana.PublicKey]solana.PublicKeySlice{table: {recipient}}),
+	)
+	require.NoError(t, err)
+
+	instructions, err := New(tx, "Action: something harmless").Instructions(solana.PublicKey{})
+	require.NoError(t, err)
+	require.Len(t, instructions, 2)
+
+	require.Equal(t, "ProposalApprove", instructions[0].Name)
+	require.Equal(t, multisigPDA, instructions[0].Multisig)
+	require.EqualValues(t, 42, instructions[0].TransactionIndex)
+	require.Equal(t, []AccountRef{
+		{PublicKey: multisigPDA},
+		{PublicKey: member, Signer: true, Writable: true},
+		{PublicKey: proposalPDA, Writable: true},
+	}, instructions[0].Accounts)
+
+	require.Empty(t, instructions[1].Name)
+	require.Equal(t, solana.SystemProgramID, instructions[1].ProgramID)
+	require.Equal(t, []AccountRef{
+		{PublicKey: member, Signer: true, Writable: true},
+		{Table: table, Entry: 0, Writable: true},
+	}, instructions[1].Accounts)
+	require.Equal(t, "lookup table "+table.String()+" entry 0 (writable)", instructions[1].Accounts[1].String())
+}
+
+func TestInstructionsChecksTransactionIndex(t *testing.T) {
+	member := solana.NewWallet().PublicKey()
+	multisigPDA := solana.NewWallet().PublicKey()
+	const index = MaxIndexSearch + 5
+	proposalPDA, _, err := pda.Proposal(multisigPDA, index, solana.PublicKey{})
+	require.NoError(t, err)
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{squads_multisig_program.NewProposalApproveInstruction(
+			squads_multisig_program.ProposalVoteArgs{}, multisigPDA, member, proposalPDA,
+		).Build()},
+		solana.Hash{7},
+		solana.TransactionPayer(member),
+	)
+	require.NoError(t, err)
+	require.NoError(t, signer.SignPartial(context.Background(), tx, signer.NewOffline(member)))
+
+	// Beyond the search, only the index from the file finds it, and it
+	// survives a round trip through the file.
+	env := New(tx)
+	env.TransactionIndex = index
+	var buf bytes.Buffer
+	require.NoError(t, env.Write(&buf))
+	env, err = Read(&buf)
+	require.NoError(t, err)
+	instructions, err := env.Instructions(solana.PublicKey{})
+	require.NoError(t, err)
+	require.EqualValues(t, index, instructions[0].TransactionIndex)
+	require.False(t, instructions[0].IndexNotFound)
+
+	// A wrong index in the file is not believed.
+	env.TransactionIndex = index + 1
+	instructions, err = env.Instructions(solana.PublicKey{})
+	require.NoError(t, err)
+	require.Zero(t, instructions[0].TransactionIndex)
+	require.True(t, instructions[0].IndexNotFound)
+}
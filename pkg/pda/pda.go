@@ -0,0 +1,99 @@
+// Package pda derives every program-derived address used by the Squads v4
+// program. Each function takes the program ID last; the zero key selects
+// DefaultProgramID.
+package pda
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// DefaultProgramID is the Squads v4 program deployed on mainnet-beta and devnet.
+var DefaultProgramID = solana.MustPublicKeyFromBase58("SQDS4ep65T869zMMBKyuUq6aD6EgTu8psMjkvj52pCf")
+
+var (
+	seedPrefix            = []byte("multisig")
+	seedProgramConfig     = []byte("program_config")
+	seedMultisig          = []byte("multisig")
+	seedVault             = []byte("vault")
+	seedTransaction       = []byte("transaction")
+	seedProposal          = []byte("proposal")
+	seedBatchTransaction  = []byte("batch_transaction")
+	seedEphemeralSigner   = []byte("ephemeral_signer")
+	seedSpendingLimit     = []byte("spending_limit")
+	seedTransactionBuffer = []byte("transaction_buffer")
+)
+
+// ProgramConfig derives the global program config address.
+func ProgramConfig(programID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return find("program config", programID, seedPrefix, seedProgramConfig)
+}
+
+// Multisig derives the multisig address seeded by createKey.
+func Multisig(createKey, programID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return find("multisig", programID, seedPrefix, seedMultisig, createKey[:])
+}
+
+// Vault derives the vault at vaultIndex of a multisig.
+func Vault(multisigPDA solana.PublicKey, vaultIndex uint8, programID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return find("vault", programID, seedPrefix, multisigPDA[:], seedVault, []byte{vaultIndex})
+}
+
+// Transaction derives the vault, config or batch transaction at
+// transactionIndex of a multisig; all three share this address.
+func Transaction(multisigPDA solana.PublicKey, transactionIndex uint64, programID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return find("transaction", programID, seedPrefix, multisigPDA[:], seedTransaction, u64(transactionIndex))
+}
+
+// Proposal derives the proposal for the transaction at transactionIndex.
+func Proposal(multisigPDA solana.PublicKey, transactionIndex uint64, programID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return find("proposal", programID, seedPrefix, multisigPDA[:], seedTransaction, u64(transactionIndex), seedProposal)
+}
+
+// BatchTransaction derives the transaction at innerIndex (starting at 1) of
+// the batch at batchIndex.
+func BatchTransaction(multisigPDA solana.PublicKey, batchIndex uint64, innerIndex uint32, programID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	inner := make([]byte, 4)
+	binary.LittleEndian.PutUint32(inner, innerIndex)
+	return find("batch transaction", programID, seedPrefix, multisigPDA[:], seedTransaction, u64(batchIndex), seedBatchTransaction, inner)
+}
+
+// EphemeralSigner derives the ephemeral signer at signerIndex of a vault or
+// batch transaction.
+func EphemeralSigner(transactionPDA solana.PublicKey, signerIndex uint8, programID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return find("ephemeral signer", programID, seedPrefix, transactionPDA[:], seedEphemeralSigner, []byte{signerIndex})
+}
+
+// SpendingLimit derives the spending limit seeded by createKey.
+func SpendingLimit(multisigPDA, createKey, programID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return find("spending limit", programID, seedPrefix, multisigPDA[:], seedSpendingLimit, createKey[:])
+}
+
+// TransactionBuffer derives the buffer at bufferIndex owned by creator.
+func TransactionBuffer(multisigPDA, creator solana.PublicKey, bufferIndex uint8, programID solana.PublicKey) (solana.PublicKey, uint8, error) {
+	return find("transaction buffer", programID, seedPrefix, multisigPDA[:], seedTransactionBuffer, creator[:], []byte{bufferIndex})
+}
+
+// ProgramIDOrDefault returns programID, or DefaultProgramID if it is zero.
+func ProgramIDOrDefault(programID solana.PublicKey) solana.PublicKey {
+	if programID.IsZero() {
+		return DefaultProgramID
+	}
+	return programID
+}
+
+func find(kind string, programID solana.PublicKey, seeds ...[]byte) (solana.PublicKey, uint8, error) {
+	address, bump, err := solana.FindProgramAddress(seeds, ProgramIDOrDefault(programID))
+	if err != nil {
+		return solana.PublicKey{}, 0, fmt.Errorf("failed to derive %s PDA: %w", kind, err)
+	}
+	return address, bump, nil
+}
+
+func u64(value uint64) []byte {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, value)
+	return b
+}
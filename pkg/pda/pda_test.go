@@ -0,0 +1,89 @@
+package pda
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramConfig(t *testing.T) {
+	// The program config of the mainnet deployment.
+	address, _, err := ProgramConfig(solana.PublicKey{})
+	require.NoError(t, err)
+	require.Equal(t, "BSTq9w3kZwNwpBXJEvTZz2G9ZTNyKBvoSeXMvwb4cNZr", address.String())
+
+	explicit, _, err := ProgramConfig(DefaultProgramID)
+	require.NoError(t, err)
+	require.Equal(t, address, explicit)
+
+	custom, _, err := ProgramConfig(solana.NewWallet().PublicKey())
+	require.NoError(t, err)
+	require.NotEqual(t, address, custom)
+}
+
+// TestKnownAddresses pins every derivation to a fixed address. The expected
+// addresses were derived outside this package, by hashing the seeds as the
+// program declares them, and that derivation reproduces the mainnet program
+// config above. Index 258 spans two bytes, so a seed of the wrong width or
+// byte order changes the address.
+func TestKnownAddresses(t *testing.T) {
+	createKey := solana.MustPublicKeyFromBase58("6tBou5MHL5aWpDy6cgf3wiwGGK2mR8qs68ujtpaoWrf2")
+	member := solana.MustPublicKeyFromBase58("Hy5oibb1cYdmjyPJ2fiypDtKYvp1uZTuPkmFzVy7TL8c")
+	multisigPDA := solana.MustPublicKeyFromBase58("HWyN1dHNhQZe6NBFR17uAVhHDTq8zHWs7jRKNpBSzGdx")
+	txPDA := solana.MustPublicKeyFromBase58("FsBz2HrYV8ULUSgpWDF11PDwPzueN7YRDzBJx5yLgPaW")
+	programID := solana.PublicKey{}
+
+	tests := []struct {
+		name   string
+		derive func() (solana.PublicKey, uint8, error)
+		want   string
+	}{
+		{"multisig", func() (solana.PublicKey, uint8, error) { return Multisig(createKey, programID) }, multisigPDA.String()},
+		{"vault 0", func() (solana.PublicKey, uint8, error) { return Vault(multisigPDA, 0, programID) }, "4cAT8kfXJWeMUMUYesn1eXAEyKqNkrzUKHGLJGjGZnYH"},
+		{"vault 1", func() (solana.PublicKey, uint8, error) { return Vault(multisigPDA, 1, programID) }, "6K4G5DftPGdT7TUVgpPfyV4JKq6eSKz6qbLSPq8vg6vy"},
+		{"transaction 1", func() (solana.PublicKey, uint8, error) { return Transaction(multisigPDA, 1, programID) }, txPDA.String()},
+		{"transaction 258", func() (solana.PublicKey, uint8, error) { return Transaction(multisigPDA, 258, programID) }, "489jgFDx64ZRgDyMkBvHQUyPabR2bxJYHbb3hJW8SZk2"},
+		{"proposal 1", func() (solana.PublicKey, uint8, error) { return Proposal(multisigPDA, 1, programID) }, "fH6zJLp4f2DMLb6b3TnGAN17akjAtsqXMA3ogYBKEmA"},
+		{"batch 2 transaction 3", func() (solana.PublicKey, uint8, error) { return BatchTransaction(multisigPDA, 2, 3, programID) }, "C66sjxMo49mB7NYWMno1pe7kGdo9yywEyaPnAfofdBjY"},
+		{"ephemeral signer 1", func() (solana.PublicKey, uint8, error) { return EphemeralSigner(txPDA, 1, programID) }, "2p3VqMMg6BJeyTw54cQBYMp3LsVjxTW3tQqUyrhDv8tw"},
+		{"spending limit", func() (solana.PublicKey, uint8, error) { return SpendingLimit(multisigPDA, member, programID) }, "AjHtAisJcx8JyB9zAXEAMeBsLMZNZrtM5g1V1orpiymN"},
+		{"transaction buffer 2", func() (solana.PublicKey, uint8, error) { return TransactionBuffer(multisigPDA, member, 2, programID) }, "6R9UeUEznqGAgvSznRoDeA6gZriycJscfUVQprcvtLbs"},
+	}
+	for _, tt := range tests {
+		address, _, err := tt.derive()
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.want, address.String(), tt.name)
+	}
+}
+
+func TestDerivationsAreDistinct(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	member := solana.NewWallet().PublicKey()
+	programID := solana.PublicKey{}
+
+	txPDA, _, err := Transaction(multisigPDA, 1, programID)
+	require.NoError(t, err)
+
+	derive := map[string]func() (solana.PublicKey, uint8, error){
+		"multisig":           func() (solana.PublicKey, uint8, error) { return Multisig(member, programID) },
+		"vault":              func() (solana.PublicKey, uint8, error) { return Vault(multisigPDA, 0, programID) },
+		"vault 1":            func() (solana.PublicKey, uint8, error) { return Vault(multisigPDA, 1, programID) },
+		"transaction 2":      func() (solana.PublicKey, uint8, error) { return Transaction(multisigPDA, 2, programID) },
+		"proposal":           func() (solana.PublicKey, uint8, error) { return Proposal(multisigPDA, 1, programID) },
+		"batch transaction":  func() (solana.PublicKey, uint8, error) { return BatchTransaction(multisigPDA, 1, 1, programID) },
+		"ephemeral signer":   func() (solana.PublicKey, uint8, error) { return EphemeralSigner(txPDA, 0, programID) },
+		"spending limit":     func() (solana.PublicKey, uint8, error) { return SpendingLimit(multisigPDA, member, programID) },
+		"transaction buffer": func() (solana.PublicKey, uint8, error) { return TransactionBuffer(multisigPDA, member, 0, programID) },
+	}
+
+	seen := map[solana.PublicKey]string{txPDA: "transaction"}
+	for name, fn := range derive {
+		address, _, err := fn()
+		require.NoError(t, err, name)
+		require.False(t, address.IsOnCurve(), "%s must be off the curve", name)
+		other, dup := seen[address]
+		require.False(t, dup, "%s collides with %s", name, other)
+		seen[address] = name
+	}
+}
@@ -0,0 +1,359 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// DefaultPollInterval is how often the status is polled and the transaction
+// rebroadcast while waiting for confirmation.
+const DefaultPollInterval = 2 * time.Second
+
+// DefaultMaxResigns is how many times an expired transaction is rebuilt with
+// a fresh blockhash before giving up.
+const DefaultMaxResigns = 3
+
+// Confirmation controls how SendAndConfirm waits for a transaction. The
+// deadline comes from the context.
+type Confirmation struct {
+	Commitment   rpc.CommitmentType // target commitment; defaults to Options.GetCommitment()
+	PollInterval time.Duration      // defaults to DefaultPollInterval
+	MaxResigns   int                // defaults to DefaultMaxResigns; negative disables re-signing
+}
+
+// Status is the outcome of a confirmed send.
+type Status int
+
+const (
+	StatusPending Status = iota // the deadline passed before the outcome was known
+	StatusLanded                // reached the target commitment without error
+	StatusFailed                // included in a block but failed, usually with a program error
+	StatusExpired               // the blockhash or nonce became invalid before it landed
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusLanded:
+		return "landed"
+	case StatusFailed:
+		return "failed"
+	case StatusExpired:
+		return "expired"
+	default:
+		return "pending"
+	}
+}
+
+// Result describes what happened to a transaction sent by SendAndConfirm.
+type Result struct {
+	Signature solana.Signature // signature of the last copy sent
+	Status    Status
+	Slot      uint64      // slot the transaction was processed in, when known
+	Err       interface{} // transaction error reported by the cluster when Status is StatusFailed
+	Resigned  int         // times the transaction was rebuilt with a fresh blockhash
+}
+
+// Sentinel errors matched by ConfirmError.
+var (
+	ErrFailed  = errors.New("transaction failed")
+	ErrExpired = errors.New("transaction expired before it landed")
+	ErrPending = errors.New("transaction was not confirmed before the deadline")
+)
+
+// ConfirmError is returned when a transaction did not land. errors.Is matches
+// it against ErrFailed, ErrExpired or ErrPending according to its status.
+type ConfirmError struct {
+	Result      *Result
+	Instruction *InstructionError // set when a failed transaction names the failing instruction
+}
+
+func (e *ConfirmError) Error() string {
+	switch e.Result.Status {
+	case StatusFailed:
+		if e.Instruction != nil {
+			return fmt.Sprintf("transaction %s failed: %v", e.Result.Signature, e.Instruction)
+		}
+		return fmt.Sprintf("transaction %s failed: %v", e.Result.Signature, e.Result.Err)
+	case StatusExpired:
+		return fmt.Sprintf("transaction %s expired before it landed", e.Result.Signature)
+	default:
+		return fmt.Sprintf("transaction %s was not confirmed before the deadline", e.Result.Signature)
+	}
+}
+
+// Unwrap returns the instruction error, if any.
+func (e *ConfirmError) Unwrap() error {
+	if e.Instruction == nil {
+		return nil
+	}
+	return e.Instruction
+}
+
+// Is implements errors.Is.
+func (e *ConfirmError) Is(target error) bool {
+	switch e.Result.Status {
+	case StatusFailed:
+		return target == ErrFailed
+	case StatusExpired:
+		return target == ErrExpired
+	case StatusPending:
+		return target == ErrPending
+	}
+	return false
+}
+
+// SendAndConfirm builds, signs and submits a transaction, then waits until it
+// reaches the target commitment. While waiting it rebroadcasts the
+// transaction. It is rebuilt and re-signed only once the finalized chain is
+// past the blockhash's last valid block height, or has advanced the nonce,
+// and the old signature is still unknown with the transaction history
+// searched; the old copy can then no longer be processed, so it cannot run
+// twice. That takes a couple of minutes, so a ctx deadline much shorter
+// than that ends with StatusPending before any re-sign.
+//
+// A non-nil error other than a *ConfirmError means the transaction was not
+// sent; with a *ConfirmError the Result is also returned.
+func (o Options) SendAndConfirm(
+	ctx context.Context,
+	instructions []solana.Instruction,
+	member signer.Signer,
+	signers ...signer.Signer,
+) (*Result, error) {
+	build := func(ctx context.Context) (*solana.Transaction, uint64, error) {
+		return o.buildTransaction(ctx, instructions, member, signers)
+	}
+	tx, lastValid, err := build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return o.confirm(ctx, tx, lastValid, build)
+}
+
+// SubmitAndConfirm broadcasts an already signed transaction and waits for it
+// like SendAndConfirm. It cannot re-sign, so an expired blockhash ends with
+// StatusExpired.
+func (o Options) SubmitAndConfirm(ctx context.Context, tx *solana.Transaction) (*Result, error) {
+	return o.confirm(ctx, tx, 0, nil)
+}
+
+func (o Options) confirmation() Confirmation {
+	var cfg Confirmation
+	if o.Confirmation != nil {
+		cfg = *o.Confirmation
+	}
+	if cfg.Commitment == "" {
+		cfg.Commitment = o.GetCommitment()
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	if cfg.MaxResigns == 0 {
+		cfg.MaxResigns = DefaultMaxResigns
+	}
+	return cfg
+}
+
+// confirm submits tx and waits for it. lastValid is the last block height at
+// which its blockhash is valid, or 0 if unknown or a nonce is used.
+func (o Options) confirm(
+	ctx context.Context,
+	tx *solana.Transaction,
+	lastValid uint64,
+	rebuild func(context.Context) (*solana.Transaction, uint64, error),
+) (*Result, error) {
+	cfg := o.confirmation()
+
+	sig, err := o.Submit(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Signature: sig}
+
+	watchCtx, stopWatching := context.WithCancel(ctx)
+	defer stopWatching()
+	notify := o.watch(watchCtx, sig, cfg.Commitment)
+
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := o.signatureStatus(ctx, result.Signature, false)
+		if err == nil && status == nil {
+			var expired bool
+			expired, err = o.isExpired(ctx, tx, lastValid)
+			switch {
+			case err != nil:
+				// Transient RPC failure; try again on the next tick.
+			case !expired:
+				o.rebroadcast(ctx, tx)
+			default:
+				// The recent status cache may no longer hold the signature,
+				// and with a nonce our own transaction landing is what
+				// advanced it; only a miss in the full history shows the
+				// old copy never landed.
+				status, err = o.signatureStatus(ctx, result.Signature, true)
+				if err != nil || status != nil {
+					break
+				}
+				if rebuild == nil || cfg.MaxResigns < 0 || result.Resigned >= cfg.MaxResigns {
+					result.Status = StatusExpired
+					return result, &ConfirmError{Result: result}
+				}
+				fresh, freshLastValid, err := rebuild(ctx)
+				if err != nil {
+					return result, fmt.Errorf("failed to re-sign expired transaction: %w", err)
+				}
+				sig, err := o.Submit(ctx, fresh)
+				if err != nil {
+					return result, fmt.Errorf("failed to resend transaction: %w", err)
+				}
+				tx, lastValid = fresh, freshLastValid
+				result.Signature = sig
+				result.Resigned++
+				notify = o.watch(watchCtx, sig, cfg.Commitment)
+			}
+		}
+		if err == nil && status != nil {
+			result.Slot = status.Slot
+			if status.Err != nil {
+				result.Status = StatusFailed
+				result.Err = status.Err
+				logs := o.transactionLogs(ctx, result.Signature)
+				return result, &ConfirmError{Result: result, Instruction: instructionError(tx, status.Err, logs)}
+			}
+			if reached(status, cfg.Commitment) {
+				result.Status = StatusLanded
+				return result, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			result.Status = StatusPending
+			return result, &ConfirmError{Result: result}
+		case <-ticker.C:
+		case <-notify:
+			notify = nil
+		}
+	}
+}
+
+// transactionLogs fetches the program logs of a landed transaction, or nil if
+// they are not available.
+func (o Options) transactionLogs(ctx context.Context, sig solana.Signature) []string {
+	version := uint64(0)
+	out, err := o.Client.GetTransaction(ctx, sig, &rpc.GetTransactionOpts{
+		Commitment:                     rpc.CommitmentConfirmed,
+		MaxSupportedTransactionVersion: &version,
+	})
+	if err != nil || out.Meta == nil {
+		return nil
+	}
+	return out.Meta.LogMessages
+}
+
+// signatureStatus returns the status of sig, or nil if the cluster has not
+// seen it. Without searchHistory only the recent status cache is searched.
+func (o Options) signatureStatus(ctx context.Context, sig solana.Signature, searchHistory bool) (*rpc.SignatureStatusesResult, error) {
+	out, err := o.Client.GetSignatureStatuses(ctx, searchHistory, sig)
+	if err != nil {
+		return nil, err
+	}
+	if len(out.Value) == 0 {
+		return nil, nil
+	}
+	return out.Value[0], nil
+}
+
+// reached reports whether status has reached the target commitment.
+func reached(status *rpc.SignatureStatusesResult, target rpc.CommitmentType) bool {
+	rank := map[rpc.ConfirmationStatusType]int{
+		rpc.ConfirmationStatusProcessed: 0,
+		rpc.ConfirmationStatusConfirmed: 1,
+		rpc.ConfirmationStatusFinalized: 2,
+	}
+	got, ok := rank[status.ConfirmationStatus]
+	if !ok {
+		// Nodes that omit confirmationStatus report rooted transactions
+		// with a nil confirmation count.
+		if status.Confirmations != nil {
+			return false
+		}
+		got = 2
+	}
+	return got >= rank[rpc.ConfirmationStatusType(target)]
+}
+
+// isExpired reports whether tx can no longer be processed, judged at
+// finalized commitment so no fork can still include it: its nonce has
+// advanced, or the finalized block height is past lastValid. Without
+// lastValid the blockhash must be invalid at both processed and confirmed
+// commitment, which rules out one that is merely too new for the confirmed
+// bank. An advanced nonce does not mean tx did not land, since landing is
+// what advances it; the caller must still look the signature up.
+func (o Options) isExpired(ctx context.Context, tx *solana.Transaction, lastValid uint64) (bool, error) {
+	if nonceAccount, ok := UsesNonce(tx); ok {
+		finalized := o
+		finalized.Commitment = rpc.CommitmentFinalized
+		nonce, err := finalized.FetchNonce(ctx, nonceAccount)
+		if err != nil {
+			return false, err
+		}
+		return solana.Hash(nonce.Nonce) != tx.Message.RecentBlockhash, nil
+	}
+	if lastValid > 0 {
+		height, err := o.Client.GetBlockHeight(ctx, rpc.CommitmentFinalized)
+		if err != nil {
+			return false, err
+		}
+		return height > lastValid, nil
+	}
+	for _, commitment := range []rpc.CommitmentType{rpc.CommitmentProcessed, rpc.CommitmentConfirmed} {
+		valid, err := o.Client.IsBlockhashValid(ctx, tx.Message.RecentBlockhash, commitment)
+		if err != nil {
+			return false, err
+		}
+		if valid.Value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// rebroadcast resends tx without preflight. Errors are ignored; the next
+// status poll decides what happens.
+func (o Options) rebroadcast(ctx context.Context, tx *solana.Transaction) {
+	noRetries := uint(0)
+	_, _ = o.Client.SendTransactionWithOpts(ctx, tx, rpc.TransactionOpts{
+		SkipPreflight: true,
+		MaxRetries:    &noRetries,
+	})
+}
+
+// watch returns a channel that is closed when the websocket reports sig at
+// commitment, so confirmation doesn't wait for the next poll. Without a
+// websocket client it returns nil, which never fires.
+func (o Options) watch(ctx context.Context, sig solana.Signature, commitment rpc.CommitmentType) <-chan struct{} {
+	if o.WsClient == nil {
+		return nil
+	}
+	sub, err := o.WsClient.SignatureSubscribe(sig, commitment)
+	if err != nil {
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		defer sub.Unsubscribe()
+		if _, err := sub.Recv(ctx); err == nil {
+			close(done)
+		}
+	}()
+	return done
+}
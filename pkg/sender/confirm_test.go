@@ -0,0 +1,117 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ag_binary "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/internal/rpctest"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+func TestReached(t *testing.T) {
+	one := uint64(1)
+	tests := []struct {
+		status rpc.ConfirmationStatusType
+		count  *uint64
+		target rpc.CommitmentType
+		want   bool
+	}{
+		{rpc.ConfirmationStatusProcessed, &one, rpc.CommitmentProcessed, true},
+		{rpc.ConfirmationStatusProcessed, &one, rpc.CommitmentConfirmed, false},
+		{rpc.ConfirmationStatusConfirmed, &one, rpc.CommitmentConfirmed, true},
+		{rpc.ConfirmationStatusConfirmed, &one, rpc.CommitmentFinalized, false},
+		{rpc.ConfirmationStatusFinalized, nil, rpc.CommitmentConfirmed, true},
+		{"", &one, rpc.CommitmentConfirmed, false},
+		{"", nil, rpc.CommitmentFinalized, true},
+	}
+	for _, tt := range tests {
+		status := &rpc.SignatureStatusesResult{ConfirmationStatus: tt.status, Confirmations: tt.count}
+		require.Equal(t, tt.want, reached(status, tt.target), "reached(%q, %q)", tt.status, tt.target)
+	}
+}
+
+func TestConfirmErrorIs(t *testing.T) {
+	for status, sentinel := range map[Status]error{
+		StatusFailed:  ErrFailed,
+		StatusExpired: ErrExpired,
+		StatusPending: ErrPending,
+	} {
+		var err error = &ConfirmError{Result: &Result{Status: status}}
+		for _, other := range []error{ErrFailed, ErrExpired, ErrPending} {
+			require.Equal(t, other == sentinel, errors.Is(err, other), "%s vs %v", status, other)
+		}
+	}
+}
+
+// nonceServer is a JSON-RPC stand-in for a transaction that uses a durable
+// nonce. The first send lands: it advances the nonce, but the signature is
+// only found when the transaction history is searched.
+func nonceServer(t *testing.T, account, authority solana.PublicKey) *rpctest.Server {
+	server := rpctest.NewServer(solana.SystemProgramID)
+	setNonce := func(nonce solana.Hash) {
+		var buf bytes.Buffer
+		require.NoError(t, (&system.NonceAccount{
+			Version:          1,
+			State:            nonceStateInitialized,
+			AuthorizedPubkey: authority,
+			Nonce:            solana.PublicKey(nonce),
+		}).MarshalWithEncoder(ag_binary.NewBinEncoder(&buf)))
+		server.SetAccount(account, rpctest.Account{Owner: solana.SystemProgramID, Data: buf.Bytes()})
+	}
+	setNonce(solana.Hash{1})
+
+	sends := 0
+	server.Handle("sendTransaction", func(params []json.RawMessage) interface{} {
+		sends++
+		setNonce(solana.Hash{byte(sends + 1)})
+		return solana.Signature{byte(sends)}.String()
+	})
+	server.Handle("getSignatureStatuses", func(params []json.RawMessage) interface{} {
+		var searchHistory struct {
+			SearchTransactionHistory bool `json:"searchTransactionHistory"`
+		}
+		if len(params) > 1 {
+			json.Unmarshal(params[1], &searchHistory)
+		}
+		var status interface{}
+		if searchHistory.SearchTransactionHistory {
+			status = map[string]interface{}{"slot": 5, "confirmations": nil, "err": nil, "confirmationStatus": "finalized"}
+		}
+		return map[string]interface{}{"context": map[string]interface{}{"slot": 6}, "value": []interface{}{status}}
+	})
+	return server
+}
+
+func TestSendAndConfirmDoesNotResignLandedNonceTransaction(t *testing.T) {
+	member := signer.NewLocal(solana.NewWallet().PrivateKey)
+	nonceAccount := solana.NewWallet().PublicKey()
+	server := nonceServer(t, nonceAccount, member.PublicKey())
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	opts := Options{
+		Client:       rpc.New(httpServer.URL),
+		Nonce:        &Nonce{Account: nonceAccount},
+		Confirmation: &Confirmation{PollInterval: 10 * time.Millisecond},
+	}
+	transfer := system.NewTransferInstruction(1, member.PublicKey(), solana.NewWallet().PublicKey()).Build()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	result, err := opts.SendAndConfirm(ctx, []solana.Instruction{transfer}, member)
+	require.NoError(t, err)
+	require.Equal(t, StatusLanded, result.Status)
+	require.Equal(t, 0, result.Resigned)
+	require.Equal(t, 1, server.Calls("sendTransaction"), "the landed transaction was sent again under the advanced nonce")
+}
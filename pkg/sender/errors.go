@@ -0,0 +1,152 @@
+package sender
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+)
+
+// InstructionError reports which instruction of a transaction failed and why.
+type InstructionError struct {
+	Index   int
+	Program solana.PublicKey // program invoked by the failing instruction
+	Custom  *uint32          // program-defined error code, if the program returned one
+	Err     interface{}      // the instruction error as reported by the cluster
+
+	// FailedProgram is the program that raised Custom according to the
+	// transaction logs, which may be one Program invoked; zero without logs.
+	FailedProgram solana.PublicKey
+}
+
+func (e *InstructionError) Error() string {
+	if e.Custom != nil && !e.FailedProgram.IsZero() && !e.FailedProgram.Equals(e.Program) {
+		return fmt.Sprintf("instruction %d (%s) failed with custom program error %d raised by %s",
+			e.Index, e.Program, *e.Custom, e.FailedProgram)
+	}
+	if e.Custom != nil {
+		return fmt.Sprintf("instruction %d (%s) failed with custom program error %d", e.Index, e.Program, *e.Custom)
+	}
+	return fmt.Sprintf("instruction %d (%s) failed: %v", e.Index, e.Program, e.Err)
+}
+
+// SimulationError is returned when preflight or an explicit simulation
+// rejects a transaction before it is sent.
+type SimulationError struct {
+	Err         interface{}       // transaction error as reported by the cluster
+	Logs        []string          // program logs of the simulation
+	Instruction *InstructionError // set when a specific instruction failed
+}
+
+func (e *SimulationError) Error() string {
+	if e.Instruction != nil {
+		return "transaction simulation failed: " + e.Instruction.Error()
+	}
+	return fmt.Sprintf("transaction simulation failed: %v", e.Err)
+}
+
+// Unwrap returns the instruction error, if any.
+func (e *SimulationError) Unwrap() error {
+	if e.Instruction == nil {
+		return nil
+	}
+	return e.Instruction
+}
+
+// preflightError turns the RPC error of a rejected preflight simulation into
+// a *SimulationError, or returns err unchanged.
+func preflightError(tx *solana.Transaction, err error) error {
+	var rpcErr *jsonrpc.RPCError
+	if !errors.As(err, &rpcErr) {
+		return err
+	}
+	data, ok := rpcErr.Data.(map[string]interface{})
+	if !ok || data["err"] == nil {
+		return err
+	}
+	var logs []string
+	if raw, ok := data["logs"].([]interface{}); ok {
+		for _, line := range raw {
+			if s, ok := line.(string); ok {
+				logs = append(logs, s)
+			}
+		}
+	}
+	return &SimulationError{
+		Err:         data["err"],
+		Logs:        logs,
+		Instruction: instructionError(tx, data["err"], logs),
+	}
+}
+
+// instructionError decodes a transaction error of the form
+// {"InstructionError": [index, detail]}, returning nil for any other shape.
+// logs, if any, tell which program raised a custom error.
+func instructionError(tx *solana.Transaction, txErr interface{}, logs []string) *InstructionError {
+	obj, ok := txErr.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	pair, ok := obj["InstructionError"].([]interface{})
+	if !ok || len(pair) != 2 {
+		return nil
+	}
+	index, ok := toUint(pair[0])
+	if !ok {
+		return nil
+	}
+
+	out := &InstructionError{Index: int(index), Err: pair[1]}
+	if tx != nil && int(index) < len(tx.Message.Instructions) {
+		if program, err := tx.Message.Program(tx.Message.Instructions[index].ProgramIDIndex); err == nil {
+			out.Program = program
+		}
+	}
+	if detail, ok := pair[1].(map[string]interface{}); ok {
+		if code, ok := toUint(detail["Custom"]); ok {
+			custom := uint32(code)
+			out.Custom = &custom
+			out.FailedProgram = failedProgram(logs)
+		}
+	}
+	return out
+}
+
+// failedProgram returns the program that raised a custom error according to
+// logs. A failing CPI is logged by the callee first and then again by each
+// caller it returns the error through, so the first such line is the origin.
+func failedProgram(logs []string) solana.PublicKey {
+	for _, line := range logs {
+		rest, ok := strings.CutPrefix(line, "Program ")
+		if !ok {
+			continue
+		}
+		id, reason, ok := strings.Cut(rest, " failed: ")
+		if !ok || !strings.HasPrefix(reason, "custom program error") {
+			continue
+		}
+		if program, err := solana.PublicKeyFromBase58(id); err == nil {
+			return program
+		}
+	}
+	return solana.PublicKey{}
+}
+
+// toUint converts a JSON number decoded with or without UseNumber.
+func toUint(v interface{}) (uint64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := n.Int64()
+		return uint64(i), err == nil && i >= 0
+	case float64:
+		return uint64(n), n >= 0
+	case int:
+		return uint64(n), n >= 0
+	case uint64:
+		return n, true
+	}
+	return 0, false
+}
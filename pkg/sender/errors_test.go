@@ -0,0 +1,87 @@
+package sender
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
+	"github.com/stretchr/testify/require"
+)
+
+func testTransaction(t *testing.T) *solana.Transaction {
+	payer := solana.NewWallet().PublicKey()
+	tx, err := solana.NewTransaction(
+		append(ComputeBudgetInstructions(200_000, 0),
+			system.NewTransferInstruction(1, payer, solana.NewWallet().PublicKey()).Build()),
+		solana.Hash{},
+		solana.TransactionPayer(payer),
+	)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestInstructionError(t *testing.T) {
+	tx := testTransaction(t)
+
+	custom := map[string]interface{}{
+		"InstructionError": []interface{}{json.Number("1"), map[string]interface{}{"Custom": json.Number("6005")}},
+	}
+	ixErr := instructionError(tx, custom, nil)
+	require.NotNil(t, ixErr)
+	require.Equal(t, 1, ixErr.Index)
+	require.Equal(t, solana.SystemProgramID, ixErr.Program)
+	require.NotNil(t, ixErr.Custom)
+	require.EqualValues(t, 6005, *ixErr.Custom)
+	require.True(t, ixErr.FailedProgram.IsZero(), "no logs, no failing program")
+
+	// The error of a program called through CPI is logged by it first, then
+	// by its caller.
+	inner := solana.NewWallet().PublicKey()
+	ixErr = instructionError(tx, custom, []string{
+		"Program " + solana.SystemProgramID.String() + " invoke [1]",
+		"Program " + inner.String() + " invoke [2]",
+		"Program log: AnchorError occurred. Error Code: Overflow. Error Number: 6005.",
+		"Program " + inner.String() + " failed: custom program error: 0x1775",
+		"Program " + solana.SystemProgramID.String() + " failed: custom program error: 0x1775",
+	})
+	require.Equal(t, inner, ixErr.FailedProgram)
+	require.Contains(t, ixErr.Error(), "raised by "+inner.String())
+
+	ixErr = instructionError(tx, map[string]interface{}{
+		"InstructionError": []interface{}{float64(0), "InvalidAccountData"},
+	}, nil)
+	require.NotNil(t, ixErr)
+	require.Equal(t, solana.ComputeBudget, ixErr.Program)
+	require.Nil(t, ixErr.Custom)
+
+	require.Nil(t, instructionError(tx, "AccountNotFound", nil))
+	require.Nil(t, instructionError(tx, map[string]interface{}{"InsufficientFundsForRent": map[string]interface{}{}}, nil))
+}
+
+func TestPreflightError(t *testing.T) {
+	tx := testTransaction(t)
+	rpcErr := &jsonrpc.RPCError{
+		Code:    -32002,
+		Message: "Transaction simulation failed",
+		Data: map[string]interface{}{
+			"err":  map[string]interface{}{"InstructionError": []interface{}{json.Number("1"), map[string]interface{}{"Custom": json.Number("6004")}}},
+			"logs": []interface{}{"Program log: AnchorError"},
+		},
+	}
+
+	err := preflightError(tx, fmt.Errorf("send: %w", rpcErr))
+	var simErr *SimulationError
+	require.True(t, errors.As(err, &simErr))
+	require.Equal(t, []string{"Program log: AnchorError"}, simErr.Logs)
+
+	var ixErr *InstructionError
+	require.True(t, errors.As(err, &ixErr))
+	require.EqualValues(t, 6004, *ixErr.Custom)
+
+	other := errors.New("connection refused")
+	require.Equal(t, other, preflightError(tx, other))
+}
@@ -0,0 +1,141 @@
+package sender
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ag_binary "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// NonceAccountSize is the size of a system nonce account.
+const NonceAccountSize = 80
+
+// nonceStateInitialized is the system program's marker for a usable nonce.
+const nonceStateInitialized = 1
+
+// Nonce makes a transaction use a durable nonce instead of a recent blockhash,
+// so it stays valid until the nonce is advanced rather than for ~60-90 seconds.
+type Nonce struct {
+	Account   solana.PublicKey
+	Authority signer.Signer // optional; defaults to the acting member
+}
+
+// FetchNonce fetches and decodes a nonce account.
+func (o Options) FetchNonce(ctx context.Context, account solana.PublicKey) (*system.NonceAccount, error) {
+	accountInfo, err := o.GetAccountInfo(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce account: %w", err)
+	}
+	if !accountInfo.Value.Owner.Equals(solana.SystemProgramID) {
+		return nil, fmt.Errorf("%s is not owned by the system program", account)
+	}
+
+	var nonce system.NonceAccount
+	if err := nonce.UnmarshalWithDecoder(ag_binary.NewBinDecoder(accountInfo.Value.Data.GetBinary())); err != nil {
+		return nil, fmt.Errorf("failed to decode nonce account: %w", err)
+	}
+	if nonce.State != nonceStateInitialized {
+		return nil, fmt.Errorf("nonce account %s is not initialized", account)
+	}
+	return &nonce, nil
+}
+
+// nonceAuthority returns the signer that advances the nonce on behalf of member.
+func (o Options) nonceAuthority(member signer.Signer) signer.Signer {
+	if o.Nonce.Authority != nil {
+		return o.Nonce.Authority
+	}
+	return member
+}
+
+// advanceNonce fetches the current nonce value and builds the
+// AdvanceNonceAccount instruction, which the runtime requires to come first.
+func (o Options) advanceNonce(ctx context.Context, member signer.Signer) (solana.Instruction, solana.Hash, error) {
+	nonce, err := o.FetchNonce(ctx, o.Nonce.Account)
+	if err != nil {
+		return nil, solana.Hash{}, err
+	}
+
+	authority := o.nonceAuthority(member).PublicKey()
+	if !nonce.AuthorizedPubkey.Equals(authority) {
+		return nil, solana.Hash{}, fmt.Errorf("nonce account %s is controlled by %s, not %s",
+			o.Nonce.Account, nonce.AuthorizedPubkey, authority)
+	}
+
+	advance := system.NewAdvanceNonceAccountInstruction(
+		o.Nonce.Account,
+		solana.SysVarRecentBlockHashesPubkey,
+		authority,
+	).Build()
+	return advance, solana.Hash(nonce.Nonce), nil
+}
+
+// UsesNonce reports whether tx starts with an AdvanceNonceAccount instruction,
+// returning the nonce account if so.
+func UsesNonce(tx *solana.Transaction) (solana.PublicKey, bool) {
+	if len(tx.Message.Instructions) == 0 {
+		return solana.PublicKey{}, false
+	}
+	first := tx.Message.Instructions[0]
+	keys := tx.Message.AccountKeys
+	if int(first.ProgramIDIndex) >= len(keys) || !keys[first.ProgramIDIndex].Equals(solana.SystemProgramID) {
+		return solana.PublicKey{}, false
+	}
+	if len(first.Data) < 4 || binary.LittleEndian.Uint32(first.Data) != system.Instruction_AdvanceNonceAccount {
+		return solana.PublicKey{}, false
+	}
+	if len(first.Accounts) == 0 || int(first.Accounts[0]) >= len(keys) {
+		return solana.PublicKey{}, false
+	}
+	return keys[first.Accounts[0]], true
+}
+
+// CreateNonceAccount creates and initializes a nonce account at the address of
+// nonceAccount, funded by funder and controlled by authority. The authority
+// may be any key, including a multisig vault.
+func (o Options) CreateNonceAccount(
+	ctx context.Context,
+	funder signer.Signer,
+	nonceAccount signer.Signer,
+	authority solana.PublicKey,
+) (solana.Signature, error) {
+	if err := o.Validate(); err != nil {
+		return solana.Signature{}, err
+	}
+	if funder == nil || nonceAccount == nil {
+		return solana.Signature{}, errors.New("funder and nonce account signers are required")
+	}
+
+	rent, err := o.Client.GetMinimumBalanceForRentExemption(ctx, NonceAccountSize, o.GetCommitment())
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("failed to get rent exemption: %w", err)
+	}
+
+	instructions := []solana.Instruction{
+		system.NewCreateAccountInstruction(
+			rent,
+			NonceAccountSize,
+			solana.SystemProgramID,
+			o.Payer(funder).PublicKey(),
+			nonceAccount.PublicKey(),
+		).Build(),
+		system.NewInitializeNonceAccountInstruction(
+			authority,
+			nonceAccount.PublicKey(),
+			solana.SysVarRecentBlockHashesPubkey,
+			solana.SysVarRentPubkey,
+		).Build(),
+	}
+
+	result, err := o.SendAndConfirm(ctx, instructions, funder, nonceAccount)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	return result.Signature, nil
+}
@@ -0,0 +1,32 @@
+package sender
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsesNonce(t *testing.T) {
+	payer := solana.NewWallet().PublicKey()
+	nonceAccount := solana.NewWallet().PublicKey()
+	transfer := system.NewTransferInstruction(1, payer, solana.NewWallet().PublicKey()).Build()
+	advance := system.NewAdvanceNonceAccountInstruction(
+		nonceAccount,
+		solana.SysVarRecentBlockHashesPubkey,
+		payer,
+	).Build()
+
+	tx, err := solana.NewTransaction([]solana.Instruction{advance, transfer}, solana.Hash{1}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+	account, ok := UsesNonce(tx)
+	require.True(t, ok)
+	require.Equal(t, nonceAccount, account)
+
+	// AdvanceNonceAccount only counts as the first instruction.
+	tx, err = solana.NewTransaction([]solana.Instruction{transfer, advance}, solana.Hash{1}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+	_, ok = UsesNonce(tx)
+	require.False(t, ok)
+}
@@ -0,0 +1,163 @@
+package sender
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gagliardetto/solana-go"
+	computebudget "github.com/gagliardetto/solana-go/programs/compute-budget"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// MaxComputeUnitLimit is the largest compute unit limit a transaction may request.
+const MaxComputeUnitLimit = computebudget.MAX_COMPUTE_UNIT_LIMIT
+
+// autoUnitLimitMargin is the headroom, in percent, added to the simulated
+// compute usage so small state changes before landing don't exhaust the budget.
+const autoUnitLimitMargin = 10
+
+// autoUnitPricePercentile picks the recent prioritization fee to pay. Paying
+// above the median keeps transactions competitive during congestion.
+const autoUnitPricePercentile = 75
+
+// ComputeBudget controls the ComputeBudget instructions added to outgoing
+// transactions. Explicit values win over the automatic ones.
+type ComputeBudget struct {
+	UnitLimit uint32 // compute unit limit; 0 keeps the runtime default
+	UnitPrice uint64 // priority fee in micro-lamports per compute unit; 0 pays none
+
+	AutoUnitLimit bool // size UnitLimit by simulating the transaction
+	AutoUnitPrice bool // derive UnitPrice from recent prioritization fees of the writable accounts
+}
+
+// computeBudgetInstructions resolves the automatic settings and returns the
+// SetComputeUnitLimit and SetComputeUnitPrice instructions to insert after
+// prefix (the nonce advance, if any).
+func (o Options) computeBudgetInstructions(
+	ctx context.Context,
+	prefix []solana.Instruction,
+	instructions []solana.Instruction,
+	payer solana.PublicKey,
+	blockhash solana.Hash,
+) ([]solana.Instruction, error) {
+	budget := *o.ComputeBudget
+
+	if budget.UnitPrice == 0 && budget.AutoUnitPrice {
+		price, err := o.estimateUnitPrice(ctx, append(prefix, instructions...), payer, blockhash)
+		if err != nil {
+			return nil, err
+		}
+		budget.UnitPrice = price
+	}
+
+	if budget.UnitLimit == 0 && budget.AutoUnitLimit {
+		// Simulate with the maximum limit and the final price so the
+		// simulated instruction list matches what will be sent.
+		probe := append([]solana.Instruction{}, prefix...)
+		probe = append(probe, ComputeBudgetInstructions(MaxComputeUnitLimit, budget.UnitPrice)...)
+		probe = append(probe, instructions...)
+		limit, err := o.estimateUnitLimit(ctx, probe, payer, blockhash)
+		if err != nil {
+			return nil, err
+		}
+		budget.UnitLimit = limit
+	}
+
+	return ComputeBudgetInstructions(budget.UnitLimit, budget.UnitPrice), nil
+}
+
+// ComputeBudgetInstructions builds SetComputeUnitLimit and SetComputeUnitPrice
+// instructions, skipping zero values.
+func ComputeBudgetInstructions(unitLimit uint32, unitPrice uint64) []solana.Instruction {
+	var instructions []solana.Instruction
+	if unitLimit > 0 {
+		instructions = append(instructions, computebudget.NewSetComputeUnitLimitInstruction(unitLimit).Build())
+	}
+	if unitPrice > 0 {
+		instructions = append(instructions, computebudget.NewSetComputeUnitPriceInstruction(unitPrice).Build())
+	}
+	return instructions
+}
+
+// estimateUnitLimit simulates the transaction without signatures and returns
+// the consumed compute units plus autoUnitLimitMargin.
+func (o Options) estimateUnitLimit(
+	ctx context.Context,
+	instructions []solana.Instruction,
+	payer solana.PublicKey,
+	blockhash solana.Hash,
+) (uint32, error) {
+	tx, err := solana.NewTransaction(instructions, blockhash, o.transactionOptions(payer)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create simulation transaction: %w", err)
+	}
+	tx.Signatures = make([]solana.Signature, tx.Message.Header.NumRequiredSignatures)
+
+	res, err := o.Client.SimulateTransactionWithOpts(ctx, tx, &rpc.SimulateTransactionOpts{
+		Commitment:             o.GetCommitment(),
+		ReplaceRecentBlockhash: true,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to simulate transaction: %w", err)
+	}
+	if res.Value.Err != nil {
+		return 0, &SimulationError{
+			Err:         res.Value.Err,
+			Logs:        res.Value.Logs,
+			Instruction: instructionError(tx, res.Value.Err, res.Value.Logs),
+		}
+	}
+	if res.Value.UnitsConsumed == nil {
+		return 0, fmt.Errorf("simulation did not report compute units")
+	}
+
+	limit := *res.Value.UnitsConsumed * (100 + autoUnitLimitMargin) / 100
+	if limit > MaxComputeUnitLimit {
+		limit = MaxComputeUnitLimit
+	}
+	return uint32(limit), nil
+}
+
+// estimateUnitPrice returns the autoUnitPricePercentile of the recent
+// prioritization fees paid for the writable accounts of the transaction.
+func (o Options) estimateUnitPrice(
+	ctx context.Context,
+	instructions []solana.Instruction,
+	payer solana.PublicKey,
+	blockhash solana.Hash,
+) (uint64, error) {
+	tx, err := solana.NewTransaction(instructions, blockhash, o.transactionOptions(payer)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	writable, err := tx.Message.Writable()
+	if err != nil {
+		return 0, err
+	}
+
+	fees, err := o.Client.GetRecentPrioritizationFees(ctx, writable)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get recent prioritization fees: %w", err)
+	}
+	samples := make([]uint64, len(fees))
+	for i, fee := range fees {
+		samples[i] = fee.PrioritizationFee
+	}
+	return percentile(samples, autoUnitPricePercentile), nil
+}
+
+// percentile returns the p-th percentile (nearest rank) of values, or 0 for
+// an empty slice.
+func percentile(values []uint64, p int) uint64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]uint64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,38 @@
+package sender
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		values []uint64
+		p      int
+		want   uint64
+	}{
+		{nil, 75, 0},
+		{[]uint64{5}, 75, 5},
+		{[]uint64{4, 1, 3, 2}, 50, 2},
+		{[]uint64{4, 1, 3, 2}, 75, 3},
+		{[]uint64{0, 0, 0, 100}, 75, 0},
+		{[]uint64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, 75, 80},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, percentile(tt.values, tt.p), "percentile(%v, %d)", tt.values, tt.p)
+	}
+}
+
+func TestComputeBudgetInstructions(t *testing.T) {
+	require.Empty(t, ComputeBudgetInstructions(0, 0))
+
+	ixs := ComputeBudgetInstructions(200_000, 5_000)
+	require.Len(t, ixs, 2)
+	for _, ix := range ixs {
+		require.Equal(t, solana.ComputeBudget, ix.ProgramID())
+	}
+
+	require.Len(t, ComputeBudgetInstructions(0, 5_000), 1)
+}
@@ -0,0 +1,207 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/ws"
+
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// DefaultCommitment is used when Options.Commitment is left empty.
+const DefaultCommitment = rpc.CommitmentConfirmed
+
+// Options carries the connection settings shared by every transaction the SDK
+// reads or sends. It is embedded in the SDK input types and filled in by
+// squads.Client.
+type Options struct {
+	Client     *rpc.Client
+	WsClient   *ws.Client         // optional; speeds up confirmation
+	Commitment rpc.CommitmentType // defaults to DefaultCommitment
+	FeePayer   signer.Signer      // optional; defaults to the acting member
+	Nonce      *Nonce             // optional; use a durable nonce instead of a recent blockhash
+
+	ComputeBudget *ComputeBudget // optional; adds ComputeBudget instructions
+	Confirmation  *Confirmation  // optional; tunes SendAndConfirm
+
+	// AddressTables, if set, makes transactions v0 messages that load their
+	// non-signer accounts from these lookup tables (table address to its
+	// addresses).
+	AddressTables map[solana.PublicKey]solana.PublicKeySlice
+}
+
+// Validate checks that the options are usable.
+func (o Options) Validate() error {
+	if o.Client == nil {
+		return errors.New("rpc client is required")
+	}
+	return nil
+}
+
+// GetCommitment returns the configured commitment or DefaultCommitment.
+func (o Options) GetCommitment() rpc.CommitmentType {
+	if o.Commitment == "" {
+		return DefaultCommitment
+	}
+	return o.Commitment
+}
+
+// Payer returns the fee payer for a transaction sent on behalf of member.
+func (o Options) Payer(member signer.Signer) signer.Signer {
+	if o.FeePayer != nil {
+		return o.FeePayer
+	}
+	return member
+}
+
+// GetAccountInfo fetches an account at the configured commitment.
+// A missing account is reported as rpc.ErrNotFound.
+func (o Options) GetAccountInfo(ctx context.Context, account solana.PublicKey) (*rpc.GetAccountInfoResult, error) {
+	return o.Client.GetAccountInfoWithOpts(ctx, account, &rpc.GetAccountInfoOpts{
+		Commitment: o.GetCommitment(),
+	})
+}
+
+// BuildTransaction assembles a transaction paid by o.Payer(member) with a fresh
+// blockhash, or the current nonce when o.Nonce is set, and signs it with the
+// payer, the member, the nonce authority and any extra signers.
+func (o Options) BuildTransaction(
+	ctx context.Context,
+	instructions []solana.Instruction,
+	member signer.Signer,
+	signers ...signer.Signer,
+) (*solana.Transaction, error) {
+	tx, _, err := o.buildTransaction(ctx, instructions, member, signers)
+	return tx, err
+}
+
+// buildTransaction is BuildTransaction that also returns the last block
+// height at which the blockhash is valid, or 0 when a nonce is used.
+func (o Options) buildTransaction(
+	ctx context.Context,
+	instructions []solana.Instruction,
+	member signer.Signer,
+	signers []signer.Signer,
+) (*solana.Transaction, uint64, error) {
+	tx, all, lastValid, err := o.newTransaction(ctx, instructions, member, signers)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := signer.SignTransaction(ctx, tx, all...); err != nil {
+		return nil, 0, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return tx, lastValid, nil
+}
+
+// Prepare is like BuildTransaction but only signs with the signers that hold
+// a key. Signature slots for offline signers are left empty so the
+// transaction can be exported, signed elsewhere and passed to Submit.
+func (o Options) Prepare(
+	ctx context.Context,
+	instructions []solana.Instruction,
+	member signer.Signer,
+	signers ...signer.Signer,
+) (*solana.Transaction, error) {
+	tx, all, _, err := o.newTransaction(ctx, instructions, member, signers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := signer.SignPartial(ctx, tx, all...); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return tx, nil
+}
+
+// newTransaction creates an unsigned transaction paid by o.Payer(member) and
+// returns it with every signer that may be required and the last block
+// height at which its blockhash is valid (0 with a nonce).
+func (o Options) newTransaction(
+	ctx context.Context,
+	instructions []solana.Instruction,
+	member signer.Signer,
+	signers []signer.Signer,
+) (*solana.Transaction, []signer.Signer, uint64, error) {
+	all := append([]signer.Signer{o.Payer(member), member}, signers...)
+	payer := o.Payer(member).PublicKey()
+
+	var prefix []solana.Instruction
+	var blockhash solana.Hash
+	var lastValid uint64
+	if o.Nonce != nil {
+		advance, hash, err := o.advanceNonce(ctx, member)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		prefix = append(prefix, advance)
+		blockhash = hash
+		all = append(all, o.nonceAuthority(member))
+	} else {
+		hash, err := o.Client.GetLatestBlockhash(ctx, o.GetCommitment())
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to get latest blockhash: %w", err)
+		}
+		blockhash = hash.Value.Blockhash
+		lastValid = hash.Value.LastValidBlockHeight
+	}
+
+	if o.ComputeBudget != nil {
+		budget, err := o.computeBudgetInstructions(ctx, prefix, instructions, payer, blockhash)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		prefix = append(prefix, budget...)
+	}
+
+	tx, err := solana.NewTransaction(
+		append(prefix, instructions...),
+		blockhash,
+		o.transactionOptions(payer)...,
+	)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to create transaction: %w", err)
+	}
+	return tx, all, lastValid, nil
+}
+
+// transactionOptions returns the solana.NewTransaction options for a
+// transaction paid by payer.
+func (o Options) transactionOptions(payer solana.PublicKey) []solana.TransactionOption {
+	opts := []solana.TransactionOption{solana.TransactionPayer(payer)}
+	if len(o.AddressTables) > 0 {
+		opts = append(opts, solana.TransactionAddressTables(o.AddressTables))
+	}
+	return opts
+}
+
+// Send builds, signs and submits a transaction without waiting for confirmation.
+func (o Options) Send(
+	ctx context.Context,
+	instructions []solana.Instruction,
+	member signer.Signer,
+	signers ...signer.Signer,
+) (solana.Signature, error) {
+	tx, err := o.BuildTransaction(ctx, instructions, member, signers...)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	return o.Submit(ctx, tx)
+}
+
+// Submit broadcasts an already signed transaction.
+func (o Options) Submit(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+	sig, err := o.Client.SendTransactionWithOpts(ctx, tx, rpc.TransactionOpts{
+		PreflightCommitment: o.GetCommitment(),
+	})
+	if err != nil {
+		return sig, preflightError(tx, err)
+	}
+	return sig, nil
+}
@@ -0,0 +1,147 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Remote asks an HTTP signing service for signatures.
+//
+// The service exposes two JSON endpoints relative to the base URL:
+//
+//	GET  /public-key -> {"publicKey": "<base58>"}
+//	POST /sign       {"publicKey": "<base58>", "message": "<base64>"} -> {"signature": "<base58>"}
+//
+// Every returned signature is verified against the public key before use.
+type Remote struct {
+	endpoint   string
+	publicKey  solana.PublicKey
+	token      string
+	httpClient *http.Client
+}
+
+// RemoteOption configures a Remote signer.
+type RemoteOption func(*Remote)
+
+// WithToken sends "Authorization: Bearer <token>" with every request.
+func WithToken(token string) RemoteOption {
+	return func(r *Remote) {
+		r.token = token
+	}
+}
+
+// WithHTTPClient replaces the default HTTP client (30s timeout).
+func WithHTTPClient(client *http.Client) RemoteOption {
+	return func(r *Remote) {
+		r.httpClient = client
+	}
+}
+
+// NewRemote creates a remote signer for a known public key.
+func NewRemote(endpoint string, publicKey solana.PublicKey, opts ...RemoteOption) *Remote {
+	r := &Remote{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		publicKey:  publicKey,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// DialRemote creates a remote signer and asks the service for its public key.
+func DialRemote(ctx context.Context, endpoint string, opts ...RemoteOption) (*Remote, error) {
+	r := NewRemote(endpoint, solana.PublicKey{}, opts...)
+
+	var resp struct {
+		PublicKey string `json:"publicKey"`
+	}
+	if err := r.do(ctx, http.MethodGet, "/public-key", nil, &resp); err != nil {
+		return nil, err
+	}
+	publicKey, err := solana.PublicKeyFromBase58(resp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid public key: %w", err)
+	}
+	r.publicKey = publicKey
+	return r, nil
+}
+
+// PublicKey implements Signer.
+func (r *Remote) PublicKey() solana.PublicKey {
+	return r.publicKey
+}
+
+// SignMessage implements Signer.
+func (r *Remote) SignMessage(ctx context.Context, message []byte) (solana.Signature, error) {
+	req := struct {
+		PublicKey string `json:"publicKey"`
+		Message   string `json:"message"`
+	}{
+		PublicKey: r.publicKey.String(),
+		Message:   base64.StdEncoding.EncodeToString(message),
+	}
+	var resp struct {
+		Signature string `json:"signature"`
+	}
+	if err := r.do(ctx, http.MethodPost, "/sign", req, &resp); err != nil {
+		return solana.Signature{}, err
+	}
+
+	sig, err := solana.SignatureFromBase58(resp.Signature)
+	if err != nil {
+		return solana.Signature{}, fmt.Errorf("remote signer returned an invalid signature: %w", err)
+	}
+	if !sig.Verify(r.publicKey, message) {
+		return solana.Signature{}, fmt.Errorf("remote signer returned a signature that does not verify for %s", r.publicKey)
+	}
+	return sig, nil
+}
+
+// do sends a JSON request and decodes the JSON response into out.
+func (r *Remote) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, r.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("remote signer returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode remote signer response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package signer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/require"
+)
+
+// newStandInServer serves the remote signer protocol for key.
+func newStandInServer(t *testing.T, key solana.PrivateKey, token string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/public-key", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"publicKey": key.PublicKey().String()})
+	})
+	mux.HandleFunc("/sign", func(w http.ResponseWriter, r *http.Request) {
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			PublicKey string `json:"publicKey"`
+			Message   string `json:"message"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		message, err := base64.StdEncoding.DecodeString(req.Message)
+		require.NoError(t, err)
+		sig, err := key.Sign(message)
+		require.NoError(t, err)
+		json.NewEncoder(w).Encode(map[string]string{"signature": sig.String()})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRemoteSignMessage(t *testing.T) {
+	ctx := context.Background()
+	key := solana.NewWallet().PrivateKey
+	server := newStandInServer(t, key, "secret")
+
+	remote, err := DialRemote(ctx, server.URL, WithToken("secret"))
+	require.NoError(t, err)
+	require.Equal(t, key.PublicKey(), remote.PublicKey())
+
+	message := []byte("squads")
+	sig, err := remote.SignMessage(ctx, message)
+	require.NoError(t, err)
+	require.True(t, sig.Verify(key.PublicKey(), message))
+}
+
+func TestRemoteRejectsUnauthorized(t *testing.T) {
+	key := solana.NewWallet().PrivateKey
+	server := newStandInServer(t, key, "secret")
+
+	remote := NewRemote(server.URL, key.PublicKey())
+	_, err := remote.SignMessage(context.Background(), []byte("squads"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "401")
+}
+
+func TestRemoteRejectsSignatureFromWrongKey(t *testing.T) {
+	key := solana.NewWallet().PrivateKey
+	server := newStandInServer(t, key, "")
+
+	// The service ignores the requested key and signs with its own, so the
+	// signature must fail verification on the client side.
+	remote := NewRemote(server.URL, solana.NewWallet().PublicKey())
+	_, err := remote.SignMessage(context.Background(), []byte("squads"))
+	require.Error(t, err)
+}
+
+func TestSignTransactionWithMixedSigners(t *testing.T) {
+	ctx := context.Background()
+	payerKey := solana.NewWallet().PrivateKey
+	remoteKey := solana.NewWallet().PrivateKey
+	server := newStandInServer(t, remoteKey, "")
+
+	payer := NewLocal(payerKey)
+	remote := NewRemote(server.URL, remoteKey.PublicKey())
+
+	tx, err := solana.NewTransaction(
+		[]solana.Instruction{
+			system.NewTransferInstruction(1, remoteKey.PublicKey(), payerKey.PublicKey()).Build(),
+		},
+		solana.Hash{1},
+		solana.TransactionPayer(payerKey.PublicKey()),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, SignTransaction(ctx, tx, payer, remote))
+	require.NoError(t, tx.VerifySignatures())
+
+	err = SignTransaction(ctx, tx, payer)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), remoteKey.PublicKey().String())
+}
@@ -0,0 +1,133 @@
+// Package signer abstracts where transaction signatures come from, so SDK
+// operations can be signed by an in-memory key, a remote signing service or a
+// test double without the private key living in the caller's process.
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Signer produces ed25519 signatures for a single public key.
+type Signer interface {
+	PublicKey() solana.PublicKey
+	SignMessage(ctx context.Context, message []byte) (solana.Signature, error)
+}
+
+// Local signs with a private key held in memory.
+type Local struct {
+	key solana.PrivateKey
+}
+
+// NewLocal wraps a private key.
+func NewLocal(key solana.PrivateKey) *Local {
+	return &Local{key: key}
+}
+
+// PublicKey implements Signer.
+func (l *Local) PublicKey() solana.PublicKey {
+	return l.key.PublicKey()
+}
+
+// SignMessage implements Signer.
+func (l *Local) SignMessage(_ context.Context, message []byte) (solana.Signature, error) {
+	return l.key.Sign(message)
+}
+
+// ErrNoPrivateKey is returned by signers that only know a public key.
+var ErrNoPrivateKey = errors.New("no private key available")
+
+// Offline stands in for a key held on another machine, typically an
+// air-gapped one. It reports the public key so transactions can be built for
+// it, but refuses to sign.
+type Offline struct {
+	publicKey solana.PublicKey
+}
+
+// NewOffline creates a placeholder signer for publicKey.
+func NewOffline(publicKey solana.PublicKey) *Offline {
+	return &Offline{publicKey: publicKey}
+}
+
+// PublicKey implements Signer.
+func (o *Offline) PublicKey() solana.PublicKey {
+	return o.publicKey
+}
+
+// SignMessage implements Signer and always returns ErrNoPrivateKey.
+func (o *Offline) SignMessage(context.Context, []byte) (solana.Signature, error) {
+	return solana.Signature{}, fmt.Errorf("%s is held offline: %w", o.publicKey, ErrNoPrivateKey)
+}
+
+// SignTransaction fills in the signatures of tx for every required signer
+// found among signers. Signers that the message does not require are ignored;
+// a required signer with no matching Signer is an error.
+func SignTransaction(ctx context.Context, tx *solana.Transaction, signers ...Signer) error {
+	return sign(ctx, tx, signers, false)
+}
+
+// SignPartial is like SignTransaction but leaves the signature slot empty for
+// required keys that have no Signer or whose Signer returns ErrNoPrivateKey,
+// so the remaining signatures can be added elsewhere.
+func SignPartial(ctx context.Context, tx *solana.Transaction, signers ...Signer) error {
+	return sign(ctx, tx, signers, true)
+}
+
+// Missing lists the required signers of tx whose signature is still empty.
+func Missing(tx *solana.Transaction) []solana.PublicKey {
+	var missing []solana.PublicKey
+	required := int(tx.Message.Header.NumRequiredSignatures)
+	for i, key := range tx.Message.AccountKeys[:required] {
+		if i >= len(tx.Signatures) || tx.Signatures[i].IsZero() {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+func sign(ctx context.Context, tx *solana.Transaction, signers []Signer, partial bool) error {
+	message, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	required := tx.Message.Header.NumRequiredSignatures
+	if len(tx.Signatures) != int(required) {
+		tx.Signatures = make([]solana.Signature, required)
+	}
+
+	for i, key := range tx.Message.AccountKeys[:required] {
+		s := find(signers, key)
+		if s == nil {
+			if partial {
+				continue
+			}
+			return fmt.Errorf("no signer for required key %s", key)
+		}
+		sig, err := s.SignMessage(ctx, message)
+		if partial && errors.Is(err, ErrNoPrivateKey) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to sign with %s: %w", key, err)
+		}
+		if !sig.Verify(key, message) {
+			return fmt.Errorf("signer %s returned an invalid signature", key)
+		}
+		tx.Signatures[i] = sig
+	}
+	return nil
+}
+
+// find returns the signer for key, or nil.
+func find(signers []Signer, key solana.PublicKey) Signer {
+	for _, s := range signers {
+		if s != nil && s.PublicKey().Equals(key) {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Package spendinglimit inspects spending limits and spends from a vault
+// through them, without a proposal.
+package spendinglimit
+
+import (
+	"time"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+)
+
+// PeriodSeconds returns the length of a reset period as the program counts
+// it: a month is 30 days. OneTime limits never reset and return 0.
+func PeriodSeconds(period squads_multisig_program.Period) int64 {
+	switch period {
+	case squads_multisig_program.PeriodDay:
+		return 24 * 60 * 60
+	case squads_multisig_program.PeriodWeek:
+		return 7 * 24 * 60 * 60
+	case squads_multisig_program.PeriodMonth:
+		return 30 * 24 * 60 * 60
+	default:
+		return 0
+	}
+}
+
+// current returns the remaining amount and last reset the program would
+// see at now: once more than a full period has passed since the last
+// reset, the remaining amount is refilled and the reset time advanced by
+// whole periods.
+func current(spendingLimit *squads_multisig_program.SpendingLimit, now time.Time) (uint64, int64) {
+	period := PeriodSeconds(spendingLimit.Period)
+	if period == 0 {
+		return spendingLimit.RemainingAmount, spendingLimit.LastReset
+	}
+	passed := now.Unix() - spendingLimit.LastReset
+	if passed <= period {
+		return spendingLimit.RemainingAmount, spendingLimit.LastReset
+	}
+	return spendingLimit.Amount, spendingLimit.LastReset + passed/period*period
+}
+
+// Available returns how much can be spent through the spending limit at now,
+// taking a reset that is due but not yet recorded on chain into account.
+func Available(spendingLimit *squads_multisig_program.SpendingLimit, now time.Time) uint64 {
+	remaining, _ := current(spendingLimit, now)
+	return remaining
+}
+
+// NextReset returns when the remaining amount is next refilled. The program
+// refills it on the first use after a full period has passed. OneTime
+// limits never reset and return false.
+func NextReset(spendingLimit *squads_multisig_program.SpendingLimit, now time.Time) (time.Time, bool) {
+	period := PeriodSeconds(spendingLimit.Period)
+	if period == 0 {
+		return time.Time{}, false
+	}
+	_, lastReset := current(spendingLimit, now)
+	return time.Unix(lastReset+period+1, 0), true
+}
@@ -0,0 +1,54 @@
+package spendinglimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+)
+
+func TestAvailableAndNextReset(t *testing.T) {
+	const day = 24 * 60 * 60
+	lastReset := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	daily := &squads_multisig_program.SpendingLimit{
+		Amount:          100,
+		Period:          squads_multisig_program.PeriodDay,
+		RemainingAmount: 30,
+		LastReset:       lastReset,
+	}
+
+	tests := []struct {
+		name          string
+		now           int64
+		wantAvailable uint64
+		wantNextReset int64
+	}{
+		{"within the period", lastReset + day/2, 30, lastReset + day + 1},
+		{"exactly one period", lastReset + day, 30, lastReset + day + 1},
+		{"just past the period", lastReset + day + 1, 100, lastReset + 2*day + 1},
+		{"several periods later", lastReset + 3*day + 5, 100, lastReset + 4*day + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(tt.now, 0)
+			require.Equal(t, tt.wantAvailable, Available(daily, now))
+			next, ok := NextReset(daily, now)
+			require.True(t, ok)
+			require.Equal(t, tt.wantNextReset, next.Unix())
+		})
+	}
+
+	oneTime := &squads_multisig_program.SpendingLimit{
+		Amount:          100,
+		Period:          squads_multisig_program.PeriodOneTime,
+		RemainingAmount: 30,
+		LastReset:       lastReset,
+	}
+	require.EqualValues(t, 30, Available(oneTime, time.Unix(lastReset+365*day, 0)))
+	_, ok := NextReset(oneTime, time.Unix(lastReset, 0))
+	require.False(t, ok)
+
+	require.EqualValues(t, 30*day, PeriodSeconds(squads_multisig_program.PeriodMonth))
+}
@@ -0,0 +1,224 @@
+package spendinglimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+	"github.com/hogyzen12/squads-go/pkg/token"
+)
+
+// UseInput defines input parameters for spending from a vault through a
+// spending limit
+type UseInput struct {
+	// Required inputs
+	Multisig      solana.PublicKey
+	SpendingLimit solana.PublicKey
+	Member        signer.Signer    // a member listed on the spending limit
+	Destination   solana.PublicKey // wallet receiving the funds, not its token account
+	Amount        uint64           // in base units of the limit's mint (lamports for SOL)
+
+	// Optional inputs
+	Memo      string
+	ProgramID solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
+// UseOutput defines return values from spending through a spending limit
+type UseOutput struct {
+	Signature               solana.Signature
+	VaultPDA                solana.PublicKey
+	Mint                    solana.PublicKey // zero for SOL
+	Decimals                uint8
+	DestinationTokenAccount solana.PublicKey // zero for SOL
+	RemainingAmount         uint64           // expected to remain after the transfer
+}
+
+// Use transfers SOL or SPL tokens from the limit's vault to the destination
+// without a proposal. The member, destination and amount are checked against
+// the spending limit before sending. For tokens, the destination's
+// associated token account is created if it does not exist yet.
+func Use(ctx context.Context, input UseInput) (*UseOutput, error) {
+	instructions, output, err := buildUse(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := input.SendAndConfirm(ctx, instructions, input.Member)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	output.Signature = result.Signature
+	return output, nil
+}
+
+// PrepareUse builds the spending limit transaction without submitting it.
+// Signatures from offline signers are left empty; see sender.Options.Prepare.
+func PrepareUse(ctx context.Context, input UseInput) (*solana.Transaction, *UseOutput, error) {
+	instructions, output, err := buildUse(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := input.Prepare(ctx, instructions, input.Member)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, output, nil
+}
+
+// buildUse checks the transfer against the spending limit and returns the
+// spending limit use instruction, preceded by the destination token account
+// creation for SPL tokens.
+func buildUse(ctx context.Context, input UseInput) ([]solana.Instruction, *UseOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if input.Member == nil {
+		return nil, nil, errors.New("member signer is required")
+	}
+	if input.Destination.IsZero() {
+		return nil, nil, errors.New("destination is required")
+	}
+	if input.Amount == 0 {
+		return nil, nil, errors.New("amount must be greater than zero")
+	}
+
+	member := input.Member.PublicKey()
+	now := time.Now()
+	spendingLimit, err := accounts.FetchSpendingLimit(ctx, input.Options, input.SpendingLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch spending limit: %w", err)
+	}
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+	}
+	if err := Check(spendingLimit, input.Multisig, member, input.Destination, input.Amount, now); err != nil {
+		return nil, nil, err
+	}
+	if !isMember(multisigAccount, member) {
+		return nil, nil, fmt.Errorf("%s is no longer a member of this multisig", member)
+	}
+
+	vaultPDA, _, err := pda.Vault(input.Multisig, spendingLimit.VaultIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	output := &UseOutput{
+		VaultPDA:        vaultPDA,
+		Decimals:        token.SOLDecimals,
+		RemainingAmount: Available(spendingLimit, now) - input.Amount,
+	}
+
+	// Anchor expects the program ID in place of optional accounts that are absent.
+	programID := pda.ProgramIDOrDefault(input.ProgramID)
+	mint, vaultTokenAccount, destinationTokenAccount, tokenProgram := programID, programID, programID, programID
+
+	var instructions []solana.Instruction
+	if !spendingLimit.Mint.IsZero() {
+		mintInfo, err := token.FetchMint(ctx, input.Options, spendingLimit.Mint)
+		if err != nil {
+			return nil, nil, err
+		}
+		mint, tokenProgram = mintInfo.Address, mintInfo.Program
+		if vaultTokenAccount, err = token.AssociatedAddress(vaultPDA, mint, tokenProgram); err != nil {
+			return nil, nil, err
+		}
+		if destinationTokenAccount, err = token.AssociatedAddress(input.Destination, mint, tokenProgram); err != nil {
+			return nil, nil, err
+		}
+		createIx, err := token.NewCreateAssociatedAccountIdempotentInstruction(
+			input.Payer(input.Member).PublicKey(), input.Destination, mint, tokenProgram)
+		if err != nil {
+			return nil, nil, err
+		}
+		instructions = append(instructions, createIx)
+
+		output.Mint = mint
+		output.Decimals = mintInfo.Decimals
+		output.DestinationTokenAccount = destinationTokenAccount
+	}
+
+	useArgs := squads_multisig_program.SpendingLimitUseArgs{
+		Amount:   input.Amount,
+		Decimals: output.Decimals,
+	}
+	if input.Memo != "" {
+		useArgs.Memo = &input.Memo
+	}
+	useIx, err := multisig.WithProgramID(squads_multisig_program.NewSpendingLimitUseInstruction(
+		useArgs,
+		input.Multisig,
+		member,
+		input.SpendingLimit,
+		vaultPDA,
+		input.Destination,
+		solana.SystemProgramID,
+		mint,
+		vaultTokenAccount,
+		destinationTokenAccount,
+		tokenProgram,
+	).Build(), input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	instructions = append(instructions, useIx)
+
+	log.Printf("Spending %d base units from vault %s to %s through spending limit %s",
+		input.Amount, vaultPDA, input.Destination, input.SpendingLimit)
+
+	return instructions, output, nil
+}
+
+// Check verifies that member may send amount to destination through the
+// spending limit at now, mirroring the program's own checks.
+func Check(
+	spendingLimit *squads_multisig_program.SpendingLimit,
+	multisigPDA, member, destination solana.PublicKey,
+	amount uint64,
+	now time.Time,
+) error {
+	if !spendingLimit.Multisig.Equals(multisigPDA) {
+		return fmt.Errorf("spending limit belongs to multisig %s, not %s", spendingLimit.Multisig, multisigPDA)
+	}
+	if !containsKey(spendingLimit.Members, member) {
+		return fmt.Errorf("%s is not allowed to use this spending limit", member)
+	}
+	if len(spendingLimit.Destinations) > 0 && !containsKey(spendingLimit.Destinations, destination) {
+		return fmt.Errorf("%s is not an allowed destination of this spending limit", destination)
+	}
+	if available := Available(spendingLimit, now); amount > available {
+		return fmt.Errorf("amount %d exceeds the %d remaining on this spending limit", amount, available)
+	}
+	return nil
+}
+
+func containsKey(keys []solana.PublicKey, key solana.PublicKey) bool {
+	for _, k := range keys {
+		if k.Equals(key) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMember(multisigAccount *squads_multisig_program.Multisig, key solana.PublicKey) bool {
+	for _, member := range multisigAccount.Members {
+		if member.Key.Equals(key) {
+			return true
+		}
+	}
+	return false
+}
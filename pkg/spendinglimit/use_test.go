@@ -0,0 +1,134 @@
+package spendinglimit
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/internal/rpctest"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+	"github.com/hogyzen12/squads-go/pkg/token"
+)
+
+func TestCheck(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	alice, bob := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	treasury := solana.NewWallet().PublicKey()
+	now := time.Now()
+	spendingLimit := &squads_multisig_program.SpendingLimit{
+		Multisig:        multisigPDA,
+		Amount:          100,
+		Period:          squads_multisig_program.PeriodDay,
+		RemainingAmount: 40,
+		LastReset:       now.Unix() - 60,
+		Members:         []solana.PublicKey{alice},
+		Destinations:    []solana.PublicKey{treasury},
+	}
+
+	tests := []struct {
+		name        string
+		multisig    solana.PublicKey
+		member      solana.PublicKey
+		destination solana.PublicKey
+		amount      uint64
+		wantErr     string
+	}{
+		{"allowed", multisigPDA, alice, treasury, 40, ""},
+		{"other multisig", solana.NewWallet().PublicKey(), alice, treasury, 1, "belongs to multisig"},
+		{"not a listed member", multisigPDA, bob, treasury, 1, "not allowed to use"},
+		{"destination not allowed", multisigPDA, alice, bob, 1, "not an allowed destination"},
+		{"over the remaining amount", multisigPDA, alice, treasury, 41, "exceeds the 40 remaining"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(spendingLimit, tt.multisig, tt.member, tt.destination, tt.amount, now)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	spendingLimit.Destinations = nil
+	require.NoError(t, Check(spendingLimit, multisigPDA, alice, bob, 1, now), "no destinations allows any")
+}
+
+func TestBuildUse(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	member := solana.NewWallet()
+	destination := solana.NewWallet().PublicKey()
+	solLimit, tokenLimit := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	mint := solana.NewWallet().PublicKey()
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		Threshold: 1,
+		Members:   []squads_multisig_program.Member{{Key: member.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}}},
+	})
+	server.Add(t, solLimit, &squads_multisig_program.SpendingLimit{
+		Multisig: multisigPDA, VaultIndex: 1, Amount: 1_000, RemainingAmount: 1_000,
+		Period: squads_multisig_program.PeriodOneTime, Members: []solana.PublicKey{member.PublicKey()},
+	})
+	server.Add(t, tokenLimit, &squads_multisig_program.SpendingLimit{
+		Multisig: multisigPDA, Mint: mint, Amount: 1_000, RemainingAmount: 1_000,
+		Period: squads_multisig_program.PeriodOneTime, Members: []solana.PublicKey{member.PublicKey()},
+	})
+	mintData := make([]byte, 82)
+	mintData[44] = 6
+	server.SetAccount(mint, rpctest.Account{Owner: solana.Token2022ProgramID, Data: mintData})
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	input := UseInput{
+		Multisig:      multisigPDA,
+		SpendingLimit: solLimit,
+		Member:        signer.NewLocal(member.PrivateKey),
+		Destination:   destination,
+		Amount:        400,
+		Options:       sender.Options{Client: rpc.New(httpServer.URL)},
+	}
+
+	instructions, output, err := buildUse(context.Background(), input)
+	require.NoError(t, err)
+	require.Len(t, instructions, 1)
+	vaultPDA, _, err := pda.Vault(multisigPDA, 1, solana.PublicKey{})
+	require.NoError(t, err)
+	require.Equal(t, vaultPDA, output.VaultPDA)
+	require.EqualValues(t, token.SOLDecimals, output.Decimals)
+	require.EqualValues(t, 600, output.RemainingAmount)
+	accounts := instructions[0].Accounts()
+	require.Equal(t, destination, accounts[4].PublicKey)
+	for _, optional := range accounts[6:] {
+		require.Equal(t, multisig.DefaultProgramID, optional.PublicKey, "absent token accounts are the program ID")
+	}
+
+	input.SpendingLimit = tokenLimit
+	instructions, output, err = buildUse(context.Background(), input)
+	require.NoError(t, err)
+	require.Len(t, instructions, 2)
+	require.Equal(t, solana.SPLAssociatedTokenAccountProgramID, instructions[0].ProgramID())
+	require.EqualValues(t, 6, output.Decimals)
+	wantDestination, err := token.AssociatedAddress(destination, mint, solana.Token2022ProgramID)
+	require.NoError(t, err)
+	require.Equal(t, wantDestination, output.DestinationTokenAccount)
+	accounts = instructions[1].Accounts()
+	require.Equal(t, mint, accounts[6].PublicKey)
+	require.Equal(t, wantDestination, accounts[8].PublicKey)
+	require.Equal(t, solana.Token2022ProgramID, accounts[9].PublicKey)
+
+	input.Amount = 1_001
+	_, _, err = buildUse(context.Background(), input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeds")
+}
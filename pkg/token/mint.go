@@ -0,0 +1,58 @@
+package token
+
+import (
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+)
+
+// MintSize is the size of a mint account without Token-2022 extensions.
+const MintSize = mintSize
+
+// initializeMint2Index is the InitializeMint2 instruction of both token
+// programs.
+const initializeMint2Index = 20
+
+// NewInitializeMint2Instruction initializes an allocated mint account. A nil
+// freezeAuthority leaves the mint without one.
+func NewInitializeMint2Instruction(
+	mint solana.PublicKey,
+	decimals uint8,
+	mintAuthority solana.PublicKey,
+	freezeAuthority *solana.PublicKey,
+	tokenProgram solana.PublicKey,
+) solana.Instruction {
+	data := make([]byte, 0, 67)
+	data = append(data, initializeMint2Index, decimals)
+	data = append(data, mintAuthority[:]...)
+	if freezeAuthority != nil {
+		data = append(data, 1)
+		data = append(data, freezeAuthority[:]...)
+	} else {
+		data = append(data, 0)
+	}
+
+	return solana.NewInstruction(
+		tokenProgram,
+		solana.AccountMetaSlice{
+			solana.NewAccountMeta(mint, true, false),
+		},
+		data,
+	)
+}
+
+// CreateMintInstructions returns the instructions creating a new mint at the
+// mint address, which must sign, funded with rentLamports by payer.
+// rentLamports should be the rent exemption of MintSize bytes.
+func CreateMintInstructions(
+	payer, mint solana.PublicKey,
+	decimals uint8,
+	mintAuthority solana.PublicKey,
+	freezeAuthority *solana.PublicKey,
+	rentLamports uint64,
+	tokenProgram solana.PublicKey,
+) []solana.Instruction {
+	return []solana.Instruction{
+		system.NewCreateAccountInstruction(rentLamports, MintSize, tokenProgram, payer, mint).Build(),
+		NewInitializeMint2Instruction(mint, decimals, mintAuthority, freezeAuthority, tokenProgram),
+	}
+}
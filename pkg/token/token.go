@@ -0,0 +1,131 @@
+// Package token holds the SPL Token and Token-2022 helpers the Squads
+// operations need: mint lookups, associated token accounts and amounts in
+// a mint's decimals.
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+// SOLDecimals is the number of decimals of native SOL amounts (lamports).
+const SOLDecimals = 9
+
+// mintSize is the size of a base SPL mint; Token-2022 mints with
+// extensions are longer but share the same layout up front.
+const (
+	mintSize           = 82
+	mintDecimalsOffset = 44
+)
+
+// Mint describes a token mint and the token program that owns it.
+type Mint struct {
+	Address  solana.PublicKey
+	Program  solana.PublicKey // solana.TokenProgramID or solana.Token2022ProgramID
+	Decimals uint8
+}
+
+// IsTokenProgram reports whether programID is the SPL Token or the
+// Token-2022 program.
+func IsTokenProgram(programID solana.PublicKey) bool {
+	return programID.Equals(solana.TokenProgramID) || programID.Equals(solana.Token2022ProgramID)
+}
+
+// FetchMint loads a mint account owned by either token program.
+func FetchMint(ctx context.Context, opts sender.Options, address solana.PublicKey) (*Mint, error) {
+	accountInfo, err := opts.GetAccountInfo(ctx, address)
+	if errors.Is(err, rpc.ErrNotFound) || (err == nil && (accountInfo == nil || accountInfo.Value == nil)) {
+		return nil, fmt.Errorf("mint %s not found", address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mint %s: %w", address, err)
+	}
+	if !IsTokenProgram(accountInfo.Value.Owner) {
+		return nil, fmt.Errorf("account %s is owned by %s, not a token program", address, accountInfo.Value.Owner)
+	}
+	data := accountInfo.Value.Data.GetBinary()
+	if len(data) < mintSize {
+		return nil, fmt.Errorf("account %s is not a mint: %d bytes", address, len(data))
+	}
+	return &Mint{
+		Address:  address,
+		Program:  accountInfo.Value.Owner,
+		Decimals: data[mintDecimalsOffset],
+	}, nil
+}
+
+// AssociatedAddress derives the associated token account of owner for mint
+// under the given token program.
+func AssociatedAddress(owner, mint, tokenProgram solana.PublicKey) (solana.PublicKey, error) {
+	address, _, err := solana.FindProgramAddress(
+		[][]byte{owner[:], tokenProgram[:], mint[:]},
+		solana.SPLAssociatedTokenAccountProgramID,
+	)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to derive associated token account: %w", err)
+	}
+	return address, nil
+}
+
+// NewCreateAssociatedAccountIdempotentInstruction creates the associated
+// token account of owner for mint, or does nothing if it already exists.
+func NewCreateAssociatedAccountIdempotentInstruction(payer, owner, mint, tokenProgram solana.PublicKey) (solana.Instruction, error) {
+	address, err := AssociatedAddress(owner, mint, tokenProgram)
+	if err != nil {
+		return nil, err
+	}
+	return solana.NewInstruction(
+		solana.SPLAssociatedTokenAccountProgramID,
+		solana.AccountMetaSlice{
+			solana.NewAccountMeta(payer, true, true),
+			solana.NewAccountMeta(address, true, false),
+			solana.NewAccountMeta(owner, false, false),
+			solana.NewAccountMeta(mint, false, false),
+			solana.NewAccountMeta(solana.SystemProgramID, false, false),
+			solana.NewAccountMeta(tokenProgram, false, false),
+		},
+		[]byte{1}, // CreateIdempotent
+	), nil
+}
+
+// ParseAmount converts a decimal amount such as "1.5" into base units of a
+// mint with the given decimals. More fractional digits than the mint
+// supports is an error rather than a silent rounding.
+func ParseAmount(amount string, decimals uint8) (uint64, error) {
+	amount = strings.TrimSpace(amount)
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+	if len(fraction) > int(decimals) {
+		return 0, fmt.Errorf("amount %q has more than %d decimal places", amount, decimals)
+	}
+	digits := whole + fraction + strings.Repeat("0", int(decimals)-len(fraction))
+	if strings.TrimLeft(digits, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid amount %q", amount)
+	}
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok || !value.IsUint64() {
+		return 0, fmt.Errorf("amount %q is out of range", amount)
+	}
+	return value.Uint64(), nil
+}
+
+// FormatAmount renders base units as a decimal amount, without trailing
+// zeros.
+func FormatAmount(amount uint64, decimals uint8) string {
+	digits := fmt.Sprintf("%0*d", int(decimals)+1, amount)
+	whole, fraction := digits[:len(digits)-int(decimals)], strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+	if fraction == "" {
+		return whole
+	}
+	return whole + "." + fraction
+}
@@ -0,0 +1,127 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		amount   string
+		decimals uint8
+		want     uint64
+		wantErr  string
+	}{
+		{"1.5", 9, 1_500_000_000, ""},
+		{"0.000001", 6, 1, ""},
+		{"42", 0, 42, ""},
+		{".25", 2, 25, ""},
+		{"1.", 2, 100, ""},
+		{"0.0000001", 6, 0, "decimal places"},
+		{"", 6, 0, "invalid"},
+		{"-1", 6, 0, "invalid"},
+		{"1e3", 6, 0, "invalid"},
+		{"18446744073709551616", 0, 0, "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			got, err := ParseAmount(tt.amount, tt.decimals)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, got)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	require.Equal(t, "1.5", FormatAmount(1_500_000_000, 9))
+	require.Equal(t, "0.000001", FormatAmount(1, 6))
+	require.Equal(t, "0", FormatAmount(0, 6))
+	require.Equal(t, "42", FormatAmount(42, 0))
+	require.Equal(t, "100", FormatAmount(100_000_000, 6))
+}
+
+func TestAssociatedAddress(t *testing.T) {
+	// For the Token program the result must match solana-go's own derivation.
+	owner := solana.MustPublicKeyFromBase58("9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM")
+	want, _, err := solana.FindAssociatedTokenAddress(owner, solana.WrappedSol)
+	require.NoError(t, err)
+
+	got, err := AssociatedAddress(owner, solana.WrappedSol, solana.TokenProgramID)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+
+	token2022, err := AssociatedAddress(owner, solana.WrappedSol, solana.Token2022ProgramID)
+	require.NoError(t, err)
+	require.NotEqual(t, got, token2022)
+}
+
+func TestTransferInstructions(t *testing.T) {
+	mint := &Mint{Address: solana.NewWallet().PublicKey(), Program: solana.Token2022ProgramID, Decimals: 6}
+	vault, recipient := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	instructions, err := TransferInstructions(mint, vault, recipient, 1_500_000, true)
+	require.NoError(t, err)
+	require.Len(t, instructions, 2)
+
+	create := instructions[0]
+	require.Equal(t, solana.SPLAssociatedTokenAccountProgramID, create.ProgramID())
+	createData, err := create.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, createData)
+	require.Equal(t, vault, create.Accounts()[0].PublicKey, "the vault pays the rent")
+	require.Equal(t, solana.Token2022ProgramID, create.Accounts()[5].PublicKey)
+
+	source, err := AssociatedAddress(vault, mint.Address, mint.Program)
+	require.NoError(t, err)
+	destination, err := AssociatedAddress(recipient, mint.Address, mint.Program)
+	require.NoError(t, err)
+
+	transfer := instructions[1]
+	require.Equal(t, solana.Token2022ProgramID, transfer.ProgramID())
+	data, err := transfer.Data()
+	require.NoError(t, err)
+	require.Equal(t, []byte{12, 0x60, 0xe3, 0x16, 0, 0, 0, 0, 0, 6}, data)
+	accounts := transfer.Accounts()
+	require.Equal(t, source, accounts[0].PublicKey)
+	require.Equal(t, mint.Address, accounts[1].PublicKey)
+	require.Equal(t, destination, accounts[2].PublicKey)
+	require.Equal(t, vault, accounts[3].PublicKey)
+	require.True(t, accounts[3].IsSigner)
+
+	instructions, err = TransferInstructions(mint, vault, recipient, 1, false)
+	require.NoError(t, err)
+	require.Len(t, instructions, 1)
+}
+
+func TestCreateMintInstructions(t *testing.T) {
+	payer, mint, authority := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	instructions := CreateMintInstructions(payer, mint, 6, authority, nil, 1_461_600, solana.Token2022ProgramID)
+	require.Len(t, instructions, 2)
+
+	create := instructions[0]
+	require.Equal(t, solana.SystemProgramID, create.ProgramID())
+	require.Equal(t, payer, create.Accounts()[0].PublicKey)
+	require.Equal(t, mint, create.Accounts()[1].PublicKey)
+	require.True(t, create.Accounts()[1].IsSigner, "the new mint signs its creation")
+
+	initialize := instructions[1]
+	require.Equal(t, solana.Token2022ProgramID, initialize.ProgramID())
+	data, err := initialize.Data()
+	require.NoError(t, err)
+	require.Equal(t, append(append([]byte{20, 6}, authority[:]...), 0), data)
+
+	freeze := solana.NewWallet().PublicKey()
+	data, err = NewInitializeMint2Instruction(mint, 0, authority, &freeze, solana.TokenProgramID).Data()
+	require.NoError(t, err)
+	require.Len(t, data, 67)
+	require.Equal(t, byte(1), data[34])
+	require.Equal(t, freeze[:], data[35:])
+}
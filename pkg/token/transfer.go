@@ -0,0 +1,64 @@
+package token
+
+import (
+	"encoding/binary"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// transferCheckedIndex is the TransferChecked instruction of both token
+// programs.
+const transferCheckedIndex = 12
+
+// NewTransferCheckedInstruction moves amount base units of mint between two
+// token accounts. The program rejects it unless decimals match the mint,
+// which guards against amounts off by orders of magnitude.
+func NewTransferCheckedInstruction(
+	source, mint, destination, owner solana.PublicKey,
+	amount uint64,
+	decimals uint8,
+	tokenProgram solana.PublicKey,
+) solana.Instruction {
+	data := make([]byte, 10)
+	data[0] = transferCheckedIndex
+	binary.LittleEndian.PutUint64(data[1:9], amount)
+	data[9] = decimals
+
+	return solana.NewInstruction(
+		tokenProgram,
+		solana.AccountMetaSlice{
+			solana.NewAccountMeta(source, true, false),
+			solana.NewAccountMeta(mint, false, false),
+			solana.NewAccountMeta(destination, true, false),
+			solana.NewAccountMeta(owner, false, true),
+		},
+		data,
+	)
+}
+
+// TransferInstructions returns the instructions sending amount base units of
+// mint from owner's associated token account to recipient's. With
+// createRecipientAccount, the recipient's account is created first if it
+// does not exist, paid for by owner; a vault must then hold enough SOL for
+// the rent.
+func TransferInstructions(mint *Mint, owner, recipient solana.PublicKey, amount uint64, createRecipientAccount bool) ([]solana.Instruction, error) {
+	source, err := AssociatedAddress(owner, mint.Address, mint.Program)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := AssociatedAddress(recipient, mint.Address, mint.Program)
+	if err != nil {
+		return nil, err
+	}
+
+	var instructions []solana.Instruction
+	if createRecipientAccount {
+		createIx, err := NewCreateAssociatedAccountIdempotentInstruction(owner, recipient, mint.Address, mint.Program)
+		if err != nil {
+			return nil, err
+		}
+		instructions = append(instructions, createIx)
+	}
+	return append(instructions, NewTransferCheckedInstruction(
+		source, mint.Address, destination, owner, amount, mint.Decimals, mint.Program)), nil
+}
@@ -0,0 +1,123 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// ProposalActivateInput defines input parameters for activating a draft
+// proposal
+type ProposalActivateInput struct {
+	// Required inputs
+	Multisig         solana.PublicKey
+	TransactionIndex uint64
+	Member           signer.Signer // needs propose permission
+
+	// Optional inputs
+	ProgramID solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
+// ProposalActivateOutput defines return values from activating a draft
+// proposal
+type ProposalActivateOutput struct {
+	Signature   solana.Signature
+	ProposalPDA solana.PublicKey
+	Threshold   uint16
+}
+
+// ActivateProposal opens a draft proposal for voting. Drafts let the creator
+// finish the transaction, such as adding the transactions of a batch, before
+// members can vote on it.
+func ActivateProposal(ctx context.Context, input ProposalActivateInput) (*ProposalActivateOutput, error) {
+	instructions, output, err := buildActivateProposal(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := input.SendAndConfirm(ctx, instructions, input.Member)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	output.Signature = result.Signature
+	return output, nil
+}
+
+// PrepareActivateProposal builds the activation transaction without
+// submitting it. Signatures from offline signers are left empty; see
+// sender.Options.Prepare.
+func PrepareActivateProposal(ctx context.Context, input ProposalActivateInput) (*solana.Transaction, *ProposalActivateOutput, error) {
+	instructions, output, err := buildActivateProposal(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := input.Prepare(ctx, instructions, input.Member)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, output, nil
+}
+
+// buildActivateProposal checks that the proposal is a draft that is not
+// stale and that the member may activate it, and returns the activate
+// instruction.
+func buildActivateProposal(ctx context.Context, input ProposalActivateInput) ([]solana.Instruction, *ProposalActivateOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if input.Member == nil {
+		return nil, nil, errors.New("member signer is required")
+	}
+	member := input.Member.PublicKey()
+
+	proposalPDA, _, err := pda.Proposal(input.Multisig, input.TransactionIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+	}
+	proposal, err := accounts.FetchProposal(ctx, input.Options, proposalPDA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch proposal: %w", err)
+	}
+	if state := StateOf(proposal.Status); state != StateDraft {
+		return nil, nil, fmt.Errorf("proposal %d is not a draft, current status: %s",
+			input.TransactionIndex, DescribeProposalStatus(proposal.Status))
+	}
+	if input.TransactionIndex <= multisigAccount.StaleTransactionIndex {
+		return nil, nil, fmt.Errorf("proposal %d is stale: the multisig's config changed after it was created", input.TransactionIndex)
+	}
+	if !hasPermission(multisigAccount, member, multisig.PermissionPropose) {
+		return nil, nil, fmt.Errorf("%s is not a member of this multisig or doesn't have proposal permission", member)
+	}
+
+	activateIx, err := multisig.WithProgramID(squads_multisig_program.NewProposalActivateInstruction(
+		input.Multisig,
+		member,
+		proposalPDA,
+	).Build(), input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Printf("Activating proposal for transaction %d on multisig %s", input.TransactionIndex, input.Multisig)
+
+	return []solana.Instruction{activateIx}, &ProposalActivateOutput{
+		ProposalPDA: proposalPDA,
+		Threshold:   multisigAccount.Threshold,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/internal/rpctest"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+func TestDraftProposals(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	proposer := signer.NewOffline(solana.NewWallet().PublicKey())
+	voter := signer.NewOffline(solana.NewWallet().PublicKey())
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		Threshold:             2,
+		TransactionIndex:      3,
+		StaleTransactionIndex: 1,
+		Members: []squads_multisig_program.Member{
+			{Key: proposer.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}},
+			{Key: voter.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionVote}},
+		},
+	})
+	statuses := map[uint64]squads_multisig_program.ProposalStatus{
+		1: &squads_multisig_program.ProposalStatusDraft{}, // stale
+		2: &squads_multisig_program.ProposalStatusDraft{},
+		3: &squads_multisig_program.ProposalStatusActive{},
+	}
+	for index, status := range statuses {
+		proposalPDA, _, err := pda.Proposal(multisigPDA, index, solana.PublicKey{})
+		require.NoError(t, err)
+		server.Add(t, proposalPDA, &squads_multisig_program.Proposal{Multisig: multisigPDA, TransactionIndex: index, Status: status})
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	options := sender.Options{Client: rpc.New(httpServer.URL)}
+
+	activate := func(index uint64, member signer.Signer) ([]solana.Instruction, error) {
+		instructions, _, err := buildActivateProposal(context.Background(), ProposalActivateInput{
+			Multisig:         multisigPDA,
+			TransactionIndex: index,
+			Member:           member,
+			Options:          options,
+		})
+		return instructions, err
+	}
+
+	instructions, err := activate(2, proposer)
+	require.NoError(t, err)
+	require.Len(t, instructions, 1)
+	data, err := instructions[0].Data()
+	require.NoError(t, err)
+	require.Equal(t, squads_multisig_program.Instruction_ProposalActivate[:], data[:8])
+
+	_, err = activate(3, proposer)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not a draft")
+
+	_, err = activate(1, proposer)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stale")
+
+	_, err = activate(2, voter)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "proposal permission")
+
+	for _, action := range []string{"approve", "reject", "cancel"} {
+		_, _, err = buildVote(context.Background(), ProposalVoteInput{
+			Multisig:         multisigPDA,
+			TransactionIndex: 2,
+			Voter:            voter,
+			Action:           action,
+			Options:          options,
+		})
+		require.True(t, errors.Is(err, ErrProposalDraft), "%s on a draft: %v", action, err)
+	}
+
+	_, _, err = buildVote(context.Background(), ProposalVoteInput{
+		Multisig:         multisigPDA,
+		TransactionIndex: 3,
+		Voter:            voter,
+		Options:          options,
+	})
+	require.NoError(t, err)
+}
@@ -2,30 +2,38 @@ package transaction
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/gagliardetto/solana-go"
-	"github.com/gagliardetto/solana-go/rpc"
-	"github.com/gagliardetto/solana-go/rpc/ws"
 
 	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
 	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
 )
 
+// ErrProposalDraft is returned when voting on a proposal that is still a
+// draft; see ActivateProposal.
+var ErrProposalDraft = errors.New("proposal is a draft")
+
 // ProposalVoteInput defines input parameters for voting on a proposal
 type ProposalVoteInput struct {
 	// Required inputs
 	Multisig         solana.PublicKey
 	TransactionIndex uint64
-	Voter            solana.PrivateKey
+	Voter            signer.Signer
 
 	// Optional inputs
-	Memo     string
-	Action   string // "approve", "reject", or "cancel"
-	Client   *rpc.Client
-	WsClient *ws.Client
+	Memo      string
+	Action    string           // "approve", "reject", or "cancel"
+	ProgramID solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
 }
 
 // ProposalVoteOutput defines return values from voting on a proposal
@@ -34,18 +42,71 @@ type ProposalVoteOutput struct {
 	ProposalPDA   solana.PublicKey
 	Action        string
 	CurrentStatus string
-	Approvals     int
+	Approvals     int // vote counts including this vote
 	Rejections    int
 	Cancelled     int
-	Threshold     uint16
+	Threshold     uint16 // approvals or cancellations that settle the proposal
+	Cutoff        int    // rejections that settle the proposal; see RejectionCutoff
+	TimeLock      uint32
+
+	// Tipped is true when this vote moved the proposal to Approved, Rejected
+	// or Cancelled.
+	Tipped bool
 
 	// If approved and at threshold, shows when execution is possible
 	ExecutableAfter *time.Time
 }
 
 // VoteOnProposal votes on a proposal with the specified action (approve, reject, or cancel)
-// This version submits the transaction but doesn't wait for confirmation
+// and waits until the vote lands; see sender.Options.SendAndConfirm.
 func VoteOnProposal(ctx context.Context, input ProposalVoteInput) (*ProposalVoteOutput, error) {
+	instructions, output, err := buildVote(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := input.SendAndConfirm(ctx, instructions, input.Voter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send voting transaction: %w", err)
+	}
+	output.Signature = result.Signature.String()
+
+	log.Printf("✓ %s transaction landed: %s", output.Action, result.Signature)
+
+	// Report the status the vote left the proposal in; the counts worked out
+	// beforehand stand if it cannot be read back.
+	proposal, err := accounts.FetchProposal(ctx, input.Options, output.ProposalPDA)
+	if err != nil {
+		log.Printf("Could not read the proposal back after voting: %v", err)
+		return output, nil
+	}
+	output.CurrentStatus = DescribeProposalStatus(proposal.Status)
+	output.Approvals, output.Rejections, output.Cancelled = len(proposal.Approved), len(proposal.Rejected), len(proposal.Cancelled)
+	output.Tipped = StateOf(proposal.Status) == voteOutcomes[output.Action]
+	if approved, ok := proposal.Status.(*squads_multisig_program.ProposalStatusApproved); ok && output.Action == "approve" {
+		executableAfter := time.Unix(approved.Timestamp, 0).Add(time.Duration(output.TimeLock) * time.Second)
+		output.ExecutableAfter = &executableAfter
+	}
+
+	return output, nil
+}
+
+// PrepareVote builds the voting transaction without submitting it. Signatures
+// from offline signers are left empty; see sender.Options.Prepare.
+func PrepareVote(ctx context.Context, input ProposalVoteInput) (*solana.Transaction, *ProposalVoteOutput, error) {
+	instructions, output, err := buildVote(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := input.Prepare(ctx, instructions, input.Voter)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, output, nil
+}
+
+// buildVote validates the vote and returns its instructions.
+func buildVote(ctx context.Context, input ProposalVoteInput) ([]solana.Instruction, *ProposalVoteOutput, error) {
 	// Validate action
 	action := input.Action
 	if action == "" {
@@ -53,54 +114,49 @@ func VoteOnProposal(ctx context.Context, input ProposalVoteInput) (*ProposalVote
 	}
 
 	if action != "approve" && action != "reject" && action != "cancel" {
-		return nil, fmt.Errorf("invalid action: %s. Must be 'approve', 'reject', or 'cancel'", action)
+		return nil, nil, fmt.Errorf("invalid action: %s. Must be 'approve', 'reject', or 'cancel'", action)
 	}
 
 	log.Printf("%sing proposal for transaction %d...", action, input.TransactionIndex)
 
-	// Create clients if not provided
-	if input.Client == nil {
-		// Use a default endpoint that works with our project instead of MainnetRPCEndpoint
-		input.Client = rpc.New("https://api.mainnet-beta.solana.com")
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
 	}
-
-	var wsClientCreated bool
-	if input.WsClient == nil {
-		// Use a default WS endpoint that works with our project
-		wsClient, err := ws.Connect(ctx, "wss://api.mainnet-beta.solana.com")
-		if err != nil {
-			return nil, fmt.Errorf("failed to connect to WebSocket: %w", err)
-		}
-		input.WsClient = wsClient
-		wsClientCreated = true
-		defer func() {
-			if wsClientCreated {
-				input.WsClient.Close()
-			}
-		}()
+	if input.Voter == nil {
+		return nil, nil, errors.New("voter signer is required")
 	}
 
 	// Calculate proposal PDA
-	proposalPDA, _ := multisig.GetProposalPDA(input.Multisig, input.TransactionIndex)
+	proposalPDA, _, err := pda.Proposal(input.Multisig, input.TransactionIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Validate that the multisig and proposal accounts exist
 	// Check if the multisig account exists
-	multisigInfo, err := input.Client.GetAccountInfo(ctx, input.Multisig)
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch multisig account: %w", err)
-	}
-	if multisigInfo.Value == nil || len(multisigInfo.Value.Data.GetBinary()) == 0 {
-		return nil, fmt.Errorf("multisig account not found or not initialized: %s", input.Multisig)
+		return nil, nil, fmt.Errorf("multisig account not found or not initialized: %w", err)
 	}
 
 	// Check if the proposal account exists
-	proposalInfo, err := input.Client.GetAccountInfo(ctx, proposalPDA)
+	proposal, err := accounts.FetchProposal(ctx, input.Options, proposalPDA)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch proposal account: %w", err)
+		return nil, nil, fmt.Errorf("proposal account not found or not initialized: %w", err)
+	}
+
+	// Drafts are not open for voting until activated
+	if StateOf(proposal.Status) == StateDraft {
+		return nil, nil, fmt.Errorf("%w: proposal %d must be activated before members can %s it",
+			ErrProposalDraft, input.TransactionIndex, action)
 	}
-	if proposalInfo.Value == nil || len(proposalInfo.Value.Data.GetBinary()) == 0 {
-		return nil, fmt.Errorf("proposal account not found or not initialized: %s", proposalPDA)
+
+	// Check the proposal's state and work out where this vote leaves it
+	output, err := tallyVote(multisigAccount, proposal, input.Voter.PublicKey(), action)
+	if err != nil {
+		return nil, nil, err
 	}
+	output.ProposalPDA = proposalPDA
 
 	// Build proposal vote arguments
 	proposalVoteArgs := squads_multisig_program.ProposalVoteArgs{}
@@ -137,33 +193,9 @@ func VoteOnProposal(ctx context.Context, input ProposalVoteInput) (*ProposalVote
 		).Build()
 	}
 
-	// Get latest blockhash
-	hash, err := input.Client.GetLatestBlockhash(ctx, rpc.CommitmentFinalized)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get latest blockhash: %w", err)
-	}
-
-	// Create transaction
-	tx, err := solana.NewTransaction(
-		[]solana.Instruction{votingIx},
-		hash.Value.Blockhash,
-		solana.TransactionPayer(input.Voter.PublicKey()),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create voting transaction: %w", err)
-	}
-
-	// Sign transaction
-	_, err = tx.Sign(
-		func(key solana.PublicKey) *solana.PrivateKey {
-			if key.Equals(input.Voter.PublicKey()) {
-				return &input.Voter
-			}
-			return nil
-		},
-	)
+	votingIx, err = multisig.WithProgramID(votingIx, input.ProgramID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		return nil, nil, err
 	}
 
 	// Log transaction details for debugging
@@ -171,22 +203,84 @@ func VoteOnProposal(ctx context.Context, input ProposalVoteInput) (*ProposalVote
 		action, input.TransactionIndex, input.Voter.PublicKey())
 	log.Printf("Proposal PDA: %s", proposalPDA)
 
-	// Submit transaction WITHOUT waiting for confirmation
-	// This will prevent the CLI from hanging
-	sig, err := input.Client.SendTransaction(ctx, tx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send voting transaction: %w", err)
-	}
+	return []solana.Instruction{votingIx}, output, nil
+}
 
-	// Create output with minimal information
-	output := &ProposalVoteOutput{
-		Signature:   sig.String(),
-		ProposalPDA: proposalPDA,
-		Action:      action,
+// voteOutcomes maps each vote action to the status it can move a proposal to.
+var voteOutcomes = map[string]ProposalState{
+	"approve": StateApproved,
+	"reject":  StateRejected,
+	"cancel":  StateCancelled,
+}
+
+// tallyVote checks that voter may cast action on the proposal: approvals and
+// rejections need an active proposal that is not stale, cancellations an
+// approved one that has not been executed. It returns the vote counts once the
+// vote lands and whether it settles the proposal. A member may change an
+// approval into a rejection or back, but not cast the same vote twice.
+func tallyVote(
+	multisigAccount *squads_multisig_program.Multisig,
+	proposal *squads_multisig_program.Proposal,
+	voter solana.PublicKey,
+	action string,
+) (*ProposalVoteOutput, error) {
+	if !hasPermission(multisigAccount, voter, multisig.PermissionVote) {
+		return nil, fmt.Errorf("%s is not a member of this multisig or doesn't have vote permission", voter)
 	}
 
-	log.Printf("✓ Successfully submitted %s transaction: %s", action, sig)
-	log.Printf("Transaction may take a few seconds to confirm.")
+	state := StateOf(proposal.Status)
+	switch action {
+	case "approve", "reject":
+		if state != StateActive {
+			return nil, fmt.Errorf("cannot %s proposal %d: only active proposals can be voted on, current status: %s",
+				action, proposal.TransactionIndex, DescribeProposalStatus(proposal.Status))
+		}
+		if proposal.TransactionIndex <= multisigAccount.StaleTransactionIndex {
+			return nil, fmt.Errorf("cannot %s proposal %d: it is stale, the multisig's config changed after it was created",
+				action, proposal.TransactionIndex)
+		}
+	case "cancel":
+		if state != StateApproved {
+			return nil, fmt.Errorf("cannot cancel proposal %d: only approved proposals that have not been executed can be cancelled, current status: %s",
+				proposal.TransactionIndex, DescribeProposalStatus(proposal.Status))
+		}
+	}
 
+	output := &ProposalVoteOutput{
+		Action:        action,
+		CurrentStatus: DescribeProposalStatus(proposal.Status),
+		Approvals:     len(proposal.Approved),
+		Rejections:    len(proposal.Rejected),
+		Cancelled:     len(proposal.Cancelled),
+		Threshold:     multisigAccount.Threshold,
+		Cutoff:        RejectionCutoff(multisigAccount),
+		TimeLock:      multisigAccount.TimeLock,
+	}
+	switch action {
+	case "approve":
+		if containsKey(proposal.Approved, voter) {
+			return nil, fmt.Errorf("%s has already approved proposal %d", voter, proposal.TransactionIndex)
+		}
+		if containsKey(proposal.Rejected, voter) {
+			output.Rejections--
+		}
+		output.Approvals++
+		output.Tipped = output.Approvals >= int(output.Threshold)
+	case "reject":
+		if containsKey(proposal.Rejected, voter) {
+			return nil, fmt.Errorf("%s has already rejected proposal %d", voter, proposal.TransactionIndex)
+		}
+		if containsKey(proposal.Approved, voter) {
+			output.Approvals--
+		}
+		output.Rejections++
+		output.Tipped = output.Rejections >= output.Cutoff
+	case "cancel":
+		if containsKey(proposal.Cancelled, voter) {
+			return nil, fmt.Errorf("%s has already cancelled proposal %d", voter, proposal.TransactionIndex)
+		}
+		output.Cancelled++
+		output.Tipped = output.Cancelled >= int(output.Threshold)
+	}
 	return output, nil
 }
@@ -0,0 +1,86 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+)
+
+func TestTallyVote(t *testing.T) {
+	voters := make([]solana.PublicKey, 5)
+	members := make([]squads_multisig_program.Member, 0, len(voters)+1)
+	for i := range voters {
+		voters[i] = solana.NewWallet().PublicKey()
+		members = append(members, squads_multisig_program.Member{
+			Key: voters[i], Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionVote},
+		})
+	}
+	proposerOnly := solana.NewWallet().PublicKey()
+	members = append(members, squads_multisig_program.Member{
+		Key: proposerOnly, Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionPropose},
+	})
+	// 5 voters and a threshold of 3: 3 rejections leave too few to approve.
+	multisigAccount := &squads_multisig_program.Multisig{Threshold: 3, Members: members, StaleTransactionIndex: 1}
+	require.Equal(t, 3, RejectionCutoff(multisigAccount))
+
+	active := &squads_multisig_program.ProposalStatusActive{}
+	approved := &squads_multisig_program.ProposalStatusApproved{}
+	tests := []struct {
+		name      string
+		status    squads_multisig_program.ProposalStatus
+		index     uint64
+		approvals []solana.PublicKey
+		rejected  []solana.PublicKey
+		cancelled []solana.PublicKey
+		voter     solana.PublicKey
+		action    string
+		wantErr   string
+		want      [3]int // approvals, rejections, cancellations
+		tipped    bool
+	}{
+		{name: "approve", status: active, approvals: voters[:1], voter: voters[1], action: "approve", want: [3]int{2, 0, 0}},
+		{name: "approve reaches threshold", status: active, approvals: voters[:2], voter: voters[2], action: "approve", want: [3]int{3, 0, 0}, tipped: true},
+		{name: "approve after rejecting", status: active, rejected: voters[:1], voter: voters[0], action: "approve", want: [3]int{1, 0, 0}},
+		{name: "reject", status: active, rejected: voters[:1], voter: voters[1], action: "reject", want: [3]int{0, 2, 0}},
+		{name: "reject reaches cutoff", status: active, rejected: voters[:2], voter: voters[2], action: "reject", want: [3]int{0, 3, 0}, tipped: true},
+		{name: "reject after approving", status: active, approvals: voters[:2], voter: voters[0], action: "reject", want: [3]int{1, 1, 0}},
+		{name: "cancel", status: approved, approvals: voters[:3], voter: voters[0], action: "cancel", want: [3]int{3, 0, 1}},
+		{name: "cancel reaches threshold", status: approved, approvals: voters[:3], cancelled: voters[:2], voter: voters[3], action: "cancel", want: [3]int{3, 0, 3}, tipped: true},
+		{name: "reject approved", status: approved, voter: voters[0], action: "reject", wantErr: "only active proposals"},
+		{name: "approve stale", status: active, index: 1, voter: voters[0], action: "approve", wantErr: "stale"},
+		{name: "cancel active", status: active, voter: voters[0], action: "cancel", wantErr: "only approved proposals"},
+		{name: "cancel executed", status: &squads_multisig_program.ProposalStatusExecuted{}, voter: voters[0], action: "cancel", wantErr: "only approved proposals"},
+		{name: "reject twice", status: active, rejected: voters[:1], voter: voters[0], action: "reject", wantErr: "already rejected"},
+		{name: "cancel twice", status: approved, cancelled: voters[:1], voter: voters[0], action: "cancel", wantErr: "already cancelled"},
+		{name: "no vote permission", status: active, voter: proposerOnly, action: "approve", wantErr: "vote permission"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index := tt.index
+			if index == 0 {
+				index = 2
+			}
+			proposal := &squads_multisig_program.Proposal{
+				TransactionIndex: index,
+				Status:           tt.status,
+				Approved:         tt.approvals,
+				Rejected:         tt.rejected,
+				Cancelled:        tt.cancelled,
+			}
+			output, err := tallyVote(multisigAccount, proposal, tt.voter, tt.action)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, [3]int{output.Approvals, output.Rejections, output.Cancelled})
+			require.Equal(t, tt.tipped, output.Tipped)
+			require.Equal(t, 3, output.Cutoff)
+		})
+	}
+}
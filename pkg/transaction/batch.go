@@ -0,0 +1,411 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// BatchCreateInput defines input parameters for proposing a batch
+type BatchCreateInput struct {
+	// Required inputs
+	Multisig     solana.PublicKey
+	Creator      signer.Signer
+	Transactions [][]solana.Instruction // executed in order, each as its own vault transaction; or BuildTransactions
+
+	// BuildTransactions, if set, is called instead of reading Transactions
+	// once the vault and ephemeral signer addresses are known, so that the
+	// instructions can use the ephemeral signers, e.g. as new accounts.
+	// ephemeralSigners[i] are those of transaction i, and there are as many
+	// transactions as entries in EphemeralSigners.
+	BuildTransactions func(vaultPDA solana.PublicKey, ephemeralSigners [][]solana.PublicKey) ([][]solana.Instruction, error)
+
+	// Optional inputs
+	VaultIndex          uint8
+	Memo                string
+	AutoApprove         bool    // approve the proposal once the batch is complete
+	EphemeralSigners    []uint8 // per transaction, the number of ephemeral signer PDAs it may use as signers
+	AddressLookupTables []addresslookuptable.KeyedAddressLookupTable
+	ProgramID           solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	// BatchIndex, if set, resumes the draft batch at this index instead of
+	// creating a new one; see BatchIncompleteError. Transactions must be the
+	// same list as before: those already in the batch are checked against
+	// it and skipped.
+	BatchIndex uint64
+
+	sender.Options
+}
+
+// BatchCreateOutput defines return values from proposing a batch
+type BatchCreateOutput struct {
+	Signatures       []solana.Signature // in the order they were sent
+	BatchIndex       uint64
+	VaultPDA         solana.PublicKey
+	BatchPDA         solana.PublicKey
+	ProposalPDA      solana.PublicKey
+	TransactionPDAs  []solana.PublicKey   // the batch's transactions, in execution order
+	EphemeralSigners [][]solana.PublicKey // per transaction
+	Resumed          int                  // transactions already in the batch when resuming
+	Threshold        uint16
+	TimeLock         uint32
+}
+
+// BatchIncompleteError is returned by CreateBatch when the batch was created
+// but a later transaction failed, leaving it a draft with only some of its
+// transactions. Set BatchCreateInput.BatchIndex to BatchIndex to resume.
+type BatchIncompleteError struct {
+	BatchIndex uint64
+	Err        error
+}
+
+func (e *BatchIncompleteError) Error() string {
+	return fmt.Sprintf("batch #%d is left as a draft; resume it with the same transactions: %v", e.BatchIndex, e.Err)
+}
+
+func (e *BatchIncompleteError) Unwrap() error {
+	return e.Err
+}
+
+// CreateBatch proposes the given transactions as one batch that a single
+// approval covers. The batch and its proposal are created as a draft, the
+// transactions are added in order, and the proposal is then activated for
+// voting and optionally approved. The instructions are packed into as few
+// transactions as fit, sent one after the other; if one fails after the
+// batch was created, a *BatchIncompleteError says how to resume it.
+//
+// Batch transactions cannot be uploaded through a transaction buffer, so each
+// must fit in one transaction; propose larger ones on their own with
+// CreateVaultTransaction.
+func CreateBatch(ctx context.Context, input BatchCreateInput) (*BatchCreateOutput, error) {
+	instructions, output, err := buildBatch(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	payer := input.Payer(input.Creator).PublicKey()
+	groups, err := packInstructions(instructions, payer)
+	if err != nil {
+		return nil, err
+	}
+	for i, group := range groups {
+		result, err := input.SendAndConfirm(ctx, group, input.Creator)
+		if err != nil {
+			err = fmt.Errorf("failed to send batch transaction %d of %d: %w", i+1, len(groups), err)
+			// The first transaction creates the batch, so nothing is left
+			// behind if it fails, unless the batch existed already.
+			if i > 0 || input.BatchIndex != 0 {
+				return nil, &BatchIncompleteError{BatchIndex: output.BatchIndex, Err: err}
+			}
+			return nil, err
+		}
+		output.Signatures = append(output.Signatures, result.Signature)
+	}
+	return output, nil
+}
+
+// buildBatch validates the input and returns, in order, the batch and draft
+// proposal creation, one add instruction per transaction, and the
+// activation and optional approval of the proposal.
+func buildBatch(ctx context.Context, input BatchCreateInput) ([]solana.Instruction, *BatchCreateOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if input.Creator == nil {
+		return nil, nil, errors.New("creator signer is required")
+	}
+	count := len(input.Transactions)
+	if input.BuildTransactions != nil {
+		count = len(input.EphemeralSigners)
+	}
+	if count == 0 {
+		return nil, nil, errors.New("at least one transaction is required")
+	}
+	if len(input.EphemeralSigners) != 0 && len(input.EphemeralSigners) != count {
+		return nil, nil, fmt.Errorf("%d ephemeral signer counts given for %d transactions", len(input.EphemeralSigners), count)
+	}
+
+	creator := input.Creator.PublicKey()
+	vaultPDA, _, err := pda.Vault(input.Multisig, input.VaultIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+	}
+	if !hasPermission(multisigAccount, creator, multisig.PermissionPropose) {
+		return nil, nil, fmt.Errorf("%s is not a member of this multisig or doesn't have proposal permission", creator)
+	}
+	if input.AutoApprove && !hasPermission(multisigAccount, creator, multisig.PermissionVote) {
+		return nil, nil, fmt.Errorf("%s doesn't have vote permission and cannot auto-approve", creator)
+	}
+
+	batchIndex := multisigAccount.TransactionIndex + 1
+	if input.BatchIndex != 0 {
+		batchIndex = input.BatchIndex
+	}
+	batchPDA, _, err := pda.Transaction(input.Multisig, batchIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposalPDA, _, err := pda.Proposal(input.Multisig, batchIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rentPayer := input.Payer(input.Creator).PublicKey()
+
+	var instructions []solana.Instruction
+	resumed, activate, approve := 0, true, input.AutoApprove
+	if input.BatchIndex != 0 {
+		resumed, activate, approve, err = resumeBatch(ctx, input, count, multisigAccount, batchPDA, proposalPDA)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		batchCreateArgs := squads_multisig_program.BatchCreateArgs{VaultIndex: input.VaultIndex}
+		if input.Memo != "" {
+			batchCreateArgs.Memo = &input.Memo
+		}
+		instructions = append(instructions,
+			squads_multisig_program.NewBatchCreateInstruction(
+				batchCreateArgs,
+				input.Multisig,
+				batchPDA,
+				creator,
+				rentPayer,
+				solana.SystemProgramID,
+			).Build(),
+			squads_multisig_program.NewProposalCreateInstruction(
+				squads_multisig_program.ProposalCreateArgs{
+					TransactionIndex: batchIndex,
+					Draft:            true,
+				},
+				input.Multisig,
+				proposalPDA,
+				creator,
+				rentPayer,
+				solana.SystemProgramID,
+			).Build(),
+		)
+	}
+
+	// Only the vault and each transaction's own ephemeral signers, derived
+	// from its address in the batch, can sign inside it.
+	transactionPDAs := make([]solana.PublicKey, 0, count)
+	ephemeralSigners := make([][]solana.PublicKey, count)
+	for i := 0; i < count; i++ {
+		// Batch transactions are numbered from 1.
+		transactionPDA, _, err := pda.BatchTransaction(input.Multisig, batchIndex, uint32(i+1), input.ProgramID)
+		if err != nil {
+			return nil, nil, err
+		}
+		transactionPDAs = append(transactionPDAs, transactionPDA)
+		if len(input.EphemeralSigners) == 0 {
+			continue
+		}
+		for j := uint8(0); j < input.EphemeralSigners[i]; j++ {
+			ephemeralSigner, _, err := pda.EphemeralSigner(transactionPDA, j, input.ProgramID)
+			if err != nil {
+				return nil, nil, err
+			}
+			ephemeralSigners[i] = append(ephemeralSigners[i], ephemeralSigner)
+		}
+	}
+	transactions := input.Transactions
+	if input.BuildTransactions != nil {
+		if transactions, err = input.BuildTransactions(vaultPDA, ephemeralSigners); err != nil {
+			return nil, nil, fmt.Errorf("failed to build transactions: %w", err)
+		}
+		if len(transactions) != count {
+			return nil, nil, fmt.Errorf("built %d transactions, expected %d", len(transactions), count)
+		}
+	}
+
+	for i, transactionInstructions := range transactions {
+		if len(transactionInstructions) == 0 {
+			return nil, nil, fmt.Errorf("batch transaction %d has no instructions", i+1)
+		}
+		if err := ValidateVaultSigners(transactionInstructions, vaultPDA, ephemeralSigners[i]); err != nil {
+			return nil, nil, fmt.Errorf("batch transaction %d: %w", i+1, err)
+		}
+		message, err := CreateTransactionMessageBytes(vaultPDA, transactionInstructions, solana.Hash{}, input.AddressLookupTables)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create message of batch transaction %d: %w", i+1, err)
+		}
+		if i < resumed {
+			// Resuming with another list would silently mix two batches.
+			added, err := accounts.FetchVaultBatchTransaction(ctx, input.Options, transactionPDAs[i])
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to fetch batch transaction %d: %w", i+1, err)
+			}
+			addedMessage, err := encodeVaultTransactionMessage(&added.Message)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to encode batch transaction %d: %w", i+1, err)
+			}
+			if !bytes.Equal(addedMessage, message) || len(added.EphemeralSignerBumps) != len(ephemeralSigners[i]) {
+				return nil, nil, fmt.Errorf("batch transaction %d differs from the one already in batch #%d; "+
+					"resume with the same transactions in the same order", i+1, batchIndex)
+			}
+			continue
+		}
+
+		addIx := squads_multisig_program.NewBatchAddTransactionInstruction(
+			squads_multisig_program.BatchAddTransactionArgs{
+				EphemeralSigners:   uint8(len(ephemeralSigners[i])),
+				TransactionMessage: message,
+			},
+			input.Multisig,
+			proposalPDA,
+			batchPDA,
+			transactionPDAs[i],
+			creator,
+			rentPayer,
+			solana.SystemProgramID,
+		).Build()
+		fits, err := fitsInTransaction([]solana.Instruction{addIx}, rentPayer)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !fits {
+			return nil, nil, fmt.Errorf("batch transaction %d is too large to add in one transaction and batches cannot use transaction buffers; "+
+				"propose it on its own, which uploads it through a buffer, or use lookup tables", i+1)
+		}
+		instructions = append(instructions, addIx)
+	}
+
+	if activate {
+		instructions = append(instructions, squads_multisig_program.NewProposalActivateInstruction(
+			input.Multisig,
+			creator,
+			proposalPDA,
+		).Build())
+	}
+	if approve {
+		proposalVoteArgs := squads_multisig_program.ProposalVoteArgs{}
+		if input.Memo != "" {
+			proposalVoteArgs.Memo = &input.Memo
+		}
+		instructions = append(instructions, squads_multisig_program.NewProposalApproveInstruction(
+			proposalVoteArgs,
+			input.Multisig,
+			creator,
+			proposalPDA,
+		).Build())
+	}
+	if len(instructions) == 0 {
+		return nil, nil, fmt.Errorf("batch #%d is already complete", batchIndex)
+	}
+
+	instructions, err = multisig.BindProgramID(instructions, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resumed > 0 {
+		log.Printf("Resuming batch #%d: %d of %d transactions were already added", batchIndex, resumed, count)
+	}
+	log.Printf("Creating batch #%d with %d transactions on multisig %s", batchIndex, count, input.Multisig)
+	log.Printf("Batch PDA: %s", batchPDA)
+	log.Printf("Proposal PDA: %s", proposalPDA)
+
+	return instructions, &BatchCreateOutput{
+		BatchIndex:       batchIndex,
+		VaultPDA:         vaultPDA,
+		BatchPDA:         batchPDA,
+		ProposalPDA:      proposalPDA,
+		TransactionPDAs:  transactionPDAs,
+		EphemeralSigners: ephemeralSigners,
+		Resumed:          resumed,
+		Threshold:        multisigAccount.Threshold,
+		TimeLock:         multisigAccount.TimeLock,
+	}, nil
+}
+
+// resumeBatch checks that the batch at input.BatchIndex can be resumed by
+// the creator with count transactions, and returns how many transactions it
+// already holds and whether its proposal still needs activating and
+// approving.
+func resumeBatch(
+	ctx context.Context,
+	input BatchCreateInput,
+	count int,
+	multisigAccount *squads_multisig_program.Multisig,
+	batchPDA, proposalPDA solana.PublicKey,
+) (resumed int, activate, approve bool, err error) {
+	batch, err := accounts.FetchBatch(ctx, input.Options, batchPDA)
+	if err != nil {
+		return 0, false, false, fmt.Errorf("failed to fetch batch #%d: %w", input.BatchIndex, err)
+	}
+	proposal, err := accounts.FetchProposal(ctx, input.Options, proposalPDA)
+	if err != nil {
+		return 0, false, false, fmt.Errorf("failed to fetch proposal of batch #%d: %w", input.BatchIndex, err)
+	}
+	creator := input.Creator.PublicKey()
+	switch {
+	case !batch.Creator.Equals(creator):
+		return 0, false, false, fmt.Errorf("batch #%d was created by %s; only its creator can add transactions", input.BatchIndex, batch.Creator)
+	case batch.VaultIndex != input.VaultIndex:
+		return 0, false, false, fmt.Errorf("batch #%d uses vault %d, not %d", input.BatchIndex, batch.VaultIndex, input.VaultIndex)
+	case int(batch.Size) > count:
+		return 0, false, false, fmt.Errorf("batch #%d already holds %d transactions, more than the %d given", input.BatchIndex, batch.Size, count)
+	case input.BatchIndex <= multisigAccount.StaleTransactionIndex:
+		return 0, false, false, fmt.Errorf("batch #%d is stale: the multisig's config changed after it was created", input.BatchIndex)
+	}
+
+	switch state := StateOf(proposal.Status); {
+	case state == StateDraft:
+		return int(batch.Size), true, input.AutoApprove, nil
+	case state == StateActive && int(batch.Size) == count:
+		// Only the approval is missing.
+		return int(batch.Size), false, input.AutoApprove && !containsKey(proposal.Approved, creator), nil
+	default:
+		return 0, false, false, fmt.Errorf("batch #%d is %s; only a draft batch can be resumed",
+			input.BatchIndex, DescribeProposalStatus(proposal.Status))
+	}
+}
+
+// packInstructions splits instructions, kept in order, into as few
+// transactions paid by payer as they fit in.
+func packInstructions(instructions []solana.Instruction, payer solana.PublicKey) ([][]solana.Instruction, error) {
+	var groups [][]solana.Instruction
+	var current []solana.Instruction
+	for i, ix := range instructions {
+		if len(current) > 0 {
+			fits, err := fitsInTransaction(append(current[:len(current):len(current)], ix), payer)
+			if err != nil {
+				return nil, err
+			}
+			if fits {
+				current = append(current, ix)
+				continue
+			}
+			groups = append(groups, current)
+		}
+		fits, err := fitsInTransaction([]solana.Instruction{ix}, payer)
+		if err != nil {
+			return nil, err
+		}
+		if !fits {
+			return nil, fmt.Errorf("instruction %d does not fit in a transaction on its own", i)
+		}
+		current = []solana.Instruction{ix}
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+	return groups, nil
+}
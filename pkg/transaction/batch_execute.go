@@ -0,0 +1,188 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// BatchExecuteInput defines input parameters for executing a batch
+type BatchExecuteInput struct {
+	// Required inputs
+	Multisig   solana.PublicKey
+	BatchIndex uint64
+	Executor   signer.Signer
+
+	// Optional inputs
+	ProgramID solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
+// BatchExecuteOutput defines return values from executing a batch
+type BatchExecuteOutput struct {
+	Signatures  []solana.Signature // one per batch transaction executed by this call
+	BatchPDA    solana.PublicKey
+	ProposalPDA solana.PublicKey
+	Executed    uint32 // batch transactions executed so far, including earlier calls
+	Size        uint32
+}
+
+// batchExecuteStep describes the next batch transaction buildBatchExecute
+// found to execute.
+type batchExecuteStep struct {
+	batchPDA         solana.PublicKey
+	proposalPDA      solana.PublicKey
+	transactionIndex uint32 // within the batch, from 1
+	size             uint32
+	lookupTables     []addresslookuptable.KeyedAddressLookupTable
+}
+
+// errBatchExecuted is returned by buildBatchExecute once every transaction
+// of the batch has been executed.
+var errBatchExecuted = errors.New("every transaction of the batch has been executed")
+
+// ExecuteBatch executes the remaining transactions of an approved batch, one
+// transaction each, in order. Progress is read from the batch's
+// ExecutedTransactionIndex, so calling it again after an interruption
+// resumes with the first transaction not yet executed.
+func ExecuteBatch(ctx context.Context, input BatchExecuteInput) (*BatchExecuteOutput, error) {
+	output := &BatchExecuteOutput{}
+	for {
+		instructions, step, err := buildBatchExecute(ctx, input)
+		if errors.Is(err, errBatchExecuted) {
+			if len(output.Signatures) == 0 {
+				return nil, fmt.Errorf("all %d transactions of batch #%d have already been executed", step.size, input.BatchIndex)
+			}
+			output.Executed = step.size
+			return output, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		output.BatchPDA, output.ProposalPDA, output.Size = step.batchPDA, step.proposalPDA, step.size
+		output.Executed = step.transactionIndex - 1
+
+		opts := input.Options
+		if opts.AddressTables == nil {
+			opts.AddressTables = AddressTables(step.lookupTables)
+		}
+		result, err := opts.SendAndConfirm(ctx, instructions, input.Executor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute batch transaction %d of %d (%d executed; run again to resume): %w",
+				step.transactionIndex, step.size, output.Executed, err)
+		}
+		output.Signatures = append(output.Signatures, result.Signature)
+		log.Printf("✓ Batch transaction %d of %d executed: %s", step.transactionIndex, step.size, result.Signature)
+	}
+}
+
+// buildBatchExecute checks that the batch can be executed and returns the
+// execute instruction of its next transaction. Once all are executed it
+// returns errBatchExecuted along with the step describing the batch.
+func buildBatchExecute(ctx context.Context, input BatchExecuteInput) ([]solana.Instruction, *batchExecuteStep, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if input.Executor == nil {
+		return nil, nil, errors.New("executor signer is required")
+	}
+	executor := input.Executor.PublicKey()
+
+	batchPDA, _, err := pda.Transaction(input.Multisig, input.BatchIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposalPDA, _, err := pda.Proposal(input.Multisig, input.BatchIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	batch, err := accounts.FetchBatch(ctx, input.Options, batchPDA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch batch: %w", err)
+	}
+	if batch.ExecutedTransactionIndex >= batch.Size {
+		return nil, &batchExecuteStep{batchPDA: batchPDA, proposalPDA: proposalPDA, size: batch.Size}, errBatchExecuted
+	}
+
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+	}
+	proposal, err := accounts.FetchProposal(ctx, input.Options, proposalPDA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch proposal: %w", err)
+	}
+	if err := checkExecutable(multisigAccount, proposal); err != nil {
+		return nil, nil, err
+	}
+	if !hasPermission(multisigAccount, executor, multisig.PermissionExecute) {
+		return nil, nil, fmt.Errorf("executor %s does not have execute permission", executor)
+	}
+
+	next := batch.ExecutedTransactionIndex + 1
+	transactionPDA, _, err := pda.BatchTransaction(input.Multisig, input.BatchIndex, next, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	transaction, err := accounts.FetchVaultBatchTransaction(ctx, input.Options, transactionPDA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch batch transaction %d: %w", next, err)
+	}
+
+	lookupTableKeys := make([]solana.PublicKey, 0, len(transaction.Message.AddressTableLookups))
+	for _, lookup := range transaction.Message.AddressTableLookups {
+		lookupTableKeys = append(lookupTableKeys, lookup.AccountKey)
+	}
+	lookupTables, err := FetchAddressLookupTables(ctx, input.Options, lookupTableKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Ephemeral signers of batch transactions derive from the batch
+	// transaction's own address.
+	programSigners, err := VaultTransactionSigners(input.Multisig, transactionPDA, batch.VaultIndex, len(transaction.EphemeralSignerBumps), input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	additionalAccounts, err := VaultExecuteAccounts(&transaction.Message, programSigners, lookupTables)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	executeInstruction := squads_multisig_program.NewBatchExecuteTransactionInstruction(
+		input.Multisig,
+		executor,
+		proposalPDA,
+		batchPDA,
+		transactionPDA,
+	)
+	executeInstruction.AccountMetaSlice = append(executeInstruction.AccountMetaSlice, additionalAccounts...)
+
+	executeIx, err := multisig.WithProgramID(executeInstruction.Build(), input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Printf("Executing batch #%d transaction %d of %d on multisig %s", input.BatchIndex, next, batch.Size, input.Multisig)
+
+	return []solana.Instruction{executeIx}, &batchExecuteStep{
+		batchPDA:         batchPDA,
+		proposalPDA:      proposalPDA,
+		transactionIndex: next,
+		size:             batch.Size,
+		lookupTables:     lookupTables,
+	}, nil
+}
@@ -0,0 +1,381 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/internal/rpctest"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+func TestParseBatchJSON(t *testing.T) {
+	program := solana.NewWallet().PublicKey().String()
+	transactions, err := ParseBatchJSON([]byte(`[
+		[{"programId": "` + program + `", "accounts": [], "data": "AQ=="}],
+		[{"programId": "` + program + `", "accounts": [], "data": "Ag=="},
+		 {"programId": "` + program + `", "accounts": [], "data": "Aw=="}]
+	]`))
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+	require.Len(t, transactions[1], 2)
+
+	_, err = ParseBatchJSON([]byte(`[[{"programId": "` + program + `", "accounts": [], "data": "AQ=="}], []]`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "transaction 2")
+}
+
+func TestBuildBatch(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	creator := signer.NewOffline(solana.NewWallet().PublicKey())
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		Threshold:        2,
+		TransactionIndex: 9,
+		Members: []squads_multisig_program.Member{
+			{Key: creator.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}},
+		},
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	vaultPDA, _, err := pda.Vault(multisigPDA, 0, solana.PublicKey{})
+	require.NoError(t, err)
+	var transactions [][]solana.Instruction
+	for _, recipient := range newKeys(3) {
+		transactions = append(transactions, []solana.Instruction{system.NewTransferInstruction(1, vaultPDA, recipient).Build()})
+	}
+
+	instructions, output, err := buildBatch(context.Background(), BatchCreateInput{
+		Multisig:     multisigPDA,
+		Creator:      creator,
+		Transactions: transactions,
+		AutoApprove:  true,
+		Options:      sender.Options{Client: rpc.New(httpServer.URL)},
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 10, output.BatchIndex)
+
+	// create, draft proposal, three adds, activate, approve
+	require.Len(t, instructions, 7)
+	decoded := make([]*squads_multisig_program.Instruction, len(instructions))
+	for i, ix := range instructions {
+		decoded[i], err = squads_multisig_program.DecodeInstruction(ix.Accounts(), mustData(t, ix))
+		require.NoError(t, err)
+	}
+	require.IsType(t, &squads_multisig_program.BatchCreate{}, decoded[0].Impl)
+	proposalCreate := decoded[1].Impl.(*squads_multisig_program.ProposalCreate)
+	require.True(t, proposalCreate.Args.Draft)
+	for i := 0; i < 3; i++ {
+		add := decoded[2+i].Impl.(*squads_multisig_program.BatchAddTransaction)
+		want, _, err := pda.BatchTransaction(multisigPDA, 10, uint32(i+1), solana.PublicKey{})
+		require.NoError(t, err)
+		require.Equal(t, want, output.TransactionPDAs[i])
+		require.Equal(t, want, add.GetTransactionAccount().PublicKey)
+	}
+	require.IsType(t, &squads_multisig_program.ProposalActivate{}, decoded[5].Impl)
+	require.IsType(t, &squads_multisig_program.ProposalApprove{}, decoded[6].Impl)
+
+	groups, err := packInstructions(instructions, creator.PublicKey())
+	require.NoError(t, err)
+	var packed []solana.Instruction
+	for _, group := range groups {
+		packed = append(packed, group...)
+	}
+	require.Equal(t, instructions, packed, "packing keeps every instruction in order")
+}
+
+func TestBuildBatchExecuteResumes(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	executor := signer.NewOffline(solana.NewWallet().PublicKey())
+	recipient := solana.NewWallet().PublicKey()
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		Threshold:        1,
+		TransactionIndex: 3,
+		Members: []squads_multisig_program.Member{
+			{Key: executor.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}},
+		},
+	})
+	batchPDA, _, err := pda.Transaction(multisigPDA, 3, solana.PublicKey{})
+	require.NoError(t, err)
+	proposalPDA, _, err := pda.Proposal(multisigPDA, 3, solana.PublicKey{})
+	require.NoError(t, err)
+	server.Add(t, proposalPDA, &squads_multisig_program.Proposal{
+		Multisig:         multisigPDA,
+		TransactionIndex: 3,
+		Status:           &squads_multisig_program.ProposalStatusApproved{Timestamp: time.Now().Unix()},
+	})
+	vaultPDA, _, err := pda.Vault(multisigPDA, 0, solana.PublicKey{})
+	require.NoError(t, err)
+	secondPDA, _, err := pda.BatchTransaction(multisigPDA, 3, 2, solana.PublicKey{})
+	require.NoError(t, err)
+	server.Add(t, secondPDA, &squads_multisig_program.VaultBatchTransaction{
+		EphemeralSignerBumps: []byte{},
+		Message: squads_multisig_program.VaultTransactionMessage{
+			NumSigners: 1, NumWritableSigners: 1, NumWritableNonSigners: 1,
+			AccountKeys:         []solana.PublicKey{vaultPDA, recipient, solana.SystemProgramID},
+			Instructions:        []squads_multisig_program.MultisigCompiledInstruction{{ProgramIdIndex: 2, AccountIndexes: []byte{0, 1}, Data: []byte{}}},
+			AddressTableLookups: []squads_multisig_program.MultisigMessageAddressTableLookup{},
+		},
+	})
+	server.Add(t, batchPDA, &squads_multisig_program.Batch{Multisig: multisigPDA, Index: 3, Size: 2, ExecutedTransactionIndex: 1})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	input := BatchExecuteInput{
+		Multisig:   multisigPDA,
+		BatchIndex: 3,
+		Executor:   executor,
+		Options:    sender.Options{Client: rpc.New(httpServer.URL)},
+	}
+	instructions, step, err := buildBatchExecute(context.Background(), input)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, step.transactionIndex, "continues after the executed transaction")
+	require.EqualValues(t, 2, step.size)
+
+	metas := instructions[0].Accounts()
+	require.Len(t, metas, 8)
+	require.Equal(t, secondPDA, metas[4].PublicKey)
+	require.Equal(t, vaultPDA, metas[5].PublicKey)
+	require.False(t, metas[5].IsSigner, "the program signs for the vault")
+	require.True(t, metas[6].IsWritable)
+
+	server.Add(t, batchPDA, &squads_multisig_program.Batch{Multisig: multisigPDA, Index: 3, Size: 2, ExecutedTransactionIndex: 2})
+	_, step, err = buildBatchExecute(context.Background(), input)
+	require.ErrorIs(t, err, errBatchExecuted)
+	require.EqualValues(t, 2, step.size)
+}
+
+func TestBuildBatchResumesDraft(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	creator := signer.NewOffline(solana.NewWallet().PublicKey())
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		Threshold:        2,
+		TransactionIndex: 10,
+		Members: []squads_multisig_program.Member{
+			{Key: creator.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}},
+		},
+	})
+	batchPDA, _, err := pda.Transaction(multisigPDA, 10, solana.PublicKey{})
+	require.NoError(t, err)
+	proposalPDA, _, err := pda.Proposal(multisigPDA, 10, solana.PublicKey{})
+	require.NoError(t, err)
+	server.Add(t, batchPDA, &squads_multisig_program.Batch{Multisig: multisigPDA, Creator: creator.PublicKey(), Index: 10, Size: 1})
+	server.Add(t, proposalPDA, &squads_multisig_program.Proposal{
+		Multisig:         multisigPDA,
+		TransactionIndex: 10,
+		Status:           &squads_multisig_program.ProposalStatusDraft{Timestamp: time.Now().Unix()},
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	vaultPDA, _, err := pda.Vault(multisigPDA, 0, solana.PublicKey{})
+	require.NoError(t, err)
+	var transactions [][]solana.Instruction
+	for _, recipient := range newKeys(3) {
+		transactions = append(transactions, []solana.Instruction{system.NewTransferInstruction(1, vaultPDA, recipient).Build()})
+	}
+	// The batch transactions as the program stores them once added.
+	for i, instructions := range transactions {
+		address, _, err := pda.BatchTransaction(multisigPDA, 10, uint32(i+1), solana.PublicKey{})
+		require.NoError(t, err)
+		server.Add(t, address, storedBatchTransaction(t, vaultPDA, instructions))
+	}
+	input := BatchCreateInput{
+		Multisig:     multisigPDA,
+		Creator:      creator,
+		Transactions: transactions,
+		AutoApprove:  true,
+		BatchIndex:   10,
+		Options:      sender.Options{Client: rpc.New(httpServer.URL)},
+	}
+
+	// Another first transaction would make a mixed batch.
+	reordered := input
+	reordered.Transactions = [][]solana.Instruction{transactions[1], transactions[0], transactions[2]}
+	_, _, err = buildBatch(context.Background(), reordered)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "batch transaction 1 differs from the one already in batch #10")
+
+	instructions, output, err := buildBatch(context.Background(), input)
+	require.NoError(t, err)
+	require.Equal(t, 1, output.Resumed)
+	require.Len(t, output.TransactionPDAs, 3)
+	// the two missing adds, activate, approve
+	require.Len(t, instructions, 4)
+	for i := 0; i < 2; i++ {
+		decoded, err := squads_multisig_program.DecodeInstruction(instructions[i].Accounts(), mustData(t, instructions[i]))
+		require.NoError(t, err)
+		add := decoded.Impl.(*squads_multisig_program.BatchAddTransaction)
+		require.Equal(t, output.TransactionPDAs[1+i], add.GetTransactionAccount().PublicKey)
+	}
+
+	// Activated but not yet approved: only the approval is left.
+	server.Add(t, batchPDA, &squads_multisig_program.Batch{Multisig: multisigPDA, Creator: creator.PublicKey(), Index: 10, Size: 3})
+	server.Add(t, proposalPDA, &squads_multisig_program.Proposal{
+		Multisig:         multisigPDA,
+		TransactionIndex: 10,
+		Status:           &squads_multisig_program.ProposalStatusActive{Timestamp: time.Now().Unix()},
+	})
+	instructions, _, err = buildBatch(context.Background(), input)
+	require.NoError(t, err)
+	require.Len(t, instructions, 1)
+	decoded, err := squads_multisig_program.DecodeInstruction(instructions[0].Accounts(), mustData(t, instructions[0]))
+	require.NoError(t, err)
+	require.IsType(t, &squads_multisig_program.ProposalApprove{}, decoded.Impl)
+
+	input.AutoApprove = false
+	_, _, err = buildBatch(context.Background(), input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already complete")
+
+	other := signer.NewOffline(solana.NewWallet().PublicKey())
+	input.Creator = other
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		Threshold:        2,
+		TransactionIndex: 10,
+		Members: []squads_multisig_program.Member{
+			{Key: other.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}},
+		},
+	})
+	_, _, err = buildBatch(context.Background(), input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "only its creator")
+}
+
+// storedBatchTransaction returns the batch transaction the program stores
+// for instructions.
+func storedBatchTransaction(t *testing.T, vault solana.PublicKey, instructions []solana.Instruction) *squads_multisig_program.VaultBatchTransaction {
+	data, err := CreateTransactionMessageBytes(vault, instructions, solana.Hash{}, nil)
+	require.NoError(t, err)
+	var message squads_multisig_program.TransactionMessage
+	require.NoError(t, squads_multisig_program.NewDecoder(bytes.NewReader(data)).Decode(&message))
+
+	stored := squads_multisig_program.VaultTransactionMessage{
+		NumSigners:            message.NumSigners,
+		NumWritableSigners:    message.NumWritableSigners,
+		NumWritableNonSigners: message.NumWritableNonSigners,
+		AccountKeys:           message.AccountKeys.Data,
+		AddressTableLookups:   []squads_multisig_program.MultisigMessageAddressTableLookup{},
+	}
+	for _, ix := range message.Instructions.Data {
+		stored.Instructions = append(stored.Instructions, squads_multisig_program.MultisigCompiledInstruction{
+			ProgramIdIndex: ix.ProgramIdIndex,
+			AccountIndexes: ix.AccountIndexes.Data,
+			Data:           ix.Data.Data,
+		})
+	}
+	return &squads_multisig_program.VaultBatchTransaction{EphemeralSignerBumps: []byte{}, Message: stored}
+}
+
+func TestBuildBatchEphemeralSigners(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	creator := signer.NewOffline(solana.NewWallet().PublicKey())
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		Threshold:        1,
+		TransactionIndex: 4,
+		Members: []squads_multisig_program.Member{
+			{Key: creator.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}},
+		},
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	_, _, err := buildBatch(context.Background(), BatchCreateInput{
+		Multisig:         multisigPDA,
+		Creator:          creator,
+		Transactions:     make([][]solana.Instruction, 2),
+		EphemeralSigners: []uint8{1},
+		Options:          sender.Options{Client: rpc.New(httpServer.URL)},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "1 ephemeral signer counts given for 2 transactions")
+
+	instructions, output, err := buildBatch(context.Background(), BatchCreateInput{
+		Multisig:         multisigPDA,
+		Creator:          creator,
+		EphemeralSigners: []uint8{0, 1},
+		BuildTransactions: func(vault solana.PublicKey, ephemeral [][]solana.PublicKey) ([][]solana.Instruction, error) {
+			require.Empty(t, ephemeral[0])
+			require.Len(t, ephemeral[1], 1)
+			return [][]solana.Instruction{
+				{system.NewTransferInstruction(1, vault, solana.NewWallet().PublicKey()).Build()},
+				{system.NewCreateAccountInstruction(1, 0, solana.SystemProgramID, vault, ephemeral[1][0]).Build()},
+			}, nil
+		},
+		Options: sender.Options{Client: rpc.New(httpServer.URL)},
+	})
+	require.NoError(t, err)
+
+	// The signers derive from the batch transaction, as BatchExecute expects.
+	second, _, err := pda.BatchTransaction(multisigPDA, 5, 2, solana.PublicKey{})
+	require.NoError(t, err)
+	want, _, err := pda.EphemeralSigner(second, 0, solana.PublicKey{})
+	require.NoError(t, err)
+	require.Equal(t, [][]solana.PublicKey{nil, {want}}, output.EphemeralSigners)
+	for i, wantCount := range []uint8{0, 1} {
+		ix := instructions[2+i]
+		decoded, err := squads_multisig_program.DecodeInstruction(ix.Accounts(), mustData(t, ix))
+		require.NoError(t, err)
+		require.Equal(t, wantCount, decoded.Impl.(*squads_multisig_program.BatchAddTransaction).Args.EphemeralSigners)
+	}
+}
+
+func TestBuildBatchRejectsUnsupportedTransactions(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	creator := signer.NewOffline(solana.NewWallet().PublicKey())
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		Threshold:        1,
+		TransactionIndex: 1,
+		Members: []squads_multisig_program.Member{
+			{Key: creator.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}},
+		},
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	vaultPDA, _, err := pda.Vault(multisigPDA, 0, solana.PublicKey{})
+	require.NoError(t, err)
+	transfer := system.NewTransferInstruction(1, vaultPDA, solana.NewWallet().PublicKey()).Build()
+	input := BatchCreateInput{
+		Multisig: multisigPDA,
+		Creator:  creator,
+		Options:  sender.Options{Client: rpc.New(httpServer.URL)},
+	}
+
+	// A new account signing for itself needs an ephemeral signer.
+	input.Transactions = [][]solana.Instruction{
+		{transfer},
+		{system.NewCreateAccountInstruction(1, 0, solana.SystemProgramID, vaultPDA, solana.NewWallet().PublicKey()).Build()},
+	}
+	_, _, err = buildBatch(context.Background(), input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "batch transaction 2")
+	require.Contains(t, err.Error(), "ephemeral signers can sign")
+
+	large := solana.NewInstruction(solana.MemoProgramID, solana.AccountMetaSlice{solana.Meta(vaultPDA).SIGNER()}, make([]byte, 1100))
+	input.Transactions = [][]solana.Instruction{{transfer}, {large}}
+	_, _, err = buildBatch(context.Background(), input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "batch transaction 2 is too large")
+}
@@ -0,0 +1,294 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+const (
+	// MaxBufferSize is the largest vault transaction message a transaction
+	// buffer accepts.
+	MaxBufferSize = 4000
+
+	// maxTransactionSize is the largest serialized transaction the network
+	// accepts.
+	maxTransactionSize = 1232
+
+	// transactionSizeReserve leaves room for the compute budget and nonce
+	// instructions the sender may add to a transaction.
+	transactionSizeReserve = 160
+
+	// bufferChunkSize is how much of the message each buffer create or
+	// extend transaction carries.
+	bufferChunkSize = 600
+)
+
+// fitsInTransaction reports whether instructions paid by payer fit in one
+// legacy transaction, leaving transactionSizeReserve bytes to spare.
+func fitsInTransaction(instructions []solana.Instruction, payer solana.PublicKey) (bool, error) {
+	tx, err := solana.NewTransaction(instructions, solana.Hash{}, solana.TransactionPayer(payer))
+	if err != nil {
+		return false, fmt.Errorf("failed to compile transaction: %w", err)
+	}
+	message, err := tx.Message.MarshalBinary()
+	if err != nil {
+		return false, fmt.Errorf("failed to serialize transaction: %w", err)
+	}
+	size := 1 + solana.SignatureLength*int(tx.Message.Header.NumRequiredSignatures) + len(message)
+	return size+transactionSizeReserve <= maxTransactionSize, nil
+}
+
+// createFromBuffer uploads the planned message to the creator's transaction
+// buffer, then creates the vault transaction and its proposal from it. The
+// program closes the buffer and refunds its rent to the creator on success.
+func createFromBuffer(ctx context.Context, input VaultTransactionCreateInput, plan *vaultTransactionPlan) (*VaultTransactionCreateOutput, error) {
+	bufferPDA, err := uploadTransactionBuffer(ctx, input, plan.createArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// The program reads the message from the buffer and expects an empty
+	// placeholder in its place.
+	createArgs := plan.createArgs
+	createArgs.TransactionMessage = make([]byte, 6)
+	creator := input.Creator.PublicKey()
+	createIx, err := multisig.WithProgramID(squads_multisig_program.NewVaultTransactionCreateFromBufferInstruction(
+		createArgs,
+		input.Multisig,
+		plan.output.TransactionPDA,
+		creator,
+		input.Payer(input.Creator).PublicKey(),
+		solana.SystemProgramID,
+		bufferPDA,
+		creator,
+	).Build(), input.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+	instructions := append([]solana.Instruction{createIx}, plan.instructions[1:]...)
+
+	result, err := input.SendAndConfirm(ctx, instructions, input.Creator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the vault transaction from buffer %s, which is kept for a retry: %w", bufferPDA, err)
+	}
+	plan.output.Signature = result.Signature
+	plan.output.TransactionBuffer = bufferPDA
+	return plan.output, nil
+}
+
+// uploadTransactionBuffer writes the message of createArgs to the creator's
+// transaction buffer at input.BufferIndex, one chunk per transaction, and
+// checks the result against the message's hash and size. A buffer left by
+// an interrupted upload of the same message is resumed where it stopped.
+func uploadTransactionBuffer(
+	ctx context.Context,
+	input VaultTransactionCreateInput,
+	createArgs squads_multisig_program.VaultTransactionCreateArgs,
+) (solana.PublicKey, error) {
+	message := createArgs.TransactionMessage
+	if len(message) > MaxBufferSize {
+		return solana.PublicKey{}, fmt.Errorf("the %d byte vault transaction message exceeds the %d byte transaction buffer limit",
+			len(message), MaxBufferSize)
+	}
+	hash := sha256.Sum256(message)
+	creator := input.Creator.PublicKey()
+	bufferPDA, _, err := pda.TransactionBuffer(input.Multisig, creator, input.BufferIndex, input.ProgramID)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+
+	send := func(ix solana.Instruction) error {
+		ix, err := multisig.WithProgramID(ix, input.ProgramID)
+		if err != nil {
+			return err
+		}
+		if _, err := input.SendAndConfirm(ctx, []solana.Instruction{ix}, input.Creator); err != nil {
+			return fmt.Errorf("failed to upload to transaction buffer %s: %w", bufferPDA, err)
+		}
+		return nil
+	}
+
+	var uploaded int
+	existing, err := accounts.FetchTransactionBuffer(ctx, input.Options, bufferPDA)
+	switch {
+	case errors.Is(err, accounts.ErrNotFound):
+		uploaded = min(bufferChunkSize, len(message))
+		log.Printf("Uploading the %d byte message to transaction buffer %s", len(message), bufferPDA)
+		err = send(squads_multisig_program.NewTransactionBufferCreateInstruction(
+			squads_multisig_program.TransactionBufferCreateArgs{
+				BufferIndex:     input.BufferIndex,
+				VaultIndex:      createArgs.VaultIndex,
+				FinalBufferHash: hash,
+				FinalBufferSize: uint16(len(message)),
+				Buffer:          message[:uploaded],
+			},
+			input.Multisig,
+			bufferPDA,
+			creator,
+			input.Payer(input.Creator).PublicKey(),
+			solana.SystemProgramID,
+		).Build())
+		if err != nil {
+			return solana.PublicKey{}, err
+		}
+	case err != nil:
+		return solana.PublicKey{}, fmt.Errorf("failed to fetch transaction buffer: %w", err)
+	default:
+		if err := checkResumable(existing, createArgs.VaultIndex, message); err != nil {
+			return solana.PublicKey{}, fmt.Errorf("transaction buffer %s: %w; close it or use another buffer index", bufferPDA, err)
+		}
+		uploaded = len(existing.Buffer)
+		log.Printf("Resuming the upload to transaction buffer %s at byte %d of %d", bufferPDA, uploaded, len(message))
+	}
+
+	for uploaded < len(message) {
+		end := min(uploaded+bufferChunkSize, len(message))
+		err := send(squads_multisig_program.NewTransactionBufferExtendInstruction(
+			squads_multisig_program.TransactionBufferExtendArgs{Buffer: message[uploaded:end]},
+			input.Multisig,
+			bufferPDA,
+			creator,
+		).Build())
+		if err != nil {
+			return solana.PublicKey{}, err
+		}
+		uploaded = end
+	}
+
+	buffer, err := accounts.FetchTransactionBuffer(ctx, input.Options, bufferPDA)
+	if err != nil {
+		return solana.PublicKey{}, fmt.Errorf("failed to fetch transaction buffer: %w", err)
+	}
+	if err := verifyBuffer(buffer, message); err != nil {
+		return solana.PublicKey{}, fmt.Errorf("transaction buffer %s: %w", bufferPDA, err)
+	}
+	return bufferPDA, nil
+}
+
+// checkResumable returns an error unless buffer holds the start of message
+// for the same vault, as left by an interrupted upload.
+func checkResumable(buffer *squads_multisig_program.TransactionBuffer, vaultIndex uint8, message []byte) error {
+	if buffer.VaultIndex != vaultIndex || buffer.FinalBufferHash != sha256.Sum256(message) ||
+		int(buffer.FinalBufferSize) != len(message) || !bytes.HasPrefix(message, buffer.Buffer) {
+		return errors.New("holds a different transaction message")
+	}
+	return nil
+}
+
+// verifyBuffer returns an error unless buffer holds exactly message, with the
+// final hash and size the program checks when creating the transaction.
+func verifyBuffer(buffer *squads_multisig_program.TransactionBuffer, message []byte) error {
+	if int(buffer.FinalBufferSize) != len(buffer.Buffer) || len(buffer.Buffer) != len(message) {
+		return fmt.Errorf("holds %d of %d bytes, expected %d", len(buffer.Buffer), buffer.FinalBufferSize, len(message))
+	}
+	if hash := sha256.Sum256(buffer.Buffer); hash != buffer.FinalBufferHash || hash != sha256.Sum256(message) {
+		return errors.New("content does not match the transaction message hash")
+	}
+	return nil
+}
+
+// TransactionBufferCloseInput defines input parameters for closing a
+// transaction buffer
+type TransactionBufferCloseInput struct {
+	// Required inputs
+	Multisig solana.PublicKey
+	Creator  signer.Signer // the member who created the buffer
+
+	// Optional inputs
+	BufferIndex uint8
+	ProgramID   solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
+// TransactionBufferCloseOutput defines return values from closing a
+// transaction buffer
+type TransactionBufferCloseOutput struct {
+	Signature         solana.Signature
+	TransactionBuffer solana.PublicKey
+	Size              int // bytes uploaded before the buffer was abandoned
+}
+
+// CloseTransactionBuffer closes a buffer left by an abandoned upload and
+// refunds its rent to the creator.
+func CloseTransactionBuffer(ctx context.Context, input TransactionBufferCloseInput) (*TransactionBufferCloseOutput, error) {
+	instructions, output, err := buildCloseTransactionBuffer(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := input.SendAndConfirm(ctx, instructions, input.Creator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	output.Signature = result.Signature
+	return output, nil
+}
+
+// PrepareCloseTransactionBuffer builds the close transaction without
+// submitting it. Signatures from offline signers are left empty; see
+// sender.Options.Prepare.
+func PrepareCloseTransactionBuffer(ctx context.Context, input TransactionBufferCloseInput) (*solana.Transaction, *TransactionBufferCloseOutput, error) {
+	instructions, output, err := buildCloseTransactionBuffer(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := input.Prepare(ctx, instructions, input.Creator)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, output, nil
+}
+
+// buildCloseTransactionBuffer checks that the buffer exists and belongs to
+// the creator and returns the close instruction.
+func buildCloseTransactionBuffer(ctx context.Context, input TransactionBufferCloseInput) ([]solana.Instruction, *TransactionBufferCloseOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if input.Creator == nil {
+		return nil, nil, errors.New("creator signer is required")
+	}
+
+	creator := input.Creator.PublicKey()
+	bufferPDA, _, err := pda.TransactionBuffer(input.Multisig, creator, input.BufferIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	buffer, err := accounts.FetchTransactionBuffer(ctx, input.Options, bufferPDA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch transaction buffer: %w", err)
+	}
+	if !buffer.Multisig.Equals(input.Multisig) || !buffer.Creator.Equals(creator) {
+		return nil, nil, fmt.Errorf("transaction buffer %s does not belong to %s on multisig %s", bufferPDA, creator, input.Multisig)
+	}
+
+	closeIx, err := multisig.WithProgramID(squads_multisig_program.NewTransactionBufferCloseInstruction(
+		input.Multisig,
+		bufferPDA,
+		creator,
+	).Build(), input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Printf("Closing transaction buffer %s (%d of %d bytes uploaded)", bufferPDA, len(buffer.Buffer), buffer.FinalBufferSize)
+
+	return []solana.Instruction{closeIx}, &TransactionBufferCloseOutput{
+		TransactionBuffer: bufferPDA,
+		Size:              len(buffer.Buffer),
+	}, nil
+}
@@ -0,0 +1,91 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+)
+
+func TestFitsInTransaction(t *testing.T) {
+	payer, vault := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	fits, err := fitsInTransaction([]solana.Instruction{system.NewTransferInstruction(1, vault, payer).Build()}, payer)
+	require.NoError(t, err)
+	require.True(t, fits)
+
+	// Twenty transfers to distinct recipients compile to a message of well
+	// over a kilobyte.
+	var instructions []solana.Instruction
+	for _, recipient := range newKeys(20) {
+		instructions = append(instructions, system.NewTransferInstruction(1, vault, recipient).Build())
+	}
+	message, err := CreateTransactionMessageBytes(vault, instructions, solana.Hash{}, nil)
+	require.NoError(t, err)
+	require.Less(t, len(message), MaxBufferSize)
+
+	create := squads_multisig_program.NewVaultTransactionCreateInstruction(
+		squads_multisig_program.VaultTransactionCreateArgs{TransactionMessage: message},
+		solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), payer, payer, solana.SystemProgramID,
+	).Build()
+	fits, err = fitsInTransaction([]solana.Instruction{create}, payer)
+	require.NoError(t, err)
+	require.False(t, fits)
+}
+
+func TestTransactionBufferResumesRecompiledMessage(t *testing.T) {
+	vault := solana.NewWallet().PublicKey()
+	var instructions []solana.Instruction
+	for _, recipient := range newKeys(20) {
+		instructions = append(instructions, system.NewTransferInstruction(1, vault, recipient).Build())
+	}
+	message, err := CreateTransactionMessageBytes(vault, instructions, solana.Hash{}, nil)
+	require.NoError(t, err)
+	partial := &squads_multisig_program.TransactionBuffer{
+		FinalBufferHash: sha256.Sum256(message),
+		FinalBufferSize: uint16(len(message)),
+		Buffer:          message[:bufferChunkSize],
+	}
+
+	// A rerun compiles the same instructions again.
+	recompiled, err := CreateTransactionMessageBytes(vault, instructions, solana.Hash{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, message, recompiled)
+	require.NoError(t, checkResumable(partial, 0, recompiled))
+}
+
+func TestTransactionBufferChecks(t *testing.T) {
+	message := make([]byte, 1500)
+	for i := range message {
+		message[i] = byte(i)
+	}
+	partial := &squads_multisig_program.TransactionBuffer{
+		VaultIndex:      1,
+		FinalBufferHash: sha256.Sum256(message),
+		FinalBufferSize: uint16(len(message)),
+		Buffer:          message[:bufferChunkSize],
+	}
+
+	require.NoError(t, checkResumable(partial, 1, message))
+	require.Error(t, checkResumable(partial, 0, message), "other vault")
+	require.Error(t, checkResumable(partial, 1, message[:1400]), "other size and hash")
+
+	tampered := *partial
+	tampered.Buffer = append([]byte{0xff}, message[1:bufferChunkSize]...)
+	require.Error(t, checkResumable(&tampered, 1, message), "content is not a prefix")
+
+	err := verifyBuffer(partial, message)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "holds 600 of 1500 bytes")
+
+	complete := *partial
+	complete.Buffer = message
+	require.NoError(t, verifyBuffer(&complete, message))
+
+	complete.FinalBufferHash[0] ^= 1
+	require.Error(t, verifyBuffer(&complete, message))
+}
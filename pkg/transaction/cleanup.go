@@ -0,0 +1,298 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// CleanupInput defines input parameters for closing the accounts of settled
+// transactions
+type CleanupInput struct {
+	// Required inputs
+	Multisig solana.PublicKey
+	Signer   signer.Signer // pays the fees; closing needs no permission. Not needed for a dry run.
+
+	// Optional inputs
+	DryRun    bool             // find the closable accounts without closing them
+	ProgramID solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
+// ClosableTransaction is a transaction index whose accounts can be closed.
+type ClosableTransaction struct {
+	TransactionIndex uint64
+	Kind             accounts.Kind      // KindVaultTransaction, KindConfigTransaction or KindBatch
+	State            ProposalState      // "" if the proposal was already closed
+	Stale            bool               // at or below the multisig's StaleTransactionIndex
+	Accounts         []solana.PublicKey // every account closed, batch transactions included
+	Lamports         uint64
+}
+
+// CleanupOutput defines return values from closing settled transactions
+type CleanupOutput struct {
+	Signatures    []solana.Signature // empty for a dry run
+	RentCollector solana.PublicKey   // zero if the multisig has none
+	Transactions  []ClosableTransaction
+	Lamports      uint64 // reclaimed, or reclaimable for a dry run
+}
+
+// Cleanup closes the transaction, proposal and batch accounts of every
+// executed, rejected, cancelled or stale transaction of a multisig, sending
+// their rent to its rent collector. The close instructions are packed into as
+// few transactions as fit. Transactions that can still be executed are left
+// alone. With DryRun set, it only reports what would be closed.
+func Cleanup(ctx context.Context, input CleanupInput) (*CleanupOutput, error) {
+	instructions, output, err := buildCleanup(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if input.DryRun || len(instructions) == 0 {
+		return output, nil
+	}
+	if output.RentCollector.IsZero() {
+		return nil, fmt.Errorf("multisig %s has no rent collector; set one with a config transaction before closing accounts", input.Multisig)
+	}
+	if input.Signer == nil {
+		return nil, errors.New("signer is required")
+	}
+
+	groups, err := packInstructions(instructions, input.Payer(input.Signer).PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	for i, group := range groups {
+		result, err := input.SendAndConfirm(ctx, group, input.Signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send cleanup transaction %d of %d (%d sent; run again to resume): %w", i+1, len(groups), i, err)
+		}
+		output.Signatures = append(output.Signatures, result.Signature)
+		log.Printf("✓ Cleanup transaction %d of %d confirmed: %s", i+1, len(groups), result.Signature)
+	}
+	return output, nil
+}
+
+// buildCleanup scans every transaction index of the multisig and returns the
+// close instructions of the closable ones, oldest first. The instructions
+// are built even without a rent collector, so a dry run can still report.
+func buildCleanup(ctx context.Context, input CleanupInput) ([]solana.Instruction, *CleanupOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if input.Multisig.IsZero() {
+		return nil, nil, errors.New("multisig address is required")
+	}
+
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+	}
+	output := &CleanupOutput{}
+	if multisigAccount.RentCollector != nil {
+		output.RentCollector = *multisigAccount.RentCollector
+	}
+
+	var addresses []solana.PublicKey
+	for index := uint64(1); index <= multisigAccount.TransactionIndex; index++ {
+		proposalPDA, _, err := pda.Proposal(input.Multisig, index, input.ProgramID)
+		if err != nil {
+			return nil, nil, err
+		}
+		txPDA, _, err := pda.Transaction(input.Multisig, index, input.ProgramID)
+		if err != nil {
+			return nil, nil, err
+		}
+		addresses = append(addresses, proposalPDA, txPDA)
+	}
+	fetched, err := accounts.FetchMultiple(ctx, input.Options, addresses)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var instructions []solana.Instruction
+	for i := 0; i < len(addresses)/2; i++ {
+		index := uint64(i + 1)
+		proposalPDA, txPDA := addresses[2*i], addresses[2*i+1]
+		proposalAccount, txAccount := fetched[2*i], fetched[2*i+1]
+		if txAccount == nil {
+			// Closed already, or a proposal whose transaction was never
+			// created; the close instructions need the transaction.
+			continue
+		}
+
+		closable := ClosableTransaction{
+			TransactionIndex: index,
+			Stale:            index <= multisigAccount.StaleTransactionIndex,
+		}
+		if proposalAccount != nil {
+			decoded, err := accounts.DecodeAny(proposalAccount.Data.GetBinary())
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to decode proposal %d: %w", index, err)
+			}
+			proposal, ok := decoded.(*squads_multisig_program.Proposal)
+			if !ok {
+				return nil, nil, fmt.Errorf("proposal %d: account is a %T, not a proposal", index, decoded)
+			}
+			closable.State = StateOf(proposal.Status)
+		}
+		decoded, err := accounts.DecodeAny(txAccount.Data.GetBinary())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode transaction %d: %w", index, err)
+		}
+
+		var closeIxs []solana.Instruction
+		switch tx := decoded.(type) {
+		case *squads_multisig_program.VaultTransaction:
+			closable.Kind = accounts.KindVaultTransaction
+			if !canCloseTransaction(closable, proposalAccount != nil) {
+				continue
+			}
+			closeIxs = append(closeIxs, squads_multisig_program.NewVaultTransactionAccountsCloseInstruction(
+				input.Multisig,
+				proposalPDA,
+				txPDA,
+				output.RentCollector,
+				solana.SystemProgramID,
+			).Build())
+		case *squads_multisig_program.ConfigTransaction:
+			closable.Kind = accounts.KindConfigTransaction
+			if !canCloseTransaction(closable, proposalAccount != nil) {
+				continue
+			}
+			closeIxs = append(closeIxs, squads_multisig_program.NewConfigTransactionAccountsCloseInstruction(
+				input.Multisig,
+				proposalPDA,
+				txPDA,
+				output.RentCollector,
+				solana.SystemProgramID,
+			).Build())
+		case *squads_multisig_program.Batch:
+			closable.Kind = accounts.KindBatch
+			if !canCloseTransaction(closable, proposalAccount != nil) {
+				continue
+			}
+			batchIxs, batchTransactions, err := closeBatchTransactions(ctx, input, tx, index, proposalPDA, txPDA, output.RentCollector)
+			if err != nil {
+				return nil, nil, err
+			}
+			closeIxs = append(batchIxs, squads_multisig_program.NewBatchAccountsCloseInstruction(
+				input.Multisig,
+				proposalPDA,
+				txPDA,
+				output.RentCollector,
+				solana.SystemProgramID,
+			).Build())
+			for _, account := range batchTransactions {
+				closable.Lamports += account.lamports
+				closable.Accounts = append(closable.Accounts, account.address)
+			}
+		default:
+			return nil, nil, fmt.Errorf("transaction %d: account is a %T, not a transaction", index, decoded)
+		}
+
+		closable.Accounts = append(closable.Accounts, txPDA)
+		closable.Lamports += txAccount.Lamports
+		if proposalAccount != nil {
+			closable.Accounts = append(closable.Accounts, proposalPDA)
+			closable.Lamports += proposalAccount.Lamports
+		}
+		output.Transactions = append(output.Transactions, closable)
+		output.Lamports += closable.Lamports
+		instructions = append(instructions, closeIxs...)
+	}
+
+	instructions, err = multisig.BindProgramID(instructions, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Printf("Found %d closable transactions holding %d lamports on multisig %s", len(output.Transactions), output.Lamports, input.Multisig)
+
+	return instructions, output, nil
+}
+
+// canCloseTransaction applies the program's rules for closing the accounts of
+// a vault transaction, config transaction or batch. Approved vault
+// transactions and batches can still be executed, even when stale, while a
+// stale config transaction can no longer be. Batches cannot be closed once
+// their proposal is gone.
+func canCloseTransaction(closable ClosableTransaction, hasProposal bool) bool {
+	if !hasProposal {
+		return closable.Kind != accounts.KindBatch && closable.Stale
+	}
+	switch closable.State {
+	case StateRejected, StateExecuted, StateCancelled:
+		return true
+	case StateDraft, StateActive:
+		return closable.Stale
+	case StateApproved:
+		return closable.Kind == accounts.KindConfigTransaction && closable.Stale
+	default:
+		return false
+	}
+}
+
+// batchTransactionAccount is a batch transaction found by
+// closeBatchTransactions.
+type batchTransactionAccount struct {
+	address  solana.PublicKey
+	lamports uint64
+}
+
+// closeBatchTransactions returns the close instructions of the transactions
+// left in a batch. The program only closes the last one, so they run from
+// last to first, leaving the batch empty for its own close.
+func closeBatchTransactions(
+	ctx context.Context,
+	input CleanupInput,
+	batch *squads_multisig_program.Batch,
+	batchIndex uint64,
+	proposalPDA, batchPDA, rentCollector solana.PublicKey,
+) ([]solana.Instruction, []batchTransactionAccount, error) {
+	var addresses []solana.PublicKey
+	for index := batch.Size; index >= 1; index-- {
+		transactionPDA, _, err := pda.BatchTransaction(input.Multisig, batchIndex, index, input.ProgramID)
+		if err != nil {
+			return nil, nil, err
+		}
+		addresses = append(addresses, transactionPDA)
+	}
+	var fetched []*rpc.Account
+	if len(addresses) > 0 {
+		var err error
+		fetched, err = accounts.FetchMultiple(ctx, input.Options, addresses)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	instructions := make([]solana.Instruction, 0, len(addresses))
+	closed := make([]batchTransactionAccount, 0, len(addresses))
+	for i, address := range addresses {
+		if fetched[i] == nil {
+			return nil, nil, fmt.Errorf("batch #%d transaction %d not found", batchIndex, batch.Size-uint32(i))
+		}
+		instructions = append(instructions, squads_multisig_program.NewVaultBatchTransactionAccountCloseInstruction(
+			input.Multisig,
+			proposalPDA,
+			batchPDA,
+			address,
+			rentCollector,
+			solana.SystemProgramID,
+		).Build())
+		closed = append(closed, batchTransactionAccount{address: address, lamports: fetched[i].Lamports})
+	}
+	return instructions, closed, nil
+}
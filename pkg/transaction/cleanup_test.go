@@ -0,0 +1,100 @@
+package transaction
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	ag_binary "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/internal/rpctest"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+func TestBuildCleanup(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	rentCollector := solana.NewWallet().PublicKey()
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		TransactionIndex:      7,
+		StaleTransactionIndex: 4,
+		RentCollector:         &rentCollector,
+	})
+	add := func(index uint64, status squads_multisig_program.ProposalStatus, tx ag_binary.EncoderDecoder) {
+		if status != nil {
+			proposalPDA, _, err := pda.Proposal(multisigPDA, index, solana.PublicKey{})
+			require.NoError(t, err)
+			server.Add(t, proposalPDA, &squads_multisig_program.Proposal{Multisig: multisigPDA, TransactionIndex: index, Status: status})
+		}
+		txPDA, _, err := pda.Transaction(multisigPDA, index, solana.PublicKey{})
+		require.NoError(t, err)
+		server.Add(t, txPDA, tx)
+	}
+	add(1, &squads_multisig_program.ProposalStatusExecuted{}, &squads_multisig_program.VaultTransaction{Index: 1})
+	add(2, &squads_multisig_program.ProposalStatusApproved{}, &squads_multisig_program.VaultTransaction{Index: 2})
+	add(3, &squads_multisig_program.ProposalStatusApproved{}, &squads_multisig_program.ConfigTransaction{Index: 3})
+	add(4, nil, &squads_multisig_program.VaultTransaction{Index: 4})
+	add(5, &squads_multisig_program.ProposalStatusExecuted{}, &squads_multisig_program.Batch{Index: 5, Size: 2, ExecutedTransactionIndex: 2})
+	add(6, &squads_multisig_program.ProposalStatusActive{}, &squads_multisig_program.VaultTransaction{Index: 6})
+	// Index 7 was closed already.
+	var batchTransactions []solana.PublicKey
+	for index := uint32(1); index <= 2; index++ {
+		address, _, err := pda.BatchTransaction(multisigPDA, 5, index, solana.PublicKey{})
+		require.NoError(t, err)
+		server.Add(t, address, &squads_multisig_program.VaultBatchTransaction{})
+		batchTransactions = append(batchTransactions, address)
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	input := CleanupInput{
+		Multisig: multisigPDA,
+		DryRun:   true,
+		Options:  sender.Options{Client: rpc.New(httpServer.URL)},
+	}
+	instructions, output, err := buildCleanup(context.Background(), input)
+	require.NoError(t, err)
+	require.Equal(t, rentCollector, output.RentCollector)
+
+	var indices []uint64
+	for _, closable := range output.Transactions {
+		indices = append(indices, closable.TransactionIndex)
+	}
+	// 2 is approved and can still be executed, 6 is live and 7 is gone.
+	require.Equal(t, []uint64{1, 3, 4, 5}, indices)
+	require.Equal(t, accounts.KindConfigTransaction, output.Transactions[1].Kind)
+	require.Len(t, output.Transactions[2].Accounts, 1, "only the transaction is left without its proposal")
+	require.Len(t, output.Transactions[3].Accounts, 4, "batch transactions, batch and proposal")
+	require.EqualValues(t, 9, output.Lamports, "the fake server gives every account 1 lamport")
+
+	require.Len(t, instructions, 6)
+	batchPDA, _, err := pda.Transaction(multisigPDA, 5, solana.PublicKey{})
+	require.NoError(t, err)
+	// The batch transactions close from last to first, then the batch itself.
+	require.Equal(t, batchTransactions[1], instructions[3].Accounts()[3].PublicKey)
+	require.Equal(t, batchTransactions[0], instructions[4].Accounts()[3].PublicKey)
+	require.Equal(t, batchPDA, instructions[5].Accounts()[2].PublicKey)
+	for _, ix := range instructions {
+		collector := ix.Accounts()[len(ix.Accounts())-2]
+		require.Equal(t, rentCollector, collector.PublicKey)
+		require.True(t, collector.IsWritable)
+	}
+
+	output, err = Cleanup(context.Background(), input)
+	require.NoError(t, err)
+	require.Empty(t, output.Signatures, "a dry run sends nothing")
+
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{TransactionIndex: 7, StaleTransactionIndex: 4})
+	input.DryRun = false
+	_, err = Cleanup(context.Background(), input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no rent collector")
+}
@@ -1,6 +1,8 @@
-package multisigtransaction
+package transaction
 
 import (
+	"sort"
+
 	"github.com/gagliardetto/solana-go"
 	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
 )
@@ -14,6 +16,13 @@ type CompiledKeyMeta struct {
 type CompiledKeys struct {
 	Payer      solana.PublicKey           `json:"payer"`
 	KeyMetaMap map[string]CompiledKeyMeta `json:"keyMetaMap"`
+
+	// keys lists the keys of KeyMetaMap in the order they were first seen,
+	// payer first, like the insertion-ordered Map of the Squads TS SDK. Map
+	// iteration order is random, and the same instructions must always
+	// compile to the same message, or an interrupted buffer upload could not
+	// be resumed.
+	keys []string
 }
 
 type AccountKeysFromLookups struct {
@@ -34,21 +43,36 @@ type MessageAccountKeys struct {
 	AccountKeysFromLookups AccountKeysFromLookups `json:"accountKeysFromLookups"`
 }
 
+// NewCompiledKeys orders the keys of keyMetaMap with the payer first and the
+// rest sorted by address. CompileKeys keeps them in order of appearance.
 func NewCompiledKeys(payer solana.PublicKey, keyMetaMap map[string]CompiledKeyMeta) *CompiledKeys {
+	keys := make([]string, 0, len(keyMetaMap))
+	for address := range keyMetaMap {
+		if address != payer.String() {
+			keys = append(keys, address)
+		}
+	}
+	sort.Strings(keys)
+	if _, ok := keyMetaMap[payer.String()]; ok {
+		keys = append([]string{payer.String()}, keys...)
+	}
 	return &CompiledKeys{
 		Payer:      payer,
 		KeyMetaMap: keyMetaMap,
+		keys:       keys,
 	}
 }
 
 func CompileKeys(instructions []solana.Instruction, payer solana.PublicKey) *CompiledKeys {
 	keyMetaMap := make(map[string]CompiledKeyMeta)
+	var keys []string
 
 	getOrInsertDefault := func(pubkey solana.PublicKey) *CompiledKeyMeta {
 		address := pubkey.String()
 		if keyMeta, exists := keyMetaMap[address]; exists {
 			return &keyMeta
 		}
+		keys = append(keys, address)
 
 		keyMeta := CompiledKeyMeta{
 			IsSigner:   false,
@@ -77,13 +101,22 @@ func CompileKeys(instructions []solana.Instruction, payer solana.PublicKey) *Com
 		}
 	}
 
-	return NewCompiledKeys(payer, keyMetaMap)
+	return &CompiledKeys{
+		Payer:      payer,
+		KeyMetaMap: keyMetaMap,
+		keys:       keys,
+	}
 }
 
 func (ck *CompiledKeys) GetMessageComponents() (solana.MessageHeader, []solana.PublicKey) {
 	var writableSigners, readonlySigners, writableNonSigners, readonlyNonSigners []string
 
-	for address, meta := range ck.KeyMetaMap {
+	for _, address := range ck.keys {
+		meta, ok := ck.KeyMetaMap[address]
+		if !ok {
+			// drained into a lookup table
+			continue
+		}
 		if meta.IsSigner && meta.IsWritable {
 			writableSigners = append(writableSigners, address)
 		} else if meta.IsSigner && !meta.IsWritable {
@@ -161,18 +194,14 @@ func (ck *CompiledKeys) drainKeysFoundInLookupTable(lookupTableEntries []solana.
 	var lookupTableIndexes []uint8
 	var drainedKeys []solana.PublicKey
 
-	for address, keyMeta := range ck.KeyMetaMap {
-		if keyMetaFilter(keyMeta) {
-			key, _ := solana.PublicKeyFromBase58(address)
-
-			for i, entry := range lookupTableEntries {
-				if entry.Equals(key) {
-					lookupTableIndexes = append(lookupTableIndexes, uint8(i))
-					drainedKeys = append(drainedKeys, key)
-					delete(ck.KeyMetaMap, address)
-					break
-				}
-			}
+	// Walk the table in index order so the indexes come out the same on
+	// every compile.
+	for i, entry := range lookupTableEntries {
+		address := entry.String()
+		if keyMeta, ok := ck.KeyMetaMap[address]; ok && keyMetaFilter(keyMeta) {
+			lookupTableIndexes = append(lookupTableIndexes, uint8(i))
+			drainedKeys = append(drainedKeys, entry)
+			delete(ck.KeyMetaMap, address)
 		}
 	}
 
@@ -0,0 +1,337 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// ConfigTransactionCreateInput defines input parameters for proposing a
+// change to the multisig's own settings
+type ConfigTransactionCreateInput struct {
+	// Required inputs
+	Multisig solana.PublicKey
+	Creator  signer.Signer
+	Actions  []squads_multisig_program.ConfigAction
+
+	// Optional inputs
+	Memo        string
+	Draft       bool             // create the proposal as a draft instead of opening it for voting
+	AutoApprove bool             // approve the proposal in the same transaction
+	ProgramID   solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
+// ConfigTransactionCreateOutput defines return values from proposing a config transaction
+type ConfigTransactionCreateOutput struct {
+	Signature        solana.Signature
+	TransactionIndex uint64
+	TransactionPDA   solana.PublicKey
+	ProposalPDA      solana.PublicKey
+	Threshold        uint16
+	TimeLock         uint32
+}
+
+// CreateConfigTransaction creates a config transaction carrying the given
+// actions, creates its proposal and optionally approves it, all in one
+// transaction. Config transactions are how autonomous multisigs (those
+// without a config authority) change their members, threshold, time lock,
+// rent collector and spending limits.
+func CreateConfigTransaction(ctx context.Context, input ConfigTransactionCreateInput) (*ConfigTransactionCreateOutput, error) {
+	instructions, output, err := buildConfigTransaction(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := input.SendAndConfirm(ctx, instructions, input.Creator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	output.Signature = result.Signature
+	return output, nil
+}
+
+// PrepareConfigTransaction builds the create transaction without submitting it.
+// Signatures from offline signers are left empty; see sender.Options.Prepare.
+func PrepareConfigTransaction(ctx context.Context, input ConfigTransactionCreateInput) (*solana.Transaction, *ConfigTransactionCreateOutput, error) {
+	instructions, output, err := buildConfigTransaction(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := input.Prepare(ctx, instructions, input.Creator)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, output, nil
+}
+
+// buildConfigTransaction validates the input and returns the create, proposal
+// and optional approve instructions.
+func buildConfigTransaction(ctx context.Context, input ConfigTransactionCreateInput) ([]solana.Instruction, *ConfigTransactionCreateOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if input.Creator == nil {
+		return nil, nil, errors.New("creator signer is required")
+	}
+	if len(input.Actions) == 0 {
+		return nil, nil, errors.New("at least one config action is required")
+	}
+	for i, action := range input.Actions {
+		if action == nil {
+			return nil, nil, fmt.Errorf("config action %d is nil", i)
+		}
+	}
+	if input.Draft && input.AutoApprove {
+		return nil, nil, errors.New("a draft proposal cannot be approved until it is activated")
+	}
+
+	creator := input.Creator.PublicKey()
+
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+	}
+	if !multisigAccount.ConfigAuthority.IsZero() {
+		return nil, nil, fmt.Errorf("multisig is controlled by config authority %s; config transactions only apply to autonomous multisigs",
+			multisigAccount.ConfigAuthority)
+	}
+	if !hasPermission(multisigAccount, creator, multisig.PermissionPropose) {
+		return nil, nil, fmt.Errorf("%s is not a member of this multisig or doesn't have proposal permission", creator)
+	}
+	if input.AutoApprove && !hasPermission(multisigAccount, creator, multisig.PermissionVote) {
+		return nil, nil, fmt.Errorf("%s doesn't have vote permission and cannot auto-approve", creator)
+	}
+
+	transactionIndex := multisigAccount.TransactionIndex + 1
+	txPDA, _, err := pda.Transaction(input.Multisig, transactionIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposalPDA, _, err := pda.Proposal(input.Multisig, transactionIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rentPayer := input.Payer(input.Creator).PublicKey()
+
+	configTxCreateArgs := squads_multisig_program.ConfigTransactionCreateArgs{
+		Actions: input.Actions,
+	}
+	if input.Memo != "" {
+		configTxCreateArgs.Memo = &input.Memo
+	}
+
+	instructions := []solana.Instruction{
+		squads_multisig_program.NewConfigTransactionCreateInstruction(
+			configTxCreateArgs,
+			input.Multisig,
+			txPDA,
+			creator,
+			rentPayer,
+			solana.SystemProgramID,
+		).Build(),
+		squads_multisig_program.NewProposalCreateInstruction(
+			squads_multisig_program.ProposalCreateArgs{
+				TransactionIndex: transactionIndex,
+				Draft:            input.Draft,
+			},
+			input.Multisig,
+			proposalPDA,
+			creator,
+			rentPayer,
+			solana.SystemProgramID,
+		).Build(),
+	}
+
+	if input.AutoApprove {
+		proposalVoteArgs := squads_multisig_program.ProposalVoteArgs{}
+		if input.Memo != "" {
+			proposalVoteArgs.Memo = &input.Memo
+		}
+		instructions = append(instructions, squads_multisig_program.NewProposalApproveInstruction(
+			proposalVoteArgs,
+			input.Multisig,
+			creator,
+			proposalPDA,
+		).Build())
+	}
+
+	instructions, err = multisig.BindProgramID(instructions, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Printf("Creating config transaction #%d on multisig %s with %d actions", transactionIndex, input.Multisig, len(input.Actions))
+	log.Printf("Transaction PDA: %s", txPDA)
+	log.Printf("Proposal PDA: %s", proposalPDA)
+
+	return instructions, &ConfigTransactionCreateOutput{
+		TransactionIndex: transactionIndex,
+		TransactionPDA:   txPDA,
+		ProposalPDA:      proposalPDA,
+		Threshold:        multisigAccount.Threshold,
+		TimeLock:         multisigAccount.TimeLock,
+	}, nil
+}
+
+// ConfigTransactionExecuteInput defines input parameters for executing a config transaction
+type ConfigTransactionExecuteInput struct {
+	// Required inputs
+	Multisig         solana.PublicKey
+	TransactionIndex uint64
+	Executor         signer.Signer
+
+	// Optional inputs
+	ProgramID solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
+// ConfigTransactionExecuteOutput defines return values from executing a config transaction
+type ConfigTransactionExecuteOutput struct {
+	Signature        solana.Signature
+	TransactionPDA   solana.PublicKey
+	ProposalPDA      solana.PublicKey
+	TransactionIndex uint64
+	Actions          []squads_multisig_program.ConfigAction
+	SpendingLimits   []solana.PublicKey // spending limit accounts created or closed by the actions
+}
+
+// ExecuteConfigTransaction applies the actions of an approved config
+// transaction to the multisig. The fee payer (or the executor) pays for any
+// reallocation of the multisig account and for new spending limit accounts.
+func ExecuteConfigTransaction(ctx context.Context, input ConfigTransactionExecuteInput) (*ConfigTransactionExecuteOutput, error) {
+	instructions, output, err := buildConfigExecute(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := input.SendAndConfirm(ctx, instructions, input.Executor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute config transaction: %w", err)
+	}
+	output.Signature = result.Signature
+	return output, nil
+}
+
+// PrepareConfigExecute builds the execute transaction without submitting it.
+// Signatures from offline signers are left empty; see sender.Options.Prepare.
+func PrepareConfigExecute(ctx context.Context, input ConfigTransactionExecuteInput) (*solana.Transaction, *ConfigTransactionExecuteOutput, error) {
+	instructions, output, err := buildConfigExecute(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := input.Prepare(ctx, instructions, input.Executor)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, output, nil
+}
+
+// buildConfigExecute checks that the config transaction can be executed and
+// returns the execute instruction with its spending limit accounts.
+func buildConfigExecute(ctx context.Context, input ConfigTransactionExecuteInput) ([]solana.Instruction, *ConfigTransactionExecuteOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if input.Executor == nil {
+		return nil, nil, errors.New("executor signer is required")
+	}
+
+	executor := input.Executor.PublicKey()
+	txPDA, _, err := pda.Transaction(input.Multisig, input.TransactionIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposalPDA, _, err := pda.Proposal(input.Multisig, input.TransactionIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+	}
+	proposal, err := accounts.FetchProposal(ctx, input.Options, proposalPDA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch proposal: %w", err)
+	}
+	if err := checkExecutable(multisigAccount, proposal); err != nil {
+		return nil, nil, err
+	}
+	if !hasPermission(multisigAccount, executor, multisig.PermissionExecute) {
+		return nil, nil, fmt.Errorf("executor %s does not have execute permission", executor)
+	}
+
+	configTx, err := accounts.FetchConfigTransaction(ctx, input.Options, txPDA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch config transaction: %w", err)
+	}
+
+	spendingLimits, err := ConfigSpendingLimits(input.Multisig, configTx.Actions, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	executeInstruction := squads_multisig_program.NewConfigTransactionExecuteInstruction(
+		input.Multisig,
+		executor,
+		proposalPDA,
+		txPDA,
+		input.Payer(input.Executor).PublicKey(),
+		solana.SystemProgramID,
+	)
+	for _, spendingLimit := range spendingLimits {
+		executeInstruction.AccountMetaSlice = append(executeInstruction.AccountMetaSlice,
+			solana.NewAccountMeta(spendingLimit, true, false))
+	}
+
+	executeIx, err := multisig.WithProgramID(executeInstruction.Build(), input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.Printf("Executing config transaction #%d on multisig %s with %d actions",
+		input.TransactionIndex, input.Multisig, len(configTx.Actions))
+	log.Printf("Transaction PDA: %s", txPDA)
+	log.Printf("Proposal PDA: %s", proposalPDA)
+
+	return []solana.Instruction{executeIx}, &ConfigTransactionExecuteOutput{
+		TransactionPDA:   txPDA,
+		ProposalPDA:      proposalPDA,
+		TransactionIndex: input.TransactionIndex,
+		Actions:          configTx.Actions,
+		SpendingLimits:   spendingLimits,
+	}, nil
+}
+
+// ConfigSpendingLimits returns the spending limit accounts that executing the
+// actions creates or closes, in action order. ConfigTransactionExecute expects
+// them as writable remaining accounts.
+func ConfigSpendingLimits(multisigPDA solana.PublicKey, actions []squads_multisig_program.ConfigAction, programID solana.PublicKey) ([]solana.PublicKey, error) {
+	var spendingLimits []solana.PublicKey
+	for _, action := range actions {
+		switch action := action.(type) {
+		case *squads_multisig_program.ConfigActionAddSpendingLimit:
+			spendingLimit, _, err := pda.SpendingLimit(multisigPDA, action.CreateKey, programID)
+			if err != nil {
+				return nil, err
+			}
+			spendingLimits = append(spendingLimits, spendingLimit)
+		case *squads_multisig_program.ConfigActionRemoveSpendingLimit:
+			spendingLimits = append(spendingLimits, action.SpendingLimit)
+		}
+	}
+	return spendingLimits, nil
+}
@@ -0,0 +1,103 @@
+package transaction
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/internal/rpctest"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+func TestBuildConfigExecute(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	executor := signer.NewOffline(solana.NewWallet().PublicKey())
+	createKey, removed := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		Threshold:        1,
+		TransactionIndex: 4,
+		Members: []squads_multisig_program.Member{
+			{Key: executor.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}},
+		},
+	})
+	proposalPDA, _, err := pda.Proposal(multisigPDA, 4, solana.PublicKey{})
+	require.NoError(t, err)
+	txPDA, _, err := pda.Transaction(multisigPDA, 4, solana.PublicKey{})
+	require.NoError(t, err)
+	server.Add(t, proposalPDA, &squads_multisig_program.Proposal{
+		Multisig:         multisigPDA,
+		TransactionIndex: 4,
+		Status:           &squads_multisig_program.ProposalStatusApproved{Timestamp: time.Now().Unix()},
+	})
+	server.Add(t, txPDA, &squads_multisig_program.ConfigTransaction{
+		Multisig: multisigPDA,
+		Index:    4,
+		Actions: []squads_multisig_program.ConfigAction{
+			&squads_multisig_program.ConfigActionChangeThreshold{NewThreshold: 1},
+			&squads_multisig_program.ConfigActionAddSpendingLimit{CreateKey: createKey, Amount: 5, Period: squads_multisig_program.PeriodDay},
+			&squads_multisig_program.ConfigActionRemoveSpendingLimit{SpendingLimit: removed},
+		},
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	instructions, output, err := buildConfigExecute(context.Background(), ConfigTransactionExecuteInput{
+		Multisig:         multisigPDA,
+		TransactionIndex: 4,
+		Executor:         executor,
+		Options:          sender.Options{Client: rpc.New(httpServer.URL)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.Actions, 3)
+
+	added, _, err := pda.SpendingLimit(multisigPDA, createKey, solana.PublicKey{})
+	require.NoError(t, err)
+	require.Equal(t, []solana.PublicKey{added, removed}, output.SpendingLimits)
+
+	require.Len(t, instructions, 1)
+	metas := instructions[0].Accounts()
+	require.Len(t, metas, 8)
+	require.Equal(t, executor.PublicKey(), metas[4].PublicKey, "executor pays rent by default")
+	require.True(t, metas[4].IsSigner)
+	require.Equal(t, solana.SystemProgramID, metas[5].PublicKey)
+	for i, spendingLimit := range output.SpendingLimits {
+		require.Equal(t, spendingLimit, metas[6+i].PublicKey)
+		require.True(t, metas[6+i].IsWritable)
+		require.False(t, metas[6+i].IsSigner)
+	}
+}
+
+func TestBuildConfigTransactionRejectsControlledMultisig(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	creator := signer.NewOffline(solana.NewWallet().PublicKey())
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		ConfigAuthority: solana.NewWallet().PublicKey(),
+		Members: []squads_multisig_program.Member{
+			{Key: creator.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}},
+		},
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	_, _, err := buildConfigTransaction(context.Background(), ConfigTransactionCreateInput{
+		Multisig: multisigPDA,
+		Creator:  creator,
+		Actions:  []squads_multisig_program.ConfigAction{&squads_multisig_program.ConfigActionSetTimeLock{NewTimeLock: 60}},
+		Options:  sender.Options{Client: rpc.New(httpServer.URL)},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "autonomous")
+}
@@ -0,0 +1,264 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+// VaultTransactionCreateInput defines input parameters for proposing a vault transaction
+type VaultTransactionCreateInput struct {
+	// Required inputs
+	Multisig     solana.PublicKey
+	Creator      signer.Signer
+	Instructions []solana.Instruction // or BuildInstructions
+
+	// BuildInstructions, if set, is called instead of reading Instructions
+	// once the vault and ephemeral signer addresses are known, so that the
+	// instructions can use the ephemeral signers, e.g. as new accounts.
+	BuildInstructions func(vaultPDA solana.PublicKey, ephemeralSigners []solana.PublicKey) ([]solana.Instruction, error)
+
+	// Optional inputs
+	VaultIndex          uint8
+	Memo                string
+	Draft               bool  // create the proposal as a draft instead of opening it for voting
+	AutoApprove         bool  // approve the proposal in the same transaction
+	EphemeralSigners    uint8 // number of ephemeral signer PDAs the instructions may use as signers
+	AddressLookupTables []addresslookuptable.KeyedAddressLookupTable
+	BufferIndex         uint8            // creator's transaction buffer used when the message is too large for one transaction
+	ProgramID           solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
+// VaultTransactionCreateOutput defines return values from proposing a vault transaction
+type VaultTransactionCreateOutput struct {
+	Signature        solana.Signature
+	TransactionIndex uint64
+	VaultPDA         solana.PublicKey
+	TransactionPDA   solana.PublicKey
+	ProposalPDA      solana.PublicKey
+	EphemeralSigners []solana.PublicKey
+	Threshold        uint16
+	TimeLock         uint32
+
+	// TransactionBuffer is the buffer the message was uploaded through, or
+	// zero if it fit in one transaction. The program closes the buffer when
+	// it creates the vault transaction.
+	TransactionBuffer solana.PublicKey
+}
+
+// vaultTransactionPlan is what buildVaultTransaction works out: the create,
+// proposal and optional approve instructions, and the create arguments
+// carrying the compiled message in case it has to go through a buffer.
+type vaultTransactionPlan struct {
+	instructions []solana.Instruction
+	createArgs   squads_multisig_program.VaultTransactionCreateArgs
+	output       *VaultTransactionCreateOutput
+}
+
+// CreateVaultTransaction wraps the given instructions into a vault transaction,
+// creates its proposal and optionally approves it, all in one transaction.
+//
+// A message too large for that is first uploaded to the creator's
+// transaction buffer at input.BufferIndex, over several transactions; see
+// createFromBuffer.
+func CreateVaultTransaction(ctx context.Context, input VaultTransactionCreateInput) (*VaultTransactionCreateOutput, error) {
+	plan, err := buildVaultTransaction(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	fits, err := fitsInTransaction(plan.instructions, input.Payer(input.Creator).PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	if !fits {
+		return createFromBuffer(ctx, input, plan)
+	}
+
+	result, err := input.SendAndConfirm(ctx, plan.instructions, input.Creator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	plan.output.Signature = result.Signature
+	return plan.output, nil
+}
+
+// PrepareVaultTransaction builds the create transaction without submitting it.
+// Signatures from offline signers are left empty; see sender.Options.Prepare.
+// Messages that need a transaction buffer cannot be prepared this way.
+func PrepareVaultTransaction(ctx context.Context, input VaultTransactionCreateInput) (*solana.Transaction, *VaultTransactionCreateOutput, error) {
+	plan, err := buildVaultTransaction(ctx, input)
+	if err != nil {
+		return nil, nil, err
+	}
+	fits, err := fitsInTransaction(plan.instructions, input.Payer(input.Creator).PublicKey())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !fits {
+		return nil, nil, fmt.Errorf("the %d byte vault transaction message does not fit in one transaction; "+
+			"propose it online to upload it through a transaction buffer", len(plan.createArgs.TransactionMessage))
+	}
+	tx, err := input.Prepare(ctx, plan.instructions, input.Creator)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, plan.output, nil
+}
+
+// buildVaultTransaction validates the input and plans the create, proposal
+// and optional approve instructions.
+func buildVaultTransaction(ctx context.Context, input VaultTransactionCreateInput) (*vaultTransactionPlan, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	if input.Creator == nil {
+		return nil, errors.New("creator signer is required")
+	}
+	if len(input.Instructions) == 0 && input.BuildInstructions == nil {
+		return nil, errors.New("at least one instruction is required")
+	}
+	if input.Draft && input.AutoApprove {
+		return nil, errors.New("a draft proposal cannot be approved until it is activated")
+	}
+
+	creator := input.Creator.PublicKey()
+	vaultPDA, _, err := pda.Vault(input.Multisig, input.VaultIndex, input.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch multisig account to get current transaction index
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+	}
+
+	// Check if the creator is a member with propose permission
+	if !hasPermission(multisigAccount, creator, multisig.PermissionPropose) {
+		return nil, fmt.Errorf("%s is not a member of this multisig or doesn't have proposal permission", creator)
+	}
+	if input.AutoApprove && !hasPermission(multisigAccount, creator, multisig.PermissionVote) {
+		return nil, fmt.Errorf("%s doesn't have vote permission and cannot auto-approve", creator)
+	}
+
+	transactionIndex := multisigAccount.TransactionIndex + 1
+	txPDA, _, err := pda.Transaction(input.Multisig, transactionIndex, input.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the vault and the ephemeral signers can sign inside the vault transaction.
+	ephemeralSigners := make([]solana.PublicKey, 0, input.EphemeralSigners)
+	for i := uint8(0); i < input.EphemeralSigners; i++ {
+		ephemeralSigner, _, err := pda.EphemeralSigner(txPDA, i, input.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		ephemeralSigners = append(ephemeralSigners, ephemeralSigner)
+	}
+	vaultInstructions := input.Instructions
+	if input.BuildInstructions != nil {
+		if vaultInstructions, err = input.BuildInstructions(vaultPDA, ephemeralSigners); err != nil {
+			return nil, fmt.Errorf("failed to build instructions: %w", err)
+		}
+	}
+	if len(vaultInstructions) == 0 {
+		return nil, errors.New("at least one instruction is required")
+	}
+	if err := ValidateVaultSigners(vaultInstructions, vaultPDA, ephemeralSigners); err != nil {
+		return nil, err
+	}
+
+	// Prepare transaction message bytes for the vault transaction.
+	// The inner message never carries a blockhash of its own.
+	txMessageBytes, err := CreateTransactionMessageBytes(vaultPDA, vaultInstructions, solana.Hash{}, input.AddressLookupTables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction message bytes: %w", err)
+	}
+	proposalPDA, _, err := pda.Proposal(input.Multisig, transactionIndex, input.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+	rentPayer := input.Payer(input.Creator).PublicKey()
+
+	vaultTxCreateArgs := squads_multisig_program.VaultTransactionCreateArgs{
+		VaultIndex:         input.VaultIndex,
+		EphemeralSigners:   input.EphemeralSigners,
+		TransactionMessage: txMessageBytes,
+	}
+	if input.Memo != "" {
+		vaultTxCreateArgs.Memo = &input.Memo
+	}
+
+	instructions := []solana.Instruction{
+		squads_multisig_program.NewVaultTransactionCreateInstruction(
+			vaultTxCreateArgs,
+			input.Multisig,
+			txPDA,
+			creator,
+			rentPayer,
+			solana.SystemProgramID,
+		).Build(),
+		squads_multisig_program.NewProposalCreateInstruction(
+			squads_multisig_program.ProposalCreateArgs{
+				TransactionIndex: transactionIndex,
+				Draft:            input.Draft,
+			},
+			input.Multisig,
+			proposalPDA,
+			creator,
+			rentPayer,
+			solana.SystemProgramID,
+		).Build(),
+	}
+
+	if input.AutoApprove {
+		proposalVoteArgs := squads_multisig_program.ProposalVoteArgs{}
+		if input.Memo != "" {
+			proposalVoteArgs.Memo = &input.Memo
+		}
+		instructions = append(instructions, squads_multisig_program.NewProposalApproveInstruction(
+			proposalVoteArgs,
+			input.Multisig,
+			creator,
+			proposalPDA,
+		).Build())
+	}
+
+	instructions, err = multisig.BindProgramID(instructions, input.ProgramID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Creating vault transaction #%d on multisig %s", transactionIndex, input.Multisig)
+	log.Printf("Transaction PDA: %s", txPDA)
+	log.Printf("Proposal PDA: %s", proposalPDA)
+
+	return &vaultTransactionPlan{
+		instructions: instructions,
+		createArgs:   vaultTxCreateArgs,
+		output: &VaultTransactionCreateOutput{
+			TransactionIndex: transactionIndex,
+			VaultPDA:         vaultPDA,
+			TransactionPDA:   txPDA,
+			ProposalPDA:      proposalPDA,
+			EphemeralSigners: ephemeralSigners,
+			Threshold:        multisigAccount.Threshold,
+			TimeLock:         multisigAccount.TimeLock,
+		},
+	}, nil
+}
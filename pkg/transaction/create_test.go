@@ -0,0 +1,84 @@
+package transaction
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/internal/rpctest"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
+)
+
+func TestBuildVaultTransactionEphemeralSigners(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	creator := signer.NewOffline(solana.NewWallet().PublicKey())
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{
+		Threshold:        1,
+		TransactionIndex: 6,
+		Members: []squads_multisig_program.Member{
+			{Key: creator.PublicKey(), Permissions: squads_multisig_program.Permissions{Mask: multisig.PermissionFull}},
+		},
+	})
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	txPDA, _, err := pda.Transaction(multisigPDA, 7, solana.PublicKey{})
+	require.NoError(t, err)
+	var want []solana.PublicKey
+	for i := uint8(0); i < 2; i++ {
+		ephemeralSigner, _, err := pda.EphemeralSigner(txPDA, i, solana.PublicKey{})
+		require.NoError(t, err)
+		want = append(want, ephemeralSigner)
+	}
+
+	input := VaultTransactionCreateInput{
+		Multisig:         multisigPDA,
+		Creator:          creator,
+		EphemeralSigners: 2,
+		BuildInstructions: func(vaultPDA solana.PublicKey, ephemeralSigners []solana.PublicKey) ([]solana.Instruction, error) {
+			require.Equal(t, want, ephemeralSigners)
+			return []solana.Instruction{
+				system.NewCreateAccountInstruction(1, 0, solana.SystemProgramID, vaultPDA, ephemeralSigners[0]).Build(),
+				system.NewCreateAccountInstruction(1, 0, solana.SystemProgramID, vaultPDA, ephemeralSigners[1]).Build(),
+			}, nil
+		},
+		Options: sender.Options{Client: rpc.New(httpServer.URL)},
+	}
+	plan, err := buildVaultTransaction(context.Background(), input)
+	require.NoError(t, err)
+	require.Equal(t, want, plan.output.EphemeralSigners)
+
+	decoded, err := squads_multisig_program.DecodeInstruction(plan.instructions[0].Accounts(), mustData(t, plan.instructions[0]))
+	require.NoError(t, err)
+	create, ok := decoded.Impl.(*squads_multisig_program.VaultTransactionCreate)
+	require.True(t, ok)
+	require.EqualValues(t, 2, create.Args.EphemeralSigners)
+
+	// Without declaring them, the ephemeral signers are foreign signers.
+	input.EphemeralSigners = 1
+	input.BuildInstructions = func(vaultPDA solana.PublicKey, ephemeralSigners []solana.PublicKey) ([]solana.Instruction, error) {
+		return []solana.Instruction{
+			system.NewCreateAccountInstruction(1, 0, solana.SystemProgramID, vaultPDA, want[1]).Build(),
+		}, nil
+	}
+	_, err = buildVaultTransaction(context.Background(), input)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), want[1].String())
+}
+
+func mustData(t *testing.T, ix solana.Instruction) []byte {
+	data, err := ix.Data()
+	require.NoError(t, err)
+	return data
+}
@@ -2,88 +2,125 @@ package transaction
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"time"
 
-	ag_binary "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
-	"github.com/gagliardetto/solana-go/rpc"
-	"github.com/gagliardetto/solana-go/rpc/ws"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
 
 	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
 	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+	"github.com/hogyzen12/squads-go/pkg/signer"
 )
 
+// ProposalExecuteInput defines input parameters for executing a proposal
+type ProposalExecuteInput struct {
+	// Required inputs
+	Multisig         solana.PublicKey
+	TransactionIndex uint64
+	Executor         signer.Signer
+
+	// Optional inputs
+	ProgramID solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
 // ProposalExecuteOutput defines return values from executing a proposal
 type ProposalExecuteOutput struct {
 	Signature        string
 	TransactionPDA   solana.PublicKey
 	ProposalPDA      solana.PublicKey
 	TransactionIndex uint64
+	// AddressLookupTables are the tables the vault transaction loads accounts
+	// from; the execute transaction uses them too to stay within size limits.
+	AddressLookupTables []addresslookuptable.KeyedAddressLookupTable
 }
 
 // ExecuteProposal executes an approved proposal that has passed its timelock
-func ExecuteProposal(ctx context.Context,
-	multisigPDA solana.PublicKey,
-	transactionIndex uint64,
-	executor solana.PrivateKey,
-	client *rpc.Client,
-	wsClient *ws.Client) (*ProposalExecuteOutput, error) {
-
+func ExecuteProposal(ctx context.Context, input ProposalExecuteInput) (*ProposalExecuteOutput, error) {
 	log.Println("Executing approved proposal...")
 
-	// Create clients if not provided
-	if client == nil {
-		client = rpc.New("https://api.mainnet-beta.solana.com")
+	instructions, output, err := buildExecute(ctx, input)
+	if err != nil {
+		return nil, err
 	}
 
-	var wsClientCreated bool
-	if wsClient == nil {
-		var err error
-		wsClient, err = ws.Connect(ctx, "wss://api.mainnet-beta.solana.com")
-		if err != nil {
-			return nil, fmt.Errorf("failed to connect to WebSocket: %w", err)
-		}
-		wsClientCreated = true
-		defer func() {
-			if wsClientCreated {
-				wsClient.Close()
-			}
-		}()
+	if input.AddressTables == nil {
+		input.AddressTables = AddressTables(output.AddressLookupTables)
 	}
 
-	// Calculate transaction and proposal PDAs
-	txPDA, _ := multisig.GetTransactionPDA(multisigPDA, transactionIndex)
-	proposalPDA, _ := multisig.GetProposalPDA(multisigPDA, transactionIndex)
+	// Send transaction and wait for it to land
+	result, err := input.SendAndConfirm(ctx, instructions, input.Executor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute transaction: %w", err)
+	}
+	output.Signature = result.Signature.String()
 
-	// Fetch the multisig account
-	multisigAccount, err := fetchMultisigAccount(client, multisigPDA)
+	log.Printf("✓ Execution transaction landed: %s", result.Signature)
+
+	return output, nil
+}
+
+// PrepareExecute builds the execute transaction without submitting it.
+// Signatures from offline signers are left empty; see sender.Options.Prepare.
+func PrepareExecute(ctx context.Context, input ProposalExecuteInput) (*solana.Transaction, *ProposalExecuteOutput, error) {
+	instructions, output, err := buildExecute(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+		return nil, nil, err
+	}
+	if input.AddressTables == nil {
+		input.AddressTables = AddressTables(output.AddressLookupTables)
 	}
+	tx, err := input.Prepare(ctx, instructions, input.Executor)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, output, nil
+}
 
-	// Fetch the proposal account
-	proposal, err := fetchProposalAccount(client, proposalPDA)
+// buildExecute checks that the proposal can be executed and returns the
+// execute instruction.
+func buildExecute(ctx context.Context, input ProposalExecuteInput) ([]solana.Instruction, *ProposalExecuteOutput, error) {
+	if err := input.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if input.Executor == nil {
+		return nil, nil, errors.New("executor signer is required")
+	}
+
+	multisigPDA := input.Multisig
+	transactionIndex := input.TransactionIndex
+	executor := input.Executor
+
+	// Calculate transaction and proposal PDAs
+	txPDA, _, err := pda.Transaction(multisigPDA, transactionIndex, input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposalPDA, _, err := pda.Proposal(multisigPDA, transactionIndex, input.ProgramID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch proposal: %w", err)
+		return nil, nil, err
 	}
 
-	// Check if the proposal is approved
-	_, isApproved := proposal.Status.(*squads_multisig_program.ProposalStatusApproved)
-	if !isApproved {
-		return nil, fmt.Errorf("proposal is not in approved state, current status: %s",
-			getProposalStatusString(proposal.Status))
+	// Fetch the multisig account
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, multisigPDA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch multisig account: %w", err)
 	}
 
-	// Check if timelock has elapsed
-	approvedStatus := proposal.Status.(*squads_multisig_program.ProposalStatusApproved)
-	approvalTime := time.Unix(approvedStatus.Timestamp, 0)
-	timelockEnd := approvalTime.Add(time.Duration(multisigAccount.TimeLock) * time.Second)
+	// Fetch the proposal account
+	proposal, err := accounts.FetchProposal(ctx, input.Options, proposalPDA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch proposal: %w", err)
+	}
 
-	if time.Now().Before(timelockEnd) && multisigAccount.TimeLock > 0 {
-		return nil, fmt.Errorf("timelock has not elapsed yet. Executable after: %s",
-			timelockEnd.Format("2006-01-02 15:04:05"))
+	if err := checkExecutable(multisigAccount, proposal); err != nil {
+		return nil, nil, err
 	}
 
 	// Check if the executor has execute permission
@@ -98,24 +135,13 @@ func ExecuteProposal(ctx context.Context,
 	}
 
 	if !hasExecutePermission {
-		return nil, fmt.Errorf("executor %s does not have execute permission", executor.PublicKey())
+		return nil, nil, fmt.Errorf("executor %s does not have execute permission", executor.PublicKey())
 	}
 
 	// Fetch the transaction account
-	txAccountInfo, err := client.GetAccountInfo(ctx, txPDA)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction account: %w", err)
-	}
-	if txAccountInfo.Value == nil || len(txAccountInfo.Value.Data.GetBinary()) < 8 {
-		return nil, fmt.Errorf("transaction account not found or has invalid data: %s", txPDA)
-	}
-
-	// Decode the vault transaction
-	var vaultTx squads_multisig_program.VaultTransaction
-	decoder := ag_binary.NewBorshDecoder(txAccountInfo.Value.Data.GetBinary())
-	err = vaultTx.UnmarshalWithDecoder(decoder)
+	vaultTx, err := accounts.FetchVaultTransaction(ctx, input.Options, txPDA)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode vault transaction: %w", err)
+		return nil, nil, err
 	}
 
 	// Log the transaction message for debugging
@@ -123,12 +149,27 @@ func ExecuteProposal(ctx context.Context,
 
 	// Check if there are instructions
 	if len(vaultTx.Message.Instructions) == 0 {
-		return nil, fmt.Errorf("transaction has no instructions and cannot be executed")
+		return nil, nil, fmt.Errorf("transaction has no instructions and cannot be executed")
 	}
 
-	// Extract additional accounts dynamically
-	additionalAccounts := vaultTx.Message.AccountKeys
-	log.Printf("Additional accounts required: %v", additionalAccounts)
+	// Load the lookup tables the vault transaction message refers to
+	lookupTableKeys := make([]solana.PublicKey, 0, len(vaultTx.Message.AddressTableLookups))
+	for _, lookup := range vaultTx.Message.AddressTableLookups {
+		lookupTableKeys = append(lookupTableKeys, lookup.AccountKey)
+	}
+	lookupTables, err := FetchAddressLookupTables(ctx, input.Options, lookupTableKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	programSigners, err := VaultTransactionSigners(multisigPDA, txPDA, vaultTx.VaultIndex, len(vaultTx.EphemeralSignerBumps), input.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	additionalAccounts, err := VaultExecuteAccounts(&vaultTx.Message, programSigners, lookupTables)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Build the VaultTransactionExecute instruction with base accounts
 	executeInstruction := squads_multisig_program.NewVaultTransactionExecuteInstructionBuilder().
@@ -136,73 +177,23 @@ func ExecuteProposal(ctx context.Context,
 		SetProposalAccount(proposalPDA).
 		SetTransactionAccount(txPDA).
 		SetMemberAccount(executor.PublicKey())
+	executeInstruction.AccountMetaSlice = append(executeInstruction.AccountMetaSlice, additionalAccounts...)
 
-	// Append additional accounts with dynamic properties
-	for i, accountKey := range additionalAccounts {
-		isWritable := false
-		// Determine if the account is writable based on the message structure
-		if i < int(vaultTx.Message.NumWritableSigners) {
-			isWritable = true // Writable signer
-		} else if (i - int(vaultTx.Message.NumSigners)) < int(vaultTx.Message.NumWritableNonSigners) {
-			isWritable = true // Writable non-signer
-		}
-		// Additional accounts are typically not signers in multisig execution
-		executeInstruction.AccountMetaSlice = append(executeInstruction.AccountMetaSlice,
-			solana.NewAccountMeta(accountKey, isWritable, false))
+	executeIx, err := multisig.WithProgramID(executeInstruction.Build(), input.ProgramID)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	executeIx := executeInstruction.Build()
-
 	// Log transaction details
 	log.Printf("Executing vault transaction #%d on multisig %s with %d additional accounts",
 		transactionIndex, multisigPDA, len(additionalAccounts))
 	log.Printf("Transaction PDA: %s", txPDA)
 	log.Printf("Proposal PDA: %s", proposalPDA)
 
-	// Get latest blockhash
-	hash, err := client.GetLatestBlockhash(ctx, rpc.CommitmentFinalized)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get latest blockhash: %w", err)
-	}
-
-	// Create transaction
-	tx, err := solana.NewTransaction(
-		[]solana.Instruction{executeIx},
-		hash.Value.Blockhash,
-		solana.TransactionPayer(executor.PublicKey()),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create execution transaction: %w", err)
-	}
-
-	// Sign transaction
-	_, err = tx.Sign(
-		func(key solana.PublicKey) *solana.PrivateKey {
-			if key.Equals(executor.PublicKey()) {
-				return &executor
-			}
-			return nil
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
-	}
-
-	// Send transaction
-	sig, err := client.SendTransaction(ctx, tx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute transaction: %w", err)
-	}
-
-	output := &ProposalExecuteOutput{
-		Signature:        sig.String(),
-		TransactionPDA:   txPDA,
-		ProposalPDA:      proposalPDA,
-		TransactionIndex: transactionIndex,
-	}
-
-	log.Printf("✓ Successfully submitted execution transaction: %s", sig)
-	log.Printf("Transaction may take a few seconds to confirm.")
-
-	return output, nil
+	return []solana.Instruction{executeIx}, &ProposalExecuteOutput{
+		TransactionPDA:      txPDA,
+		ProposalPDA:         proposalPDA,
+		TransactionIndex:    transactionIndex,
+		AddressLookupTables: lookupTables,
+	}, nil
 }
@@ -0,0 +1,118 @@
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+)
+
+// VaultTransactionSigners returns the keys the program signs for when it
+// executes a vault or batch transaction: the vault, then the transaction's
+// ephemeral signers in index order.
+func VaultTransactionSigners(
+	multisigPDA, transactionPDA solana.PublicKey,
+	vaultIndex uint8,
+	ephemeralSigners int,
+	programID solana.PublicKey,
+) ([]solana.PublicKey, error) {
+	vaultPDA, _, err := pda.Vault(multisigPDA, vaultIndex, programID)
+	if err != nil {
+		return nil, err
+	}
+	signers := []solana.PublicKey{vaultPDA}
+	for i := 0; i < ephemeralSigners; i++ {
+		ephemeralSigner, _, err := pda.EphemeralSigner(transactionPDA, uint8(i), programID)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, ephemeralSigner)
+	}
+	return signers, nil
+}
+
+// VaultExecuteAccounts turns a vault transaction message into the remaining
+// accounts VaultTransactionExecute validates, in the order it expects:
+//
+//  1. the message's address lookup tables, readonly;
+//  2. its static account keys, writable as the message header says;
+//  3. the addresses loaded from the tables, every writable one first and
+//     then every readonly one, each group in lookup order.
+//
+// Message signers are passed as signers, except programSigners (see
+// VaultTransactionSigners), which the program signs for itself.
+// lookupTables must match the message's lookups one to one.
+func VaultExecuteAccounts(
+	message *squads_multisig_program.VaultTransactionMessage,
+	programSigners []solana.PublicKey,
+	lookupTables []addresslookuptable.KeyedAddressLookupTable,
+) ([]*solana.AccountMeta, error) {
+	if len(lookupTables) != len(message.AddressTableLookups) {
+		return nil, fmt.Errorf("message uses %d lookup tables, got %d", len(message.AddressTableLookups), len(lookupTables))
+	}
+	if int(message.NumWritableSigners) > int(message.NumSigners) || int(message.NumSigners) > len(message.AccountKeys) ||
+		int(message.NumWritableNonSigners) > len(message.AccountKeys)-int(message.NumSigners) {
+		return nil, fmt.Errorf("invalid message header: %d signers (%d writable), %d writable non-signers, %d account keys",
+			message.NumSigners, message.NumWritableSigners, message.NumWritableNonSigners, len(message.AccountKeys))
+	}
+
+	signedByProgram := make(map[solana.PublicKey]bool, len(programSigners))
+	for _, key := range programSigners {
+		signedByProgram[key] = true
+	}
+
+	out := make([]*solana.AccountMeta, 0, len(lookupTables)+len(message.AccountKeys))
+	for i, lookup := range message.AddressTableLookups {
+		if !lookupTables[i].Key.Equals(lookup.AccountKey) {
+			return nil, fmt.Errorf("lookup table %d is %s, expected %s", i, lookupTables[i].Key, lookup.AccountKey)
+		}
+		out = append(out, solana.NewAccountMeta(lookup.AccountKey, false, false))
+	}
+
+	for i, key := range message.AccountKeys {
+		isSigner := isSignerIndex(message, i) && !signedByProgram[key]
+		out = append(out, solana.NewAccountMeta(key, isStaticWritableIndex(message, i), isSigner))
+	}
+
+	for _, isWritable := range []bool{true, false} {
+		for i, lookup := range message.AddressTableLookups {
+			indexes := lookup.ReadonlyIndexes
+			if isWritable {
+				indexes = lookup.WritableIndexes
+			}
+			addresses := lookupTables[i].State.Addresses
+			for _, index := range indexes {
+				if int(index) >= len(addresses) {
+					return nil, fmt.Errorf("lookup table %s has no address at index %d", lookup.AccountKey, index)
+				}
+				out = append(out, solana.NewAccountMeta(addresses[index], isWritable, false))
+			}
+		}
+	}
+	return out, nil
+}
+
+// isSignerIndex reports whether the static key at index i signs the message.
+func isSignerIndex(message *squads_multisig_program.VaultTransactionMessage, i int) bool {
+	return i < int(message.NumSigners)
+}
+
+// isStaticWritableIndex mirrors the program's check of whether the static
+// key at index i is writable: writable signers come first, and writable
+// non-signers directly follow the signers.
+func isStaticWritableIndex(message *squads_multisig_program.VaultTransactionMessage, i int) bool {
+	numSigners := int(message.NumSigners)
+	switch {
+	case i >= len(message.AccountKeys):
+		return false
+	case i < int(message.NumWritableSigners):
+		return true
+	case i >= numSigners:
+		return i-numSigners < int(message.NumWritableNonSigners)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,270 @@
+package transaction
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+)
+
+// meta is an expected remaining account: key, writable, signer.
+type meta struct {
+	key      solana.PublicKey
+	writable bool
+	signer   bool
+}
+
+func TestVaultExecuteAccounts(t *testing.T) {
+	vault, ephemeral, cosigner := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	recipient, readonly, program := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	first, second := newKeys(4), newKeys(4)
+	tables := []addresslookuptable.KeyedAddressLookupTable{
+		{Key: solana.NewWallet().PublicKey(), State: addresslookuptable.AddressLookupTableState{Addresses: first}},
+		{Key: solana.NewWallet().PublicKey(), State: addresslookuptable.AddressLookupTableState{Addresses: second}},
+	}
+	programSigners := []solana.PublicKey{vault, ephemeral}
+
+	tests := []struct {
+		name    string
+		message squads_multisig_program.VaultTransactionMessage
+		tables  []addresslookuptable.KeyedAddressLookupTable
+		want    []meta
+		wantErr string
+	}{
+		{
+			name: "SOL transfer",
+			message: squads_multisig_program.VaultTransactionMessage{
+				NumSigners: 1, NumWritableSigners: 1, NumWritableNonSigners: 1,
+				AccountKeys: []solana.PublicKey{vault, recipient, solana.SystemProgramID},
+			},
+			want: []meta{{vault, true, false}, {recipient, true, false}, {solana.SystemProgramID, false, false}},
+		},
+		{
+			name: "readonly ephemeral signer is not writable",
+			message: squads_multisig_program.VaultTransactionMessage{
+				NumSigners: 2, NumWritableSigners: 1, NumWritableNonSigners: 1,
+				AccountKeys: []solana.PublicKey{vault, ephemeral, recipient, program},
+			},
+			want: []meta{{vault, true, false}, {ephemeral, false, false}, {recipient, true, false}, {program, false, false}},
+		},
+		{
+			name: "no writable non-signers",
+			message: squads_multisig_program.VaultTransactionMessage{
+				NumSigners: 2, NumWritableSigners: 2, NumWritableNonSigners: 0,
+				AccountKeys: []solana.PublicKey{vault, ephemeral, readonly, program},
+			},
+			want: []meta{{vault, true, false}, {ephemeral, true, false}, {readonly, false, false}, {program, false, false}},
+		},
+		{
+			name: "other signers must sign the execute transaction",
+			message: squads_multisig_program.VaultTransactionMessage{
+				NumSigners: 2, NumWritableSigners: 1, NumWritableNonSigners: 0,
+				AccountKeys: []solana.PublicKey{vault, cosigner, program},
+			},
+			want: []meta{{vault, true, false}, {cosigner, false, true}, {program, false, false}},
+		},
+		{
+			name: "lookup tables",
+			message: squads_multisig_program.VaultTransactionMessage{
+				NumSigners: 1, NumWritableSigners: 1, NumWritableNonSigners: 1,
+				AccountKeys: []solana.PublicKey{vault, recipient, program},
+				AddressTableLookups: []squads_multisig_program.MultisigMessageAddressTableLookup{
+					{AccountKey: tables[0].Key, WritableIndexes: []byte{2}, ReadonlyIndexes: []byte{0, 3}},
+					{AccountKey: tables[1].Key, WritableIndexes: []byte{1}, ReadonlyIndexes: []byte{}},
+				},
+			},
+			tables: tables,
+			want: []meta{
+				{tables[0].Key, false, false}, {tables[1].Key, false, false},
+				{vault, true, false}, {recipient, true, false}, {program, false, false},
+				{first[2], true, false}, {second[1], true, false},
+				{first[0], false, false}, {first[3], false, false},
+			},
+		},
+		{
+			name: "missing lookup table",
+			message: squads_multisig_program.VaultTransactionMessage{
+				NumSigners: 1, NumWritableSigners: 1,
+				AccountKeys: []solana.PublicKey{vault, program},
+				AddressTableLookups: []squads_multisig_program.MultisigMessageAddressTableLookup{
+					{AccountKey: tables[0].Key, WritableIndexes: []byte{0}},
+				},
+			},
+			wantErr: "uses 1 lookup tables, got 0",
+		},
+		{
+			name: "lookup tables out of order",
+			message: squads_multisig_program.VaultTransactionMessage{
+				NumSigners: 1, NumWritableSigners: 1,
+				AccountKeys: []solana.PublicKey{vault, program},
+				AddressTableLookups: []squads_multisig_program.MultisigMessageAddressTableLookup{
+					{AccountKey: tables[1].Key}, {AccountKey: tables[0].Key},
+				},
+			},
+			tables:  tables,
+			wantErr: "lookup table 0 is",
+		},
+		{
+			name: "lookup index out of range",
+			message: squads_multisig_program.VaultTransactionMessage{
+				NumSigners: 1, NumWritableSigners: 1,
+				AccountKeys: []solana.PublicKey{vault, program},
+				AddressTableLookups: []squads_multisig_program.MultisigMessageAddressTableLookup{
+					{AccountKey: tables[0].Key, ReadonlyIndexes: []byte{9}},
+				},
+			},
+			tables:  tables[:1],
+			wantErr: "no address at index 9",
+		},
+		{
+			name: "header exceeds account keys",
+			message: squads_multisig_program.VaultTransactionMessage{
+				NumSigners: 1, NumWritableSigners: 1, NumWritableNonSigners: 2,
+				AccountKeys: []solana.PublicKey{vault, program},
+			},
+			wantErr: "invalid message header",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VaultExecuteAccounts(&tt.message, programSigners, tt.tables)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i, w := range tt.want {
+				require.Equal(t, w.key, got[i].PublicKey, "account %d", i)
+				require.Equal(t, w.writable, got[i].IsWritable, "account %d writable", i)
+				require.Equal(t, w.signer, got[i].IsSigner, "account %d signer", i)
+			}
+		})
+	}
+}
+
+// TestVaultExecuteAccountsMatchesRuntime checks the writable flags against
+// solana-go's own reading of a v0 message compiled the way proposals are.
+func TestVaultExecuteAccountsMatchesRuntime(t *testing.T) {
+	vault := solana.NewWallet().PublicKey()
+	keys := newKeys(6)
+	table := addresslookuptable.KeyedAddressLookupTable{
+		Key:   solana.NewWallet().PublicKey(),
+		State: addresslookuptable.AddressLookupTableState{Addresses: keys[3:]},
+	}
+	instructions := []solana.Instruction{
+		system.NewTransferInstruction(1, vault, keys[0]).Build(),
+		solana.NewInstruction(keys[1], solana.AccountMetaSlice{
+			solana.NewAccountMeta(vault, false, true),
+			solana.NewAccountMeta(keys[2], false, false),
+			solana.NewAccountMeta(keys[3], true, false),
+			solana.NewAccountMeta(keys[4], false, false),
+			solana.NewAccountMeta(keys[5], true, false),
+		}, nil),
+	}
+
+	compiled := CompileToWrappedMessageV0(vault, solana.Hash{}, instructions, []addresslookuptable.KeyedAddressLookupTable{table})
+	require.NoError(t, compiled.SetAddressTables(AddressTables([]addresslookuptable.KeyedAddressLookupTable{table})))
+
+	message := squads_multisig_program.VaultTransactionMessage{
+		NumSigners:            uint8(compiled.Header.NumRequiredSignatures),
+		NumWritableSigners:    uint8(compiled.Header.NumRequiredSignatures - compiled.Header.NumReadonlySignedAccounts),
+		NumWritableNonSigners: uint8(len(compiled.AccountKeys)) - compiled.Header.NumRequiredSignatures - compiled.Header.NumReadonlyUnsignedAccounts,
+		AccountKeys:           compiled.AccountKeys,
+	}
+	for _, lookup := range compiled.AddressTableLookups {
+		message.AddressTableLookups = append(message.AddressTableLookups, squads_multisig_program.MultisigMessageAddressTableLookup{
+			AccountKey:      lookup.AccountKey,
+			WritableIndexes: lookup.WritableIndexes,
+			ReadonlyIndexes: lookup.ReadonlyIndexes,
+		})
+	}
+
+	got, err := VaultExecuteAccounts(&message, []solana.PublicKey{vault}, []addresslookuptable.KeyedAddressLookupTable{table})
+	require.NoError(t, err)
+
+	want, err := compiled.AccountMetaList()
+	require.NoError(t, err)
+	got = got[1:] // the lookup table itself
+	require.Len(t, got, len(want))
+	for i := range want {
+		require.Equal(t, want[i].PublicKey, got[i].PublicKey, "account %d", i)
+		require.Equal(t, want[i].IsWritable, got[i].IsWritable, "account %d writable", i)
+		require.False(t, got[i].IsSigner, "account %d signer", i)
+	}
+}
+
+// TestVaultExecuteAccountsFixture resolves a stored v0 vault transaction
+// that loads accounts from two lookup tables, starting from the account
+// bytes as fetched, and checks the result against a fixed account list.
+// The vault and the first ephemeral signer are writable, the second
+// ephemeral signer is a readonly signer, and the message has one writable
+// and two readonly static non-signers.
+func TestVaultExecuteAccountsFixture(t *testing.T) {
+	const (
+		vaultTransactionData = "qPqiZFEOos/1aJAzv7ivO4wb9kH2ZoWd6ZzoTS1pT0P8maG+UV7bc/wY8x7qf58iGUmJZzXONjNNWRtNNqfjrli/woXCfZRVAQAAAAAAAAD8AP4CAAAA//4DAgEGAAAANZUTLMk9Bi3RCJ2er0XnT0VUV/UBhDXJQ2LZEyWkVNq3wfgY/YuCq53y4+3W12q1G242TCeOtfqiCSzKc7z+NRrozvfNifHiluF22CCDQ/J90TW+cVpkGyhqwBmhPx0Ig24sAos01g3+0gPyNDjDUx8oSuillJwz9ZfZUvdZdpgG3fbh12Whk9nL4UbO63msHLSF7V9bN5E6jPWFfv8AqVtuIjliIfrfOQOPVQy7NHJIhW7OY85uaYYs76OVWdyjAgAAAAUJAAAAAAECAwYHCAkKAwAAAAECAwQDAAAAAwYAAQAAAAwCAAAAqyAobW/XVy93LbghNnsNxp2giC3iRZnkKwnHD5vvVSgBAAAAAwEAAAAAqKVJ+x6jVhMLNT8KqEQ9HwA1HpUpRiqoOVACkl3DxgMCAAAAAQIBAAAABA=="
+		tableAData           = "AQAAAP//////////gLLmDgAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAB5j8ratCRMVFNB7x+o53kWg4RRTKcTeVbaiWPov5zizuOWLaft9BLxRB+rIwxlE2pPDM+O8AiTVfCl8qx+87WFBjOePtKRHkiPrSn1H1ni+oKq0BGlm2j8DnmgP3jXXszKophNoesCUPSYsbb1/U5pFVxpuO2jAV4iGrg0tggZYQ=="
+		tableBData           = "AQAAAP//////////gLLmDgAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAACKyPvPgDpeFut1tCVrAZLl9lnqp/qPunFZV+vSp0yelWWQ3wfdFUl+TJCjvdx9f39tHo89KN4gd7ysnEj1p0f/pvLAkj6469h7B5wqeGjTTOSJ1IA8X+ebv1zQ7C8dyy7xmsh7s40h8/rjd2XmkoEiOLUrzj1Fb98NiGnMxk9LriEbcd5kFftZcP/1VOMyY24mcCa2HLRye5HYtBipgfqB"
+	)
+	multisigPDA := solana.MustPublicKeyFromBase58("HWyN1dHNhQZe6NBFR17uAVhHDTq8zHWs7jRKNpBSzGdx")
+	txPDA := solana.MustPublicKeyFromBase58("FsBz2HrYV8ULUSgpWDF11PDwPzueN7YRDzBJx5yLgPaW")
+	tableA := solana.MustPublicKeyFromBase58("CX1A6xmA6SknWu7C5yD5msfgbsv8z6gtarZ5gtYocnDd")
+	tableB := solana.MustPublicKeyFromBase58("CMKgBs2FPjA7E447AyuK9psMnCYwXqApZtXtaQEyFqYn")
+
+	data, err := base64.StdEncoding.DecodeString(vaultTransactionData)
+	require.NoError(t, err)
+	decoded, err := accounts.DecodeAny(data)
+	require.NoError(t, err)
+	vaultTx, ok := decoded.(*squads_multisig_program.VaultTransaction)
+	require.True(t, ok, "got %T", decoded)
+
+	var tables []addresslookuptable.KeyedAddressLookupTable
+	for _, table := range []struct {
+		key  solana.PublicKey
+		data string
+	}{{tableA, tableAData}, {tableB, tableBData}} {
+		data, err := base64.StdEncoding.DecodeString(table.data)
+		require.NoError(t, err)
+		state, err := addresslookuptable.DecodeAddressLookupTableState(data)
+		require.NoError(t, err)
+		tables = append(tables, addresslookuptable.KeyedAddressLookupTable{Key: table.key, State: *state})
+	}
+
+	signers, err := VaultTransactionSigners(multisigPDA, txPDA, vaultTx.VaultIndex, len(vaultTx.EphemeralSignerBumps), solana.PublicKey{})
+	require.NoError(t, err)
+	got, err := VaultExecuteAccounts(&vaultTx.Message, signers, tables)
+	require.NoError(t, err)
+
+	want := []meta{
+		// lookup tables
+		{tableA, false, false},
+		{tableB, false, false},
+		// static keys: the vault and ephemeral signers are signed for by the program
+		{solana.MustPublicKeyFromBase58("4cAT8kfXJWeMUMUYesn1eXAEyKqNkrzUKHGLJGjGZnYH"), true, false},
+		{solana.MustPublicKeyFromBase58("DNKA3hGqZKC7tUn1uVW289N4oZ3HP46PpJmKdcRjvwc8"), true, false},
+		{solana.MustPublicKeyFromBase58("2p3VqMMg6BJeyTw54cQBYMp3LsVjxTW3tQqUyrhDv8tw"), false, false},
+		{solana.MustPublicKeyFromBase58("9r3r1bmovqqmcvSZEEc6egvkb9DFZhK7SAZxG8rwJJi3"), true, false},
+		{solana.TokenProgramID, false, false},
+		{solana.MustPublicKeyFromBase58("79uWC4bUTyJHbTN2RPPQBGKmpL4yinvPABBB8ybpmcYa"), false, false},
+		// writable lookups: table A index 3, table B indexes 1 and 2
+		{solana.MustPublicKeyFromBase58("Ee19yX6xs7zJM3BgXcfETNANQZTmvWyUyUBjHX5DDt3a"), true, false},
+		{solana.MustPublicKeyFromBase58("7qUJXyFnUq5rgo1CeaHigBgdBYhjZXZ9B5Nz3FM46w2v"), true, false},
+		{solana.MustPublicKeyFromBase58("CEhNQmBnFUa67pBrMt2gpvi2hkP8zupSALyc8ebpo1qo"), true, false},
+		// readonly lookups: table A index 0, table B index 4
+		{solana.MustPublicKeyFromBase58("9BXW8Fint5By3FzzC7tEDZixGhAckSbBX8LjtssZ7Qe1"), false, false},
+		{solana.MustPublicKeyFromBase58("3EEj8RqSxtxVDGWN37doxY5GMyoFHMpoxuaBp7QS1doN"), false, false},
+	}
+	require.Len(t, got, len(want))
+	for i, w := range want {
+		require.Equal(t, w.key, got[i].PublicKey, "account %d", i)
+		require.Equal(t, w.writable, got[i].IsWritable, "account %d writable", i)
+		require.Equal(t, w.signer, got[i].IsSigner, "account %d signer", i)
+	}
+}
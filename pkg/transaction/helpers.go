@@ -1,53 +1,67 @@
 package transaction
 
 import (
-	"context"
 	"fmt"
 	"time"
 
-	ag_binary "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
-	"github.com/gagliardetto/solana-go/rpc"
 
 	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
 )
 
-// fetchMultisigAccount fetches and decodes a multisig account
-func fetchMultisigAccount(client *rpc.Client, multisigPDA solana.PublicKey) (*squads_multisig_program.Multisig, error) {
-	accountInfo, err := client.GetAccountInfo(context.Background(), multisigPDA)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get multisig account: %w", err)
+// hasPermission reports whether key is a member of the multisig with the given permission bit
+func hasPermission(multisigAccount *squads_multisig_program.Multisig, key solana.PublicKey, permission uint8) bool {
+	for _, member := range multisigAccount.Members {
+		if member.Key.Equals(key) && member.Permissions.Mask&permission != 0 {
+			return true
+		}
 	}
+	return false
+}
 
-	var multisigAccount squads_multisig_program.Multisig
-	decoder := ag_binary.NewBorshDecoder(accountInfo.Value.Data.GetBinary())
-	err = multisigAccount.UnmarshalWithDecoder(decoder)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode multisig account: %w", err)
+// containsKey reports whether keys contains key
+func containsKey(keys []solana.PublicKey, key solana.PublicKey) bool {
+	for _, k := range keys {
+		if k.Equals(key) {
+			return true
+		}
 	}
-
-	return &multisigAccount, nil
+	return false
 }
 
-// fetchProposalAccount fetches and decodes a proposal account
-func fetchProposalAccount(client *rpc.Client, proposalPDA solana.PublicKey) (*squads_multisig_program.Proposal, error) {
-	accountInfo, err := client.GetAccountInfo(context.Background(), proposalPDA)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get proposal account: %w", err)
+// RejectionCutoff returns how many rejections make a proposal Rejected: once
+// more than the number of voters minus the threshold have rejected, the
+// remaining voters can no longer reach the threshold.
+func RejectionCutoff(multisigAccount *squads_multisig_program.Multisig) int {
+	voters := 0
+	for _, member := range multisigAccount.Members {
+		if member.Permissions.Mask&multisig.PermissionVote != 0 {
+			voters++
+		}
 	}
+	return voters - int(multisigAccount.Threshold) + 1
+}
 
-	var proposalAccount squads_multisig_program.Proposal
-	decoder := ag_binary.NewBorshDecoder(accountInfo.Value.Data.GetBinary())
-	err = proposalAccount.UnmarshalWithDecoder(decoder)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode proposal account: %w", err)
+// checkExecutable returns an error unless the proposal is approved and the
+// multisig's time lock has elapsed since the approval.
+func checkExecutable(multisigAccount *squads_multisig_program.Multisig, proposal *squads_multisig_program.Proposal) error {
+	approved, ok := proposal.Status.(*squads_multisig_program.ProposalStatusApproved)
+	if !ok {
+		return fmt.Errorf("proposal is not in approved state, current status: %s",
+			DescribeProposalStatus(proposal.Status))
 	}
-
-	return &proposalAccount, nil
+	timelockEnd := time.Unix(approved.Timestamp, 0).Add(time.Duration(multisigAccount.TimeLock) * time.Second)
+	if multisigAccount.TimeLock > 0 && time.Now().Before(timelockEnd) {
+		return fmt.Errorf("timelock has not elapsed yet. Executable after: %s",
+			timelockEnd.Format("2006-01-02 15:04:05"))
+	}
+	return nil
 }
 
-// getProposalStatusString returns a human-readable string for a proposal status
-func getProposalStatusString(status squads_multisig_program.ProposalStatus) string {
+// DescribeProposalStatus returns a human-readable string for a proposal
+// status, with the time it was entered
+func DescribeProposalStatus(status squads_multisig_program.ProposalStatus) string {
 	switch status.(type) {
 	case *squads_multisig_program.ProposalStatusDraft:
 		draft := status.(*squads_multisig_program.ProposalStatusDraft)
@@ -0,0 +1,160 @@
+package transaction
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// InstructionJSON is the JSON form of an instruction accepted by
+// ParseInstructionsJSON.
+type InstructionJSON struct {
+	ProgramID string            `json:"programId"`
+	Accounts  []AccountMetaJSON `json:"accounts"`
+	// Data is base64 by default; a 0x prefix or Encoding "hex" selects hex.
+	Data     string `json:"data"`
+	Encoding string `json:"encoding,omitempty"` // "base64" or "hex"
+}
+
+// AccountMetaJSON is the JSON form of one instruction account.
+type AccountMetaJSON struct {
+	Pubkey     string `json:"pubkey"`
+	IsSigner   bool   `json:"isSigner"`
+	IsWritable bool   `json:"isWritable"`
+}
+
+// ParseInstructionsJSON decodes a JSON array of InstructionJSON into
+// instructions.
+func ParseInstructionsJSON(data []byte) ([]solana.Instruction, error) {
+	var parsed []InstructionJSON
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse instructions: %w", err)
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("no instructions found")
+	}
+
+	instructions := make([]solana.Instruction, 0, len(parsed))
+	for i, ix := range parsed {
+		programID, err := solana.PublicKeyFromBase58(ix.ProgramID)
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d: invalid programId %q: %w", i, ix.ProgramID, err)
+		}
+		accounts := make(solana.AccountMetaSlice, 0, len(ix.Accounts))
+		for j, account := range ix.Accounts {
+			pubkey, err := solana.PublicKeyFromBase58(account.Pubkey)
+			if err != nil {
+				return nil, fmt.Errorf("instruction %d account %d: invalid pubkey %q: %w", i, j, account.Pubkey, err)
+			}
+			accounts = append(accounts, solana.NewAccountMeta(pubkey, account.IsWritable, account.IsSigner))
+		}
+		ixData, err := decodeInstructionData(ix.Data, ix.Encoding)
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d: %w", i, err)
+		}
+		instructions = append(instructions, solana.NewInstruction(programID, accounts, ixData))
+	}
+	return instructions, nil
+}
+
+// ParseBatchJSON decodes a JSON array of transactions, each a JSON array of
+// InstructionJSON as taken by ParseInstructionsJSON.
+func ParseBatchJSON(data []byte) ([][]solana.Instruction, error) {
+	var parsed []json.RawMessage
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse transactions: %w", err)
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("no transactions found")
+	}
+
+	transactions := make([][]solana.Instruction, 0, len(parsed))
+	for i, raw := range parsed {
+		instructions, err := ParseInstructionsJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i+1, err)
+		}
+		transactions = append(transactions, instructions)
+	}
+	return transactions, nil
+}
+
+func decodeInstructionData(data, encoding string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "":
+		if strings.HasPrefix(data, "0x") {
+			return decodeInstructionData(data, "hex")
+		}
+		return decodeInstructionData(data, "base64")
+	case "hex":
+		decoded, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex data: %w", err)
+		}
+		return decoded, nil
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 data: %w", err)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("unknown data encoding %q (base64 or hex)", encoding)
+	}
+}
+
+// InstructionsFromTransaction extracts the instructions of a transaction
+// built by another tool, such as one with the vault as signer. Signatures
+// and the fee payer are dropped; the fee payer only remains if an
+// instruction uses it. Address lookups must be resolved beforehand with
+// Message.SetAddressTables.
+func InstructionsFromTransaction(tx *solana.Transaction) ([]solana.Instruction, error) {
+	metas, err := tx.Message.AccountMetaList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve transaction accounts: %w", err)
+	}
+
+	instructions := make([]solana.Instruction, 0, len(tx.Message.Instructions))
+	for i, compiled := range tx.Message.Instructions {
+		if int(compiled.ProgramIDIndex) >= len(metas) {
+			return nil, fmt.Errorf("instruction %d: program index %d out of range", i, compiled.ProgramIDIndex)
+		}
+		accounts := make(solana.AccountMetaSlice, 0, len(compiled.Accounts))
+		for _, index := range compiled.Accounts {
+			if int(index) >= len(metas) {
+				return nil, fmt.Errorf("instruction %d: account index %d out of range", i, index)
+			}
+			meta := metas[index]
+			accounts = append(accounts, solana.NewAccountMeta(meta.PublicKey, meta.IsWritable, meta.IsSigner))
+		}
+		instructions = append(instructions, solana.NewInstruction(metas[compiled.ProgramIDIndex].PublicKey, accounts, compiled.Data))
+	}
+	if len(instructions) == 0 {
+		return nil, errors.New("transaction has no instructions")
+	}
+	return instructions, nil
+}
+
+// ValidateVaultSigners checks that every account the instructions require to
+// sign is the vault or one of the ephemeral signers; the vault transaction
+// cannot provide any other signature.
+func ValidateVaultSigners(instructions []solana.Instruction, vaultPDA solana.PublicKey, ephemeralSigners []solana.PublicKey) error {
+	allowed := map[solana.PublicKey]bool{vaultPDA: true}
+	for _, ephemeralSigner := range ephemeralSigners {
+		allowed[ephemeralSigner] = true
+	}
+	for i, ix := range instructions {
+		for _, account := range ix.Accounts() {
+			if account.IsSigner && !allowed[account.PublicKey] {
+				return fmt.Errorf("instruction %d requires a signature from %s; only the vault %s and ephemeral signers can sign",
+					i, account.PublicKey, vaultPDA)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInstructionsJSON(t *testing.T) {
+	program, vault, recipient := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	instructions, err := ParseInstructionsJSON([]byte(`[
+		{"programId": "` + program.String() + `", "accounts": [
+			{"pubkey": "` + vault.String() + `", "isSigner": true, "isWritable": true},
+			{"pubkey": "` + recipient.String() + `", "isSigner": false, "isWritable": false}
+		], "data": "AQID"},
+		{"programId": "` + program.String() + `", "accounts": [], "data": "0x0a0b"},
+		{"programId": "` + program.String() + `", "accounts": [], "data": "0c0d", "encoding": "hex"}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, instructions, 3)
+	require.Equal(t, program, instructions[0].ProgramID())
+	accounts := instructions[0].Accounts()
+	require.True(t, accounts[0].IsSigner && accounts[0].IsWritable)
+	require.False(t, accounts[1].IsSigner || accounts[1].IsWritable)
+	for i, want := range [][]byte{{1, 2, 3}, {0x0a, 0x0b}, {0x0c, 0x0d}} {
+		data, err := instructions[i].Data()
+		require.NoError(t, err)
+		require.Equal(t, want, data)
+	}
+
+	for name, input := range map[string]string{
+		"empty":            `[]`,
+		"bad program":      `[{"programId": "nope", "accounts": [], "data": ""}]`,
+		"bad hex":          `[{"programId": "` + program.String() + `", "accounts": [], "data": "0xzz"}]`,
+		"unknown encoding": `[{"programId": "` + program.String() + `", "accounts": [], "data": "", "encoding": "base58"}]`,
+	} {
+		_, err := ParseInstructionsJSON([]byte(input))
+		require.Error(t, err, name)
+	}
+}
+
+func TestInstructionsFromTransaction(t *testing.T) {
+	feePayer, vault, recipient := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	transfer := system.NewTransferInstruction(5, vault, recipient).Build()
+
+	tx, err := solana.NewTransaction([]solana.Instruction{transfer}, solana.Hash{}, solana.TransactionPayer(feePayer))
+	require.NoError(t, err)
+	encoded, err := tx.ToBase64()
+	require.NoError(t, err)
+	decoded, err := solana.TransactionFromBase64(encoded)
+	require.NoError(t, err)
+
+	instructions, err := InstructionsFromTransaction(decoded)
+	require.NoError(t, err)
+	require.Len(t, instructions, 1)
+	require.Equal(t, solana.SystemProgramID, instructions[0].ProgramID())
+	accounts := instructions[0].Accounts()
+	require.Len(t, accounts, 2, "the fee payer is dropped")
+	require.Equal(t, vault, accounts[0].PublicKey)
+	require.True(t, accounts[0].IsSigner && accounts[0].IsWritable)
+	require.Equal(t, recipient, accounts[1].PublicKey)
+	require.True(t, !accounts[1].IsSigner && accounts[1].IsWritable)
+	data, err := instructions[0].Data()
+	require.NoError(t, err)
+	wantData, err := transfer.Data()
+	require.NoError(t, err)
+	require.Equal(t, wantData, data)
+
+	require.NoError(t, ValidateVaultSigners(instructions, vault, nil))
+}
+
+func TestValidateVaultSigners(t *testing.T) {
+	vault, ephemeral, other := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+	ix := func(signer solana.PublicKey) solana.Instruction {
+		return solana.NewInstruction(solana.SystemProgramID, solana.AccountMetaSlice{solana.NewAccountMeta(signer, true, true)}, nil)
+	}
+
+	require.NoError(t, ValidateVaultSigners([]solana.Instruction{ix(vault), ix(ephemeral)}, vault, []solana.PublicKey{ephemeral}))
+
+	err := ValidateVaultSigners([]solana.Instruction{ix(vault), ix(other)}, vault, []solana.PublicKey{ephemeral})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "instruction 1 requires a signature from "+other.String())
+}
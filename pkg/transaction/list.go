@@ -0,0 +1,277 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+// DefaultProposalLimit is the page size of ListProposals when none is given.
+const DefaultProposalLimit = 50
+
+// ProposalState is the lifecycle stage of a proposal.
+type ProposalState string
+
+const (
+	StateDraft     ProposalState = "draft"
+	StateActive    ProposalState = "active"
+	StateRejected  ProposalState = "rejected"
+	StateApproved  ProposalState = "approved"
+	StateExecuting ProposalState = "executing"
+	StateExecuted  ProposalState = "executed"
+	StateCancelled ProposalState = "cancelled"
+)
+
+// StateOf returns the state of a proposal status.
+func StateOf(status squads_multisig_program.ProposalStatus) ProposalState {
+	switch status.(type) {
+	case *squads_multisig_program.ProposalStatusDraft:
+		return StateDraft
+	case *squads_multisig_program.ProposalStatusActive:
+		return StateActive
+	case *squads_multisig_program.ProposalStatusRejected:
+		return StateRejected
+	case *squads_multisig_program.ProposalStatusApproved:
+		return StateApproved
+	case *squads_multisig_program.ProposalStatusExecuting:
+		return StateExecuting
+	case *squads_multisig_program.ProposalStatusExecuted:
+		return StateExecuted
+	case *squads_multisig_program.ProposalStatusCancelled:
+		return StateCancelled
+	default:
+		return ""
+	}
+}
+
+// ParseProposalState parses a state name such as "active".
+func ParseProposalState(name string) (ProposalState, error) {
+	switch state := ProposalState(name); state {
+	case StateDraft, StateActive, StateRejected, StateApproved, StateExecuting, StateExecuted, StateCancelled:
+		return state, nil
+	}
+	return "", fmt.Errorf("unknown proposal state %q", name)
+}
+
+// ProposalFilter narrows ListProposals. Zero fields match every proposal.
+type ProposalFilter struct {
+	States   []ProposalState   // match any of these states
+	Proposer *solana.PublicKey // creator of the vault, config or batch transaction
+	Stale    *bool             // true: only stale proposals; false: only live ones
+}
+
+// ListProposalsInput defines input parameters for listing proposals
+type ListProposalsInput struct {
+	// Required inputs
+	Multisig solana.PublicKey
+
+	// Optional inputs
+	From      uint64 // lowest transaction index to scan; defaults to 1
+	To        uint64 // highest transaction index to scan; defaults to the latest
+	Filter    ProposalFilter
+	Limit     int              // most entries returned; defaults to DefaultProposalLimit
+	Cursor    uint64           // NextCursor of the previous page, to continue from there
+	ProgramID solana.PublicKey // defaults to multisig.DefaultProgramID
+
+	sender.Options
+}
+
+// ProposalEntry is one transaction index of a multisig.
+type ProposalEntry struct {
+	TransactionIndex uint64
+	ProposalPDA      solana.PublicKey
+	TransactionPDA   solana.PublicKey
+
+	// Proposal is nil if the transaction has no proposal yet, or it was closed.
+	Proposal *squads_multisig_program.Proposal
+	// Transaction is a *VaultTransaction, *ConfigTransaction or *Batch, or nil
+	// if the transaction account was closed.
+	Transaction interface{}
+	// Stale is true when the index is at or below the multisig's
+	// StaleTransactionIndex: its proposal can no longer be voted on, though
+	// an already approved vault transaction can still be executed.
+	Stale bool
+}
+
+// State returns the proposal state, or "" if there is no proposal.
+func (e ProposalEntry) State() ProposalState {
+	if e.Proposal == nil {
+		return ""
+	}
+	return StateOf(e.Proposal.Status)
+}
+
+// Creator returns the member who created the transaction, or the zero key
+// if the transaction account was closed.
+func (e ProposalEntry) Creator() solana.PublicKey {
+	switch tx := e.Transaction.(type) {
+	case *squads_multisig_program.VaultTransaction:
+		return tx.Creator
+	case *squads_multisig_program.ConfigTransaction:
+		return tx.Creator
+	case *squads_multisig_program.Batch:
+		return tx.Creator
+	}
+	return solana.PublicKey{}
+}
+
+// ProposalPage is one page of ListProposals, newest first.
+type ProposalPage struct {
+	Proposals []ProposalEntry
+	// NextCursor continues the listing when passed as Cursor; 0 means the
+	// scanned range is exhausted.
+	NextCursor uint64
+}
+
+// proposalChunk is how many indices are fetched per getMultipleAccounts call;
+// each index needs its proposal and its transaction account.
+const proposalChunk = accounts.MaxMultipleAccounts / 2
+
+// ListProposals scans the transaction indices of a multisig from newest to
+// oldest, fetching proposals and their transactions in getMultipleAccounts
+// batches, and returns up to Limit entries matching the filter. Indices whose
+// accounts were all closed are skipped.
+func ListProposals(ctx context.Context, input ListProposalsInput) (*ProposalPage, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+	if input.Multisig.IsZero() {
+		return nil, errors.New("multisig address is required")
+	}
+
+	multisigAccount, err := accounts.FetchMultisig(ctx, input.Options, input.Multisig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch multisig account: %w", err)
+	}
+
+	from, to := input.From, input.To
+	if from == 0 {
+		from = 1
+	}
+	if to == 0 || to > multisigAccount.TransactionIndex {
+		to = multisigAccount.TransactionIndex
+	}
+	if input.Cursor != 0 && input.Cursor < to {
+		to = input.Cursor
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = DefaultProposalLimit
+	}
+
+	page := &ProposalPage{}
+	for high := to; high >= from && high > 0; {
+		low := from
+		if high-from >= proposalChunk {
+			low = high - proposalChunk + 1
+		}
+
+		entries, err := fetchProposalRange(ctx, input, multisigAccount, low, high)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !input.Filter.matches(entry) {
+				continue
+			}
+			if len(page.Proposals) == limit {
+				page.NextCursor = entry.TransactionIndex
+				return page, nil
+			}
+			page.Proposals = append(page.Proposals, entry)
+		}
+
+		if low == from {
+			break
+		}
+		high = low - 1
+	}
+	return page, nil
+}
+
+// fetchProposalRange fetches the indices from high down to low.
+func fetchProposalRange(
+	ctx context.Context,
+	input ListProposalsInput,
+	multisigAccount *squads_multisig_program.Multisig,
+	low, high uint64,
+) ([]ProposalEntry, error) {
+	var entries []ProposalEntry
+	var addresses []solana.PublicKey
+	for index := high; index >= low; index-- {
+		proposalPDA, _, err := pda.Proposal(input.Multisig, index, input.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		txPDA, _, err := pda.Transaction(input.Multisig, index, input.ProgramID)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, ProposalEntry{
+			TransactionIndex: index,
+			ProposalPDA:      proposalPDA,
+			TransactionPDA:   txPDA,
+			Stale:            index <= multisigAccount.StaleTransactionIndex,
+		})
+		addresses = append(addresses, proposalPDA, txPDA)
+	}
+
+	fetched, err := accounts.FetchMultiple(ctx, input.Options, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []ProposalEntry
+	for i, entry := range entries {
+		proposalAccount, txAccount := fetched[2*i], fetched[2*i+1]
+		if proposalAccount == nil && txAccount == nil {
+			continue
+		}
+		if proposalAccount != nil {
+			decoded, err := accounts.DecodeAny(proposalAccount.Data.GetBinary())
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode proposal %d: %w", entry.TransactionIndex, err)
+			}
+			proposal, ok := decoded.(*squads_multisig_program.Proposal)
+			if !ok {
+				return nil, fmt.Errorf("proposal %d: account is a %T, not a proposal", entry.TransactionIndex, decoded)
+			}
+			entry.Proposal = proposal
+		}
+		if txAccount != nil {
+			entry.Transaction, err = accounts.DecodeAny(txAccount.Data.GetBinary())
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode transaction %d: %w", entry.TransactionIndex, err)
+			}
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+// matches reports whether entry passes the filter.
+func (f ProposalFilter) matches(entry ProposalEntry) bool {
+	if f.Stale != nil && entry.Stale != *f.Stale {
+		return false
+	}
+	if f.Proposer != nil && !entry.Creator().Equals(*f.Proposer) {
+		return false
+	}
+	if len(f.States) > 0 {
+		state := entry.State()
+		for _, want := range f.States {
+			if state == want {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+	"github.com/hogyzen12/squads-go/internal/rpctest"
+	"github.com/hogyzen12/squads-go/pkg/multisig"
+	"github.com/hogyzen12/squads-go/pkg/pda"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+func TestListProposalsPaging(t *testing.T) {
+	multisigPDA := solana.NewWallet().PublicKey()
+	alice, bob := solana.NewWallet().PublicKey(), solana.NewWallet().PublicKey()
+
+	server := rpctest.NewServer(multisig.DefaultProgramID)
+	server.Add(t, multisigPDA, &squads_multisig_program.Multisig{TransactionIndex: 120, StaleTransactionIndex: 10})
+	for index := uint64(1); index <= 120; index++ {
+		if index == 60 {
+			continue // closed
+		}
+		var status squads_multisig_program.ProposalStatus = &squads_multisig_program.ProposalStatusExecuted{}
+		if index%3 == 0 {
+			status = &squads_multisig_program.ProposalStatusActive{}
+		}
+		creator := alice
+		if index%2 == 0 {
+			creator = bob
+		}
+		proposalPDA, _, err := pda.Proposal(multisigPDA, index, solana.PublicKey{})
+		require.NoError(t, err)
+		txPDA, _, err := pda.Transaction(multisigPDA, index, solana.PublicKey{})
+		require.NoError(t, err)
+		server.Add(t, proposalPDA, &squads_multisig_program.Proposal{Multisig: multisigPDA, TransactionIndex: index, Status: status})
+		server.Add(t, txPDA, &squads_multisig_program.VaultTransaction{Multisig: multisigPDA, Creator: creator, Index: index})
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	input := ListProposalsInput{
+		Multisig: multisigPDA,
+		Options:  sender.Options{Client: rpc.New(httpServer.URL)},
+		Filter:   ProposalFilter{States: []ProposalState{StateActive}},
+		Limit:    15,
+	}
+
+	var seen []uint64
+	for {
+		page, err := ListProposals(context.Background(), input)
+		require.NoError(t, err)
+		for _, entry := range page.Proposals {
+			require.Equal(t, StateActive, entry.State())
+			seen = append(seen, entry.TransactionIndex)
+		}
+		if page.NextCursor == 0 {
+			break
+		}
+		input.Cursor = page.NextCursor
+	}
+	require.Len(t, seen, 39) // multiples of 3 except the closed index 60
+	require.EqualValues(t, 120, seen[0])
+	require.EqualValues(t, 3, seen[len(seen)-1])
+	for i := 1; i < len(seen); i++ {
+		require.Less(t, seen[i], seen[i-1], "newest first without repeats")
+	}
+
+	stale, bobOnly := true, bob
+	calls := server.Calls("getMultipleAccounts")
+	page, err := ListProposals(context.Background(), ListProposalsInput{
+		Multisig: multisigPDA,
+		Options:  sender.Options{Client: rpc.New(httpServer.URL)},
+		Filter:   ProposalFilter{Stale: &stale, Proposer: &bobOnly},
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Proposals, 5) // 2, 4, 6, 8, 10
+	require.Equal(t, 3, server.Calls("getMultipleAccounts")-calls, "120 indices in chunks of 50")
+
+	page, err = ListProposals(context.Background(), ListProposalsInput{
+		Multisig: multisigPDA,
+		Options:  sender.Options{Client: rpc.New(httpServer.URL)},
+		From:     59,
+		To:       61,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Proposals, 2, "closed index 60 is skipped")
+}
@@ -0,0 +1,59 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+
+	"github.com/hogyzen12/squads-go/pkg/accounts"
+	"github.com/hogyzen12/squads-go/pkg/sender"
+)
+
+// addressLookupTableProgramID owns every address lookup table.
+var addressLookupTableProgramID = solana.MustPublicKeyFromBase58("AddressLookupTab1e1111111111111111111111111")
+
+// FetchAddressLookupTables fetches and decodes the given address lookup
+// tables, in order. Deactivated tables are rejected since transactions can
+// no longer load accounts from them.
+func FetchAddressLookupTables(ctx context.Context, opts sender.Options, addresses []solana.PublicKey) ([]addresslookuptable.KeyedAddressLookupTable, error) {
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+	fetched, err := accounts.FetchMultiple(ctx, opts, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch address lookup tables: %w", err)
+	}
+
+	tables := make([]addresslookuptable.KeyedAddressLookupTable, 0, len(addresses))
+	for i, account := range fetched {
+		if account == nil {
+			return nil, fmt.Errorf("address lookup table %s not found", addresses[i])
+		}
+		if !account.Owner.Equals(addressLookupTableProgramID) {
+			return nil, fmt.Errorf("account %s is not an address lookup table (owner %s)", addresses[i], account.Owner)
+		}
+		state, err := addresslookuptable.DecodeAddressLookupTableState(account.Data.GetBinary())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode address lookup table %s: %w", addresses[i], err)
+		}
+		if !state.IsActive() {
+			return nil, fmt.Errorf("address lookup table %s is deactivated", addresses[i])
+		}
+		tables = append(tables, addresslookuptable.KeyedAddressLookupTable{Key: addresses[i], State: *state})
+	}
+	return tables, nil
+}
+
+// AddressTables converts lookup tables into the form sender.Options takes.
+func AddressTables(tables []addresslookuptable.KeyedAddressLookupTable) map[solana.PublicKey]solana.PublicKeySlice {
+	if len(tables) == 0 {
+		return nil
+	}
+	out := make(map[solana.PublicKey]solana.PublicKeySlice, len(tables))
+	for _, table := range tables {
+		out[table.Key] = table.State.Addresses
+	}
+	return out
+}
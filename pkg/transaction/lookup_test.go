@@ -0,0 +1,100 @@
+package transaction
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+)
+
+func newKeys(n int) []solana.PublicKey {
+	keys := make([]solana.PublicKey, n)
+	for i := range keys {
+		keys[i] = solana.NewWallet().PublicKey()
+	}
+	return keys
+}
+
+func TestCreateTransactionMessageBytesWithLookupTable(t *testing.T) {
+	vault := solana.NewWallet().PublicKey()
+	program := solana.NewWallet().PublicKey()
+	accounts := newKeys(3)
+	table := addresslookuptable.KeyedAddressLookupTable{
+		Key:   solana.NewWallet().PublicKey(),
+		State: addresslookuptable.AddressLookupTableState{Addresses: accounts},
+	}
+	ix := solana.NewInstruction(program, solana.AccountMetaSlice{
+		solana.NewAccountMeta(vault, true, true),
+		solana.NewAccountMeta(accounts[0], true, false),
+		solana.NewAccountMeta(accounts[1], false, false),
+	}, []byte{1})
+
+	message := CompileToWrappedMessageV0(vault, solana.Hash{}, []solana.Instruction{ix},
+		[]addresslookuptable.KeyedAddressLookupTable{table})
+	require.Equal(t, []solana.PublicKey{vault, program}, []solana.PublicKey(message.AccountKeys),
+		"table accounts are not static keys")
+	require.Len(t, message.AddressTableLookups, 1)
+	require.Equal(t, table.Key, message.AddressTableLookups[0].AccountKey)
+	require.Equal(t, []uint8{0}, []uint8(message.AddressTableLookups[0].WritableIndexes))
+	require.Equal(t, []uint8{1}, []uint8(message.AddressTableLookups[0].ReadonlyIndexes))
+	require.Equal(t, []uint16{0, 2, 3}, message.Instructions[0].Accounts,
+		"lookup accounts follow the static keys, writable first")
+}
+
+func TestCreateTransactionMessageBytesEncodesLookups(t *testing.T) {
+	vault := solana.NewWallet().PublicKey()
+	accounts := newKeys(2)
+	table := addresslookuptable.KeyedAddressLookupTable{
+		Key:   solana.NewWallet().PublicKey(),
+		State: addresslookuptable.AddressLookupTableState{Addresses: accounts},
+	}
+	ix := solana.NewInstruction(solana.NewWallet().PublicKey(), solana.AccountMetaSlice{
+		solana.NewAccountMeta(vault, true, true),
+		solana.NewAccountMeta(accounts[1], true, false),
+	}, []byte{1})
+
+	data, err := CreateTransactionMessageBytes(vault, []solana.Instruction{ix}, solana.Hash{},
+		[]addresslookuptable.KeyedAddressLookupTable{table})
+	require.NoError(t, err)
+	var message squads_multisig_program.TransactionMessage
+	require.NoError(t, squads_multisig_program.NewDecoder(bytes.NewReader(data)).Decode(&message))
+	require.Len(t, message.AddressTableLookups.Data, 1)
+	require.Equal(t, table.Key, message.AddressTableLookups.Data[0].AccountKey)
+	require.Equal(t, []uint8{1}, message.AddressTableLookups.Data[0].WritableIndexes.Data)
+}
+
+func TestCreateTransactionMessageBytesIsDeterministic(t *testing.T) {
+	vault := solana.NewWallet().PublicKey()
+	tableAccounts := newKeys(6)
+	table := addresslookuptable.KeyedAddressLookupTable{
+		Key:   solana.NewWallet().PublicKey(),
+		State: addresslookuptable.AddressLookupTableState{Addresses: tableAccounts},
+	}
+	var instructions []solana.Instruction
+	for i, recipient := range newKeys(8) {
+		instructions = append(instructions, solana.NewInstruction(solana.NewWallet().PublicKey(), solana.AccountMetaSlice{
+			solana.NewAccountMeta(vault, true, true),
+			solana.NewAccountMeta(recipient, true, false),
+			solana.NewAccountMeta(tableAccounts[5-i%6], i%2 == 0, false),
+		}, []byte{byte(i)}))
+	}
+	tables := []addresslookuptable.KeyedAddressLookupTable{table}
+
+	first, err := CreateTransactionMessageBytes(vault, instructions, solana.Hash{}, tables)
+	require.NoError(t, err)
+	for i := 0; i < 20; i++ {
+		again, err := CreateTransactionMessageBytes(vault, instructions, solana.Hash{}, tables)
+		require.NoError(t, err)
+		require.Equal(t, first, again, "compile %d", i+2)
+	}
+
+	message := CompileToWrappedMessageV0(vault, solana.Hash{}, instructions, tables)
+	require.Equal(t, vault, message.AccountKeys[0], "the vault comes first")
+	require.Equal(t, instructions[0].Accounts()[1].PublicKey, message.AccountKeys[1], "then keys in order of appearance")
+	require.Equal(t, []uint8{1, 3, 5}, []uint8(message.AddressTableLookups[0].WritableIndexes), "table entries in index order")
+	require.Equal(t, []uint8{0, 2, 4}, []uint8(message.AddressTableLookups[0].ReadonlyIndexes))
+}
@@ -0,0 +1,94 @@
+package transaction
+
+import (
+	"bytes"
+
+	"github.com/gagliardetto/solana-go"
+	addresslookuptable "github.com/gagliardetto/solana-go/programs/address-lookup-table"
+
+	"github.com/hogyzen12/squads-go/generated/squads_multisig_program"
+)
+
+func convertToUint8Slice(ints []uint16) []uint8 {
+	result := make([]uint8, len(ints))
+	for i, v := range ints {
+		result[i] = uint8(v)
+	}
+	return result
+}
+
+// CreateTransactionMessageBytes compiles the given instructions into the
+// Squads TransactionMessage format expected by VaultTransactionCreate.
+// The payer is normally the vault PDA that will sign for the inner instructions.
+func CreateTransactionMessageBytes(payer solana.PublicKey, instructions []solana.Instruction, recentBlockhash solana.Hash, addressLookupTableAccounts []addresslookuptable.KeyedAddressLookupTable) ([]byte, error) {
+	// Compile the message to V0 format
+	compiledMessage := CompileToWrappedMessageV0(payer,
+		recentBlockhash,
+		instructions,
+		addressLookupTableAccounts)
+	txMsg := squads_multisig_program.TransactionMessage{
+		NumSigners:            uint8(compiledMessage.Header.NumRequiredSignatures),
+		NumWritableSigners:    uint8(compiledMessage.Header.NumRequiredSignatures - compiledMessage.Header.NumReadonlySignedAccounts),
+		NumWritableNonSigners: uint8(len(compiledMessage.AccountKeys)) - compiledMessage.Header.NumRequiredSignatures - compiledMessage.Header.NumReadonlyUnsignedAccounts,
+		AccountKeys: squads_multisig_program.SmallVec[uint8, solana.PublicKey]{
+			Data: compiledMessage.AccountKeys,
+		},
+		Instructions:        squads_multisig_program.SmallVec[uint8, squads_multisig_program.CompiledInstruction]{},
+		AddressTableLookups: squads_multisig_program.SmallVec[uint8, squads_multisig_program.MessageAddressTableLookup]{},
+	}
+	for _, v := range compiledMessage.Instructions {
+		txMsg.Instructions.Data = append(txMsg.Instructions.Data, squads_multisig_program.CompiledInstruction{
+			ProgramIdIndex: uint8(v.ProgramIDIndex),
+			AccountIndexes: squads_multisig_program.SmallVec[uint8, uint8]{Data: convertToUint8Slice(v.Accounts)},
+			Data:           squads_multisig_program.SmallVec[uint16, uint8]{Data: v.Data},
+		})
+	}
+	for _, v := range compiledMessage.AddressTableLookups {
+		txMsg.AddressTableLookups.Data = append(txMsg.AddressTableLookups.Data, squads_multisig_program.MessageAddressTableLookup{
+			AccountKey:      v.AccountKey,
+			WritableIndexes: squads_multisig_program.SmallVec[uint8, uint8]{Data: v.WritableIndexes},
+			ReadonlyIndexes: squads_multisig_program.SmallVec[uint8, uint8]{Data: v.ReadonlyIndexes},
+		})
+	}
+
+	// encode custom
+	buf := new(bytes.Buffer)
+	if err := squads_multisig_program.NewEncoder(buf).Encode(&txMsg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeVaultTransactionMessage encodes a message stored in a vault or batch
+// transaction back into the TransactionMessage bytes it was created from, so
+// it can be compared with CreateTransactionMessageBytes.
+func encodeVaultTransactionMessage(message *squads_multisig_program.VaultTransactionMessage) ([]byte, error) {
+	txMsg := squads_multisig_program.TransactionMessage{
+		NumSigners:            message.NumSigners,
+		NumWritableSigners:    message.NumWritableSigners,
+		NumWritableNonSigners: message.NumWritableNonSigners,
+		AccountKeys: squads_multisig_program.SmallVec[uint8, solana.PublicKey]{
+			Data: message.AccountKeys,
+		},
+	}
+	for _, v := range message.Instructions {
+		txMsg.Instructions.Data = append(txMsg.Instructions.Data, squads_multisig_program.CompiledInstruction{
+			ProgramIdIndex: v.ProgramIdIndex,
+			AccountIndexes: squads_multisig_program.SmallVec[uint8, uint8]{Data: v.AccountIndexes},
+			Data:           squads_multisig_program.SmallVec[uint16, uint8]{Data: v.Data},
+		})
+	}
+	for _, v := range message.AddressTableLookups {
+		txMsg.AddressTableLookups.Data = append(txMsg.AddressTableLookups.Data, squads_multisig_program.MessageAddressTableLookup{
+			AccountKey:      v.AccountKey,
+			WritableIndexes: squads_multisig_program.SmallVec[uint8, uint8]{Data: v.WritableIndexes},
+			ReadonlyIndexes: squads_multisig_program.SmallVec[uint8, uint8]{Data: v.ReadonlyIndexes},
+		})
+	}
+
+	buf := new(bytes.Buffer)
+	if err := squads_multisig_program.NewEncoder(buf).Encode(&txMsg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}